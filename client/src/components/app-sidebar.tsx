@@ -10,7 +10,7 @@ import {
   SidebarMenuItem,
 } from "@/components/ui/sidebar";
 import { Link } from "@tanstack/react-router";
-import { getRoleFromAccessTokenCookie } from "@/lib/JwtDecode";
+import { getRoleFromAccessTokenCookie, isAdminRole } from "@/lib/JwtDecode";
 import { UserMenu } from "@/components/UserMenu";
 
 // Menu items.
@@ -57,7 +57,11 @@ export function AppSidebar() {
   const role =
     typeof document !== "undefined" ? getRoleFromAccessTokenCookie() : null;
 
-  const filteredItems = role ? items.filter((item) => item.role === role) : [];
+  // Super admins share the admin menu
+  const menuRole = isAdminRole(role) ? "admin" : role;
+  const filteredItems = menuRole
+    ? items.filter((item) => item.role === menuRole)
+    : [];
 
   return (
     <>
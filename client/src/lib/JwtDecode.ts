@@ -40,6 +40,14 @@ export function getRoleFromAccessTokenCookie(): string | null {
   return payload?.role ?? null;
 }
 
+/**
+ * Whether the role may use the admin pages. Super admins manage every
+ * university, university admins only their own.
+ */
+export function isAdminRole(role: string | null | undefined): boolean {
+  return role === "admin" || role === "super_admin";
+}
+
 /**
  * Get the 'name' from access_token cookie.
  */
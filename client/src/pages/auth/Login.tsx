@@ -7,6 +7,7 @@ import { Button } from "@/components/ui/button";
 import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
 import { setCookie } from "@/utils/cookies";
+import { isAdminRole } from "@/lib/JwtDecode";
 import { Link, useNavigate } from "@tanstack/react-router";
 
 // API Response wrapper type
@@ -45,7 +46,7 @@ const LoginForm = () => {
       }
 
       // Navigate based on role
-      if (payload.role === "admin") {
+      if (isAdminRole(payload.role)) {
         navigate({ to: "/admin/dashboard" });
       } else if (payload.role === "student") {
         navigate({ to: "/student/dashboard" });
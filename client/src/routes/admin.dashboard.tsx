@@ -5,7 +5,7 @@ import {
   BreadcrumbList,
 } from "@/components/ui/breadcrumb";
 import { createFileRoute, redirect } from "@tanstack/react-router";
-import { getRoleFromAccessTokenCookie } from "@/lib/JwtDecode";
+import { getRoleFromAccessTokenCookie, isAdminRole } from "@/lib/JwtDecode";
 import DashboardAdmin from "@/pages/admin/Dashboard";
 
 export const Route = createFileRoute("/admin/dashboard")({
@@ -14,7 +14,7 @@ export const Route = createFileRoute("/admin/dashboard")({
     if (!role) {
       throw redirect({ to: "/gate/login" });
     }
-    if (role !== "admin") {
+    if (!isAdminRole(role)) {
       throw redirect({ to: "/student/dashboard" });
     }
     return null;
@@ -5,7 +5,7 @@ import {
   BreadcrumbList,
   BreadcrumbSeparator,
 } from "@/components/ui/breadcrumb";
-import { getRoleFromAccessTokenCookie } from "@/lib/JwtDecode";
+import { getRoleFromAccessTokenCookie, isAdminRole } from "@/lib/JwtDecode";
 import Statistic from "@/pages/admin/Statistic";
 import { createFileRoute, redirect } from "@tanstack/react-router";
 
@@ -15,7 +15,7 @@ export const Route = createFileRoute("/admin/statistics")({
     if (!role) {
       throw redirect({ to: "/gate/login" });
     }
-    if (role !== "admin") {
+    if (!isAdminRole(role)) {
       throw redirect({ to: "/student/dashboard" });
     }
     return null;
@@ -1,12 +1,12 @@
 import { createFileRoute, redirect } from "@tanstack/react-router";
 import LoginForm from "@/pages/auth/Login";
-import { getRoleFromAccessTokenCookie } from "@/lib/JwtDecode";
+import { getRoleFromAccessTokenCookie, isAdminRole } from "@/lib/JwtDecode";
 import NotFound from "@/components/NotFound";
 
 export const Route = createFileRoute("/gate/login")({
   loader: async () => {
     const role = getRoleFromAccessTokenCookie();
-    if (role === "admin") {
+    if (isAdminRole(role)) {
       throw redirect({ to: "/admin/dashboard" });
     }
     if (role === "student") {
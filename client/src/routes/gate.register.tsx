@@ -1,11 +1,11 @@
-import { getRoleFromAccessTokenCookie } from "@/lib/JwtDecode";
+import { getRoleFromAccessTokenCookie, isAdminRole } from "@/lib/JwtDecode";
 import { Register } from "@/pages/auth/Register";
 import { createFileRoute, redirect } from "@tanstack/react-router";
 
 export const Route = createFileRoute("/gate/register")({
     loader: async () => {
       const role = getRoleFromAccessTokenCookie();
-      if (role === "admin") {
+      if (isAdminRole(role)) {
         throw redirect({ to: "/admin/dashboard" });
       }
       if (role === "student") {
@@ -1,5 +1,5 @@
 import { Button } from "@/components/ui/button";
-import { getRoleFromAccessTokenCookie } from "@/lib/JwtDecode";
+import { getRoleFromAccessTokenCookie, isAdminRole } from "@/lib/JwtDecode";
 import { createFileRoute, redirect } from "@tanstack/react-router";
 
 export const Route = createFileRoute("/")({
@@ -11,7 +11,7 @@ export const Route = createFileRoute("/")({
     if (!role) {
       throw redirect({ to: "/gate/login" });
     }
-    if (role === "admin") {
+    if (isAdminRole(role)) {
       throw redirect({ to: "/admin/dashboard" });
     }
     if (role === "student") {
@@ -38,7 +38,7 @@ export interface ApiResponse<T> {
 export interface JwtPayload {
   user_id: number;
   username: string;
-  role: 'admin' | 'student';
+  role: 'super_admin' | 'admin' | 'lecturer' | 'advisor' | 'student';
   university_id: number;
   university_name: string;
   exp: number;
@@ -34,6 +34,10 @@ func main() {
 		logrus.Fatalf("Migration failed: %v", err)
 	}
 
+	if err := models.MigrateTenancy(db); err != nil {
+		logrus.Fatalf("Migrasi data universitas gagal: %v", err)
+	}
+
 	// Jalankan seeder admin user
 	if err := models.SeederAdminUser(db); err != nil {
 		logrus.Fatalf("Seeder admin gagal: %v", err)
@@ -15,9 +15,76 @@ type Config struct {
 	DBName       string
 	DBSchema     string
 	AppPort      string
+	AppEnv       string
 	JWTSecret    string
 	JWTExpire    string
 	JWTAlgorithm string
+
+	// JWT: HS256 memakai JWTSecret, RS256/EdDSA memakai file private key PEM.
+	// JWTPreviousKeys berisi "kid:algoritma:secret-atau-file" dipisah koma untuk
+	// kunci lama yang masih diterima sampai token-tokennya kedaluwarsa.
+	JWTRefreshExpire  string
+	JWTKeyID          string
+	JWTPrivateKeyFile string
+	JWTPreviousKeys   string
+	JWTIssuer         string
+	JWTAudience       string
+
+	// Keamanan login: batas percobaan gagal per akun dan per IP dalam
+	// LoginAttemptWindow sebelum dikunci selama LoginLockout
+	LoginMaxAttempts   string
+	LoginIPMaxAttempts string
+	LoginAttemptWindow string
+	LoginLockout       string
+
+	// Kebijakan password: panjang minimal dan jenis karakter yang wajib ada
+	PasswordMinLength     string
+	PasswordRequireUpper  string
+	PasswordRequireLower  string
+	PasswordRequireDigit  string
+	PasswordRequireSymbol string
+
+	// Reset password: umur token, alamat halaman reset yang dikirim lewat email
+	// dan batas permintaan per jam untuk tiap email dan tiap IP
+	PasswordResetExpire      string
+	PasswordResetURL         string
+	PasswordResetMaxPerEmail string
+	PasswordResetMaxPerIP    string
+
+	// Early-warning: skor di bawah AlertScoreThreshold atau turun lebih dari
+	// AlertDropThreshold poin sejak assessment terakhir membuka alert
+	AlertScoreThreshold string
+	AlertDropThreshold  string
+	AlertMethod         string
+
+	// Webhook: jumlah percobaan pengiriman, jeda awal backoff, timeout per request
+	// dan interval pemeriksaan antrean retry
+	WebhookMaxAttempts  string
+	WebhookBackoff      string
+	WebhookTimeout      string
+	WebhookPollInterval string
+
+	// Email: server SMTP, atau direktori tujuan file .eml pada mode development
+	SMTPHost          string
+	SMTPPort          string
+	SMTPUsername      string
+	SMTPPassword      string
+	SMTPFrom          string
+	EmailDevDir       string
+	EmailMaxAttempts  string
+	EmailBackoff      string
+	EmailPollInterval string
+
+	// Presensi: bobot tiap status kehadiran (0-1) dalam attendance rate
+	AttendanceWeightPresent string
+	AttendanceWeightLate    string
+	AttendanceWeightExcused string
+	AttendanceWeightAbsent  string
+
+	// Impor dataset: batas ukuran file CSV dalam MB dan satu-satunya direktori
+	// server yang boleh dibaca lewat file_path (kosong menonaktifkan mode path)
+	DatasetMaxUploadMB string
+	DatasetImportDir   string
 }
 
 func LoadConfig() *Config {
@@ -33,8 +100,59 @@ func LoadConfig() *Config {
 		DBName:       os.Getenv("BLUEPRINT_DB_DATABASE"),
 		DBSchema:     os.Getenv("BLUEPRINT_DB_SCHEMA"),
 		AppPort:      os.Getenv("PORT"),
+		AppEnv:       os.Getenv("APP_ENV"),
 		JWTSecret:    os.Getenv("JWT_SECRET"),
 		JWTExpire:    os.Getenv("JWT_EXPIRE"),
 		JWTAlgorithm: os.Getenv("JWT_ALGORITHM"),
+
+		JWTRefreshExpire:  os.Getenv("JWT_REFRESH_EXPIRE"),
+		JWTKeyID:          os.Getenv("JWT_KEY_ID"),
+		JWTPrivateKeyFile: os.Getenv("JWT_PRIVATE_KEY_FILE"),
+		JWTPreviousKeys:   os.Getenv("JWT_PREVIOUS_KEYS"),
+		JWTIssuer:         os.Getenv("JWT_ISSUER"),
+		JWTAudience:       os.Getenv("JWT_AUDIENCE"),
+
+		LoginMaxAttempts:   os.Getenv("LOGIN_MAX_ATTEMPTS"),
+		LoginIPMaxAttempts: os.Getenv("LOGIN_IP_MAX_ATTEMPTS"),
+		LoginAttemptWindow: os.Getenv("LOGIN_ATTEMPT_WINDOW"),
+		LoginLockout:       os.Getenv("LOGIN_LOCKOUT"),
+
+		PasswordMinLength:     os.Getenv("PASSWORD_MIN_LENGTH"),
+		PasswordRequireUpper:  os.Getenv("PASSWORD_REQUIRE_UPPER"),
+		PasswordRequireLower:  os.Getenv("PASSWORD_REQUIRE_LOWER"),
+		PasswordRequireDigit:  os.Getenv("PASSWORD_REQUIRE_DIGIT"),
+		PasswordRequireSymbol: os.Getenv("PASSWORD_REQUIRE_SYMBOL"),
+
+		PasswordResetExpire:      os.Getenv("PASSWORD_RESET_EXPIRE"),
+		PasswordResetURL:         os.Getenv("PASSWORD_RESET_URL"),
+		PasswordResetMaxPerEmail: os.Getenv("PASSWORD_RESET_MAX_PER_EMAIL"),
+		PasswordResetMaxPerIP:    os.Getenv("PASSWORD_RESET_MAX_PER_IP"),
+
+		AlertScoreThreshold: os.Getenv("ALERT_SCORE_THRESHOLD"),
+		AlertDropThreshold:  os.Getenv("ALERT_DROP_THRESHOLD"),
+		AlertMethod:         os.Getenv("ALERT_METHOD"),
+
+		WebhookMaxAttempts:  os.Getenv("WEBHOOK_MAX_ATTEMPTS"),
+		WebhookBackoff:      os.Getenv("WEBHOOK_BACKOFF"),
+		WebhookTimeout:      os.Getenv("WEBHOOK_TIMEOUT"),
+		WebhookPollInterval: os.Getenv("WEBHOOK_POLL_INTERVAL"),
+
+		SMTPHost:          os.Getenv("SMTP_HOST"),
+		SMTPPort:          os.Getenv("SMTP_PORT"),
+		SMTPUsername:      os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:      os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:          os.Getenv("SMTP_FROM"),
+		EmailDevDir:       os.Getenv("EMAIL_DEV_DIR"),
+		EmailMaxAttempts:  os.Getenv("EMAIL_MAX_ATTEMPTS"),
+		EmailBackoff:      os.Getenv("EMAIL_BACKOFF"),
+		EmailPollInterval: os.Getenv("EMAIL_POLL_INTERVAL"),
+
+		AttendanceWeightPresent: os.Getenv("ATTENDANCE_WEIGHT_PRESENT"),
+		AttendanceWeightLate:    os.Getenv("ATTENDANCE_WEIGHT_LATE"),
+		AttendanceWeightExcused: os.Getenv("ATTENDANCE_WEIGHT_EXCUSED"),
+		AttendanceWeightAbsent:  os.Getenv("ATTENDANCE_WEIGHT_ABSENT"),
+
+		DatasetMaxUploadMB: os.Getenv("DATASET_MAX_UPLOAD_MB"),
+		DatasetImportDir:   os.Getenv("DATASET_IMPORT_DIR"),
 	}
 }
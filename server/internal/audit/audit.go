@@ -0,0 +1,246 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Entry describes one change to record. Before is nil for a creation and
+// After is nil for a deletion.
+type Entry struct {
+	Action     string
+	EntityType string
+	EntityID   interface{}
+	Before     interface{}
+	After      interface{}
+	// Partial marks After as a GORM update struct: zero-valued fields were
+	// not written and are left out of the diff
+	Partial bool
+}
+
+// Change is the old and new value of one field
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Store appends entries to the chain. Append must set PrevHash and Hash with
+// Chain while holding a lock that keeps concurrent appends in order. tx is the
+// transaction of the change being recorded; when it is nil the store appends
+// in a transaction of its own.
+type Store interface {
+	Append(ctx context.Context, tx *gorm.DB, entry *models.AuditLog) error
+}
+
+// Recorder writes an audit entry inside the transaction of the change it
+// describes, so the change and its entry are committed or rolled back together.
+// A nil Recorder records nothing.
+type Recorder func(tx *gorm.DB) error
+
+// Run calls the recorder with the transaction unless it is nil
+func (rec Recorder) Run(tx *gorm.DB) error {
+	if rec == nil {
+		return nil
+	}
+	return rec(tx)
+}
+
+// Log records changes made through the API. A nil *Log is valid and drops
+// every entry, which keeps handlers usable in tests.
+type Log struct {
+	store Store
+	now   func() time.Time
+}
+
+// NewLog creates a Log writing to store
+func NewLog(store Store) *Log {
+	return &Log{store: store, now: time.Now}
+}
+
+// Record stores the entry with the caller and IP of the request after the
+// change has been committed. A failure is only logged, so the chain can miss a
+// change that did happen; writes that run in a transaction should pass a
+// Recorder from l.Recorder to their repository instead.
+func (l *Log) Record(r *http.Request, entry Entry) {
+	if l == nil {
+		return
+	}
+	if err := l.append(nil, r, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"action":      entry.Action,
+			"entity_type": entry.EntityType,
+			"entity_id":   entry.EntityID,
+		}).Error("Failed to write audit log")
+	}
+}
+
+// Recorder returns a Recorder that stores the entry with the caller and IP of
+// the request. build runs inside the transaction after the change is written,
+// so IDs generated by the database are known. A nil Log returns a nil Recorder.
+func (l *Log) Recorder(r *http.Request, build func() Entry) Recorder {
+	if l == nil {
+		return nil
+	}
+	return func(tx *gorm.DB) error {
+		return l.append(tx, r, build())
+	}
+}
+
+func (l *Log) append(tx *gorm.DB, r *http.Request, entry Entry) error {
+	changes, err := models.NewJSON(Diff(entry.Before, entry.After, entry.Partial))
+	if err != nil {
+		return fmt.Errorf("encode audit changes: %w", err)
+	}
+	log := &models.AuditLog{
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		Changes:    changes,
+		IP:         middleware.ClientIP(r),
+		// Postgres menyimpan timestamp sampai mikrodetik
+		CreatedAt: l.now().UTC().Truncate(time.Microsecond),
+	}
+	if entry.EntityID != nil {
+		log.EntityID = fmt.Sprint(entry.EntityID)
+	}
+	if caller, ok := middleware.UserFromContext(r.Context()); ok {
+		id := caller.ID
+		log.ActorID = &id
+		log.ActorRole = caller.Role
+	}
+	return l.store.Append(r.Context(), tx, log)
+}
+
+// ignoredFields change on every write and say nothing about the change itself
+var ignoredFields = map[string]bool{"created_at": true, "updated_at": true}
+
+// Diff compares the JSON representation of before and after and returns the
+// fields whose value changed. Fields hidden from JSON, such as password
+// hashes, never appear, and neither do empty relations that were not loaded.
+func Diff(before, after interface{}, partial bool) map[string]Change {
+	old, new := fields(before), fields(after)
+	changes := map[string]Change{}
+	for key, value := range new {
+		if ignoredFields[key] || (isZero(value) && (partial || isZero(old[key]))) {
+			continue
+		}
+		if previous, ok := old[key]; !ok || !reflect.DeepEqual(previous, value) {
+			changes[key] = Change{From: old[key], To: value}
+		}
+	}
+	if after == nil {
+		for key, value := range old {
+			if !ignoredFields[key] {
+				changes[key] = Change{From: value}
+			}
+		}
+	}
+	return changes
+}
+
+func fields(v interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return result
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return result
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		// Nilai yang bukan objek dicatat utuh
+		var value interface{}
+		json.Unmarshal(data, &value)
+		return map[string]interface{}{"value": value}
+	}
+	return result
+}
+
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == "" || strings.HasPrefix(v, "0001-01-01T00:00:00")
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		for _, item := range v {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// hashInput is the canonical form of an entry that the hash covers
+type hashInput struct {
+	PrevHash   string      `json:"prev_hash"`
+	ActorID    *uint       `json:"actor_id"`
+	ActorRole  string      `json:"actor_role"`
+	Action     string      `json:"action"`
+	EntityType string      `json:"entity_type"`
+	EntityID   string      `json:"entity_id"`
+	Changes    interface{} `json:"changes"`
+	IP         string      `json:"ip"`
+	CreatedAt  string      `json:"created_at"`
+}
+
+// Hash computes the hash of the entry chained to its PrevHash. Changes are
+// re-encoded so the jsonb round trip through the database does not alter the
+// result.
+func Hash(entry models.AuditLog) string {
+	var changes interface{}
+	if len(entry.Changes) > 0 {
+		json.Unmarshal(entry.Changes, &changes)
+	}
+	data, _ := json.Marshal(hashInput{
+		PrevHash:   entry.PrevHash,
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Changes:    changes,
+		IP:         entry.IP,
+		CreatedAt:  entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Chain links the entry to the hash of the entry before it
+func Chain(entry *models.AuditLog, prevHash string) {
+	entry.PrevHash = prevHash
+	entry.Hash = Hash(*entry)
+}
+
+// Verify checks that entries, in ID order, continue the chain from prevHash.
+// It returns the ID of the first entry that was altered or whose predecessor
+// is missing, and the hash to continue from with the next batch.
+func Verify(prevHash string, entries []models.AuditLog) (brokenID int, lastHash string) {
+	for _, entry := range entries {
+		if entry.PrevHash != prevHash || Hash(entry) != entry.Hash {
+			return entry.ID, prevHash
+		}
+		prevHash = entry.Hash
+	}
+	return 0, prevHash
+}
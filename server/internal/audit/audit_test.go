@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+)
+
+type sample struct {
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Password  string    `json:"-"`
+	Score     float64   `json:"score"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func TestDiff_ReportsChangedFields(t *testing.T) {
+	before := sample{Name: "Budi", Email: "budi@example.ac.id", Password: "a", Score: 70}
+	after := sample{Name: "Budi", Email: "budi@kampus.ac.id", Password: "b", Score: 80, UpdatedAt: time.Now()}
+
+	changes := Diff(before, after, false)
+	if len(changes) != 2 {
+		t.Fatalf("expected email and score changes, got %v", changes)
+	}
+	if changes["email"].From != "budi@example.ac.id" || changes["email"].To != "budi@kampus.ac.id" {
+		t.Errorf("unexpected email change %+v", changes["email"])
+	}
+	if _, ok := changes["password"]; ok {
+		t.Error("hidden fields must not be recorded")
+	}
+}
+
+func TestDiff_PartialSkipsUnsetFields(t *testing.T) {
+	before := sample{Name: "Budi", Email: "budi@example.ac.id", Score: 70}
+	changes := Diff(before, sample{Score: 75}, true)
+	if len(changes) != 1 || changes["score"].To != float64(75) {
+		t.Errorf("expected only the score change, got %v", changes)
+	}
+}
+
+func TestDiff_CreateAndDelete(t *testing.T) {
+	created := Diff(nil, &sample{Name: "Budi"}, false)
+	if len(created) != 1 || created["name"].From != nil {
+		t.Errorf("unexpected create diff %v", created)
+	}
+
+	deleted := Diff(&sample{Name: "Budi", Score: 70}, nil, false)
+	if len(deleted) != 3 || deleted["score"].From != float64(70) || deleted["score"].To != nil {
+		t.Errorf("unexpected delete diff %v", deleted)
+	}
+}
+
+func TestLog_RecordChainsEntries(t *testing.T) {
+	store := &MemoryStore{}
+	log := NewLog(store)
+
+	req := httptest.NewRequest("PUT", "/users/2", nil)
+	req.RemoteAddr = "10.0.0.5:4321"
+	req = req.WithContext(middleware.WithUser(req.Context(), middleware.AuthUser{ID: 1, Role: "admin"}))
+
+	log.Record(req, Entry{Action: models.AuditCreate, EntityType: models.AuditEntityUser, EntityID: 2, After: sample{Name: "Budi"}})
+	log.Record(req, Entry{Action: models.AuditUpdate, EntityType: models.AuditEntityUser, EntityID: 2, Before: sample{Name: "Budi"}, After: sample{Name: "Budi S."}})
+
+	if len(store.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(store.Entries))
+	}
+	first, second := store.Entries[0], store.Entries[1]
+	if first.ActorID == nil || *first.ActorID != 1 || first.ActorRole != "admin" || first.IP != "10.0.0.5" || first.EntityID != "2" {
+		t.Errorf("unexpected entry %+v", first)
+	}
+	if second.PrevHash != first.Hash {
+		t.Error("second entry must chain to the first")
+	}
+	if brokenID, _ := Verify("", store.Entries); brokenID != 0 {
+		t.Errorf("expected an intact chain, broken at %d", brokenID)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	store := &MemoryStore{}
+	for i := 0; i < 3; i++ {
+		changes, _ := models.NewJSON(map[string]Change{"score": {From: i, To: i + 1}})
+		store.Append(context.Background(), nil, &models.AuditLog{Action: models.AuditUpdate, EntityType: models.AuditEntityGrade, EntityID: "1", Changes: changes, CreatedAt: time.Now().UTC()})
+	}
+
+	tampered := append([]models.AuditLog(nil), store.Entries...)
+	tampered[1].Changes = models.JSON(`{"score":{"from":1,"to":100}}`)
+	if brokenID, _ := Verify("", tampered); brokenID != 2 {
+		t.Errorf("expected the altered entry 2 to break the chain, got %d", brokenID)
+	}
+
+	removed := []models.AuditLog{store.Entries[0], store.Entries[2]}
+	if brokenID, _ := Verify("", removed); brokenID != 3 {
+		t.Errorf("expected the entry after the removed one to break the chain, got %d", brokenID)
+	}
+}
+
+func TestLog_NilIsNoop(t *testing.T) {
+	var log *Log
+	log.Record(httptest.NewRequest("POST", "/users", nil), Entry{Action: models.AuditCreate})
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MemoryStore keeps the chain in memory. It is meant for tests and for
+// running without a database.
+type MemoryStore struct {
+	mu      sync.Mutex
+	Entries []models.AuditLog
+}
+
+// Append chains and stores a copy of the entry; there is no database
+// transaction to join, so tx is ignored
+func (s *MemoryStore) Append(ctx context.Context, tx *gorm.DB, entry *models.AuditLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prevHash := ""
+	if len(s.Entries) > 0 {
+		prevHash = s.Entries[len(s.Entries)-1].Hash
+	}
+	entry.ID = len(s.Entries) + 1
+	Chain(entry, prevHash)
+	s.Entries = append(s.Entries, *entry)
+	return nil
+}
@@ -1,9 +1,17 @@
 package academic
 
+import "tsukamoto/internal/modules/tren"
+
 // Konsisten semua menggunakan float32 untuk menghindari precision loss
+// AcademicFilter membatasi daftar data akademik; TermID 0 berarti semua term
+type AcademicFilter struct {
+	TermID uint
+}
+
 type CreateAcademicRequest struct {
 	StudentID         uint    `json:"student_id"`
 	UniversityID      uint    `json:"university_id"`
+	TermID            uint    `json:"term_id,omitempty"`
 	CoreCourseAverage float32 `json:"core_course_average"`
 	AttendanceRate    float32 `json:"attendance_rate"`
 	FinalExamScore    float32 `json:"final_exam_score"`
@@ -34,4 +42,21 @@ type AcademicResponse struct {
 type UpdateAcademicResponse struct {
 	Message string           `json:"message"`
 	Data    AcademicResponse `json:"data,omitempty"`
-}
\ No newline at end of file
+}
+// TrendPoint is one term in the trend of a student
+type TrendPoint struct {
+	AcademicID int   `json:"academic_id"`
+	TermID     *uint `json:"term_id"`
+	tren.Point
+}
+
+// TrendResponse is the performance trend of a student across terms
+type TrendResponse struct {
+	StudentID          uint               `json:"student_id"`
+	Method             string             `json:"method"`
+	Series             []TrendPoint       `json:"series"`
+	Slopes             map[string]float64 `json:"slopes"`
+	Transitions        []tren.Transition  `json:"transitions"`
+	Projection         *tren.Projection   `json:"projection"`
+	SkippedAcademicIDs []int              `json:"skipped_academic_ids"`
+}
@@ -2,19 +2,66 @@ package academic
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/asesmen"
+	"tsukamoto/internal/modules/inferensi"
+	"tsukamoto/internal/modules/normalisasi"
+	"tsukamoto/internal/modules/tren"
+	"tsukamoto/internal/tenant"
+	"tsukamoto/internal/utils"
 
 	"github.com/gorilla/mux"
 )
 
 type academicHandler struct {
-	repo AcademicRepository
+	repo  AcademicRepository
+	bus   *events.Bus
+	audit *audit.Log
 }
 
-func NewAcademicHandler(repo AcademicRepository) AcademicHandler {
-	return &academicHandler{repo: repo}
+func NewAcademicHandler(repo AcademicRepository, bus *events.Bus, log *audit.Log) AcademicHandler {
+	return &academicHandler{repo: repo, bus: bus, audit: log}
+}
+
+// parseFilter reads the optional term_id query parameter
+func parseFilter(r *http.Request) (AcademicFilter, bool) {
+	var filter AcademicFilter
+	if value := r.URL.Query().Get("term_id"); value != "" {
+		termID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || termID == 0 {
+			return filter, false
+		}
+		filter.TermID = uint(termID)
+	}
+	return filter, true
+}
+
+// manualProvenance marks typed-in fields as manual on top of the current
+// provenance. With all set every field counts as typed in, otherwise only the
+// non-zero ones that an update actually writes.
+func manualProvenance(current models.StringMap, gpa, cca, attendance, midterm, finalExam float32, all bool) models.StringMap {
+	provenance := models.StringMap{}
+	for field, source := range current {
+		provenance[field] = source
+	}
+	values := map[string]float32{
+		"gpa":                 gpa,
+		"core_course_average": cca,
+		"attendance_rate":     attendance,
+		"midterm_exam_score":  midterm,
+		"final_exam_score":    finalExam,
+	}
+	for field, value := range values {
+		if all || value != 0 {
+			provenance[field] = models.ProvenanceManual
+		}
+	}
+	return provenance
 }
 
 func (h *academicHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -34,24 +81,79 @@ func (h *academicHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Langsung assign tanpa konversi karena sudah sama-sama float32
+	university, err := h.repo.GetUniversityByID(r.Context(), req.UniversityID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Field: "university_id", Message: "University not found"},
+		}, nil)
+		return
+	}
+
+	// Satu data akademik per mahasiswa per term
+	if req.TermID != 0 {
+		if _, err := h.repo.GetTermByID(r.Context(), req.TermID); err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+				{Field: "term_id", Message: "Term not found"},
+			}, nil)
+			return
+		}
+		exists, err := h.repo.ExistsForTerm(r.Context(), req.StudentID, req.TermID)
+		if err != nil {
+			utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{
+				{Message: "Failed to create academic record"},
+			}, nil)
+			return
+		}
+		if exists {
+			utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{
+				{Field: "term_id", Message: "Academic record for this student and term already exists"},
+			}, nil)
+			return
+		}
+	}
+
+	// Validasi dan konversi nilai ke skala model berdasarkan skala universitas
+	input, errs := normalisasi.Normalize(normalisasi.Input{
+		GPA:               float64(req.GPA),
+		CoreCourseAverage: float64(req.CoreCourseAverage),
+		AttendanceRate:    float64(req.AttendanceRate),
+		MidtermExamScore:  float64(req.MidtermExamScore),
+		FinalExamScore:    float64(req.FinalExamScore),
+	}, normalisasi.ScaleFor(university))
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
 	academic := models.Academic{
-		UserID:            req.StudentID,
-		UniversityID:      req.UniversityID,
-		CoreCourseAverage: req.CoreCourseAverage,
-		AttendanceRate:    req.AttendanceRate,
-		FinalExamScore:    req.FinalExamScore,
-		GPA:               req.GPA,
-		MidtermExamScore:  req.MidtermExamScore,
+		UserID:       req.StudentID,
+		UniversityID: req.UniversityID,
 	}
+	if req.TermID != 0 {
+		termID := req.TermID
+		academic.TermID = &termID
+	}
+	input.Apply(&academic)
+	academic.Provenance = manualProvenance(nil, req.GPA, req.CoreCourseAverage, req.AttendanceRate, req.MidtermExamScore, req.FinalExamScore, true)
 
-	if err := h.repo.Create(r.Context(), academic); err != nil {
+	record := h.audit.Recorder(r, func() audit.Entry {
+		return audit.Entry{Action: models.AuditCreate, EntityType: models.AuditEntityAcademic, EntityID: academic.ID, After: academic}
+	})
+	if err := h.repo.Create(r.Context(), &academic, record); err != nil {
+		if errors.Is(err, tenant.ErrOutsideScope) {
+			utils.WriteResponse(w, http.StatusForbidden, []utils.ErrorDetail{
+				{Field: "university_id", Message: "University is outside your scope"},
+			}, nil)
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to create academic record"})
 		return
 	}
 
+	h.bus.Publish(r.Context(), events.AcademicChanged, events.AcademicChangedPayload{UserIDs: []uint{academic.UserID}})
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Academic record created successfully"})
@@ -75,29 +177,66 @@ func (h *academicHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Langsung assign tanpa konversi karena sudah sama-sama float32
-	academic := models.Academic{
-		CoreCourseAverage: req.CoreCourseAverage,
-		AttendanceRate:    req.AttendanceRate,
-		FinalExamScore:    req.FinalExamScore,
-		GPA:               req.GPA,
-		MidtermExamScore:  req.MidtermExamScore,
+	existing, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		if existing == nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Academic not found"})
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to fetch academic record"})
+		return
+	}
+
+	// Validasi dan konversi nilai ke skala model berdasarkan skala universitas
+	input, errs := normalisasi.Normalize(normalisasi.Input{
+		GPA:               float64(req.GPA),
+		CoreCourseAverage: float64(req.CoreCourseAverage),
+		AttendanceRate:    float64(req.AttendanceRate),
+		MidtermExamScore:  float64(req.MidtermExamScore),
+		FinalExamScore:    float64(req.FinalExamScore),
+	}, normalisasi.ScaleFor(&existing.University))
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
 	}
 
-	if err := h.repo.Update(r.Context(), id, academic); err != nil {
+	// Field bernilai nol tidak ikut di-update oleh GORM
+	var academic models.Academic
+	input.Apply(&academic)
+	// Nilai yang diketik menjadi override yang tidak ditimpa perhitungan dari nilai mata kuliah
+	academic.Provenance = manualProvenance(existing.Provenance, req.GPA, req.CoreCourseAverage, req.AttendanceRate, req.MidtermExamScore, req.FinalExamScore, false)
+
+	record := h.audit.Recorder(r, func() audit.Entry {
+		return audit.Entry{Action: models.AuditUpdate, EntityType: models.AuditEntityAcademic, EntityID: id, Before: existing, After: academic, Partial: true}
+	})
+	if err := h.repo.Update(r.Context(), id, academic, record); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to update academic record"})
 		return
 	}
 
+	h.bus.Publish(r.Context(), events.AcademicChanged, events.AcademicChangedPayload{UserIDs: []uint{existing.UserID}})
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Academic record updated successfully"})
 }
 
+// GetAll handles GET /academic?term_id=
 func (h *academicHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	academics, err := h.repo.GetAll(r.Context())
+	filter, ok := parseFilter(r)
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid term ID"})
+		return
+	}
+
+	academics, err := h.repo.GetAll(r.Context(), filter)
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -110,6 +249,8 @@ func (h *academicHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(academics)
 }
 
+// GetByStudentID handles GET /academic/student/:student_id?term_id= and
+// returns the student's history from the most recent term
 func (h *academicHandler) GetByStudentID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	studentID, err := strconv.ParseUint(vars["student_id"], 10, 32)
@@ -120,7 +261,15 @@ func (h *academicHandler) GetByStudentID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	academics, err := h.repo.GetByStudentID(r.Context(), uint(studentID))
+	filter, ok := parseFilter(r)
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid term ID"})
+		return
+	}
+
+	academics, err := h.repo.GetByStudentID(r.Context(), uint(studentID), filter)
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -170,3 +319,86 @@ func (h *academicHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(academic)
 }
+
+// noTermLabel names records stored before terms were introduced
+const noTermLabel = "(no term)"
+
+// TrendByStudentID handles GET /academic/student/:student_id/trend?method= and
+// analyses the student's records from the oldest term to the latest. Records
+// with values outside the model scale are skipped and reported.
+func (h *academicHandler) TrendByStudentID(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.ParseUint(mux.Vars(r)["student_id"], 10, 32)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "student_id", Message: "Invalid student ID"}}, nil)
+		return
+	}
+	method, err := inferensi.ParseMethod(r.URL.Query().Get("method"))
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "method", Message: "Invalid inference method"}}, nil)
+		return
+	}
+
+	academics, err := h.repo.GetByStudentID(r.Context(), uint(studentID), AcademicFilter{})
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Failed to fetch academic records"}}, nil)
+		return
+	}
+	if len(academics) == 0 {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "No academic records found for this student"}}, nil)
+		return
+	}
+
+	response := TrendResponse{
+		StudentID:          uint(studentID),
+		Method:             string(method),
+		Series:             []TrendPoint{},
+		SkippedAcademicIDs: []int{},
+	}
+
+	// GetByStudentID mengembalikan term terbaru lebih dulu
+	var observations []tren.Observation
+	for i := len(academics) - 1; i >= 0; i-- {
+		academic := academics[i]
+		input := normalisasi.FromAcademic(academic)
+		if errs := normalisasi.Validate(input); len(errs) > 0 {
+			response.SkippedAcademicIDs = append(response.SkippedAcademicIDs, academic.ID)
+			continue
+		}
+
+		label := noTermLabel
+		if academic.Term != nil {
+			label = academic.Term.Label()
+		}
+		observations = append(observations, tren.Observation{
+			Label:  label,
+			Inputs: inferensi.DefaultInputs(input.GPA, input.CoreCourseAverage, input.AttendanceRate, input.MidtermExamScore, input.FinalExamScore),
+		})
+		response.Series = append(response.Series, TrendPoint{AcademicID: academic.ID, TermID: academic.TermID})
+	}
+
+	// Model mengikuti universitas pada record terbaru
+	config, err := h.repo.GetFuzzyConfig(r.Context(), academics[0].UniversityID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Failed to load the university's fuzzy model"}}, nil)
+		return
+	}
+	model, err := asesmen.ModelFrom(config)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+		return
+	}
+
+	analysis, err := tren.Analyze(model.System, model.Thresholds, method, observations)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+		return
+	}
+	for i, point := range analysis.Series {
+		response.Series[i].Point = point
+	}
+	response.Slopes = analysis.Slopes
+	response.Transitions = analysis.Transitions
+	response.Projection = analysis.Projection
+
+	utils.WriteResponse(w, http.StatusOK, nil, response)
+}
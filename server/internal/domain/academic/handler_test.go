@@ -9,7 +9,10 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
 
 	"github.com/gorilla/mux"
 )
@@ -83,26 +86,64 @@ func assertJSONResponseFlexible(t *testing.T, w *httptest.ResponseRecorder, expe
 type mockAcademicRepo struct {
 	CreateFn         func(ctx context.Context, academic models.Academic) error
 	UpdateFn         func(ctx context.Context, id int, academic models.Academic) error
-	GetAllFn         func(ctx context.Context) ([]models.Academic, error)
-	GetByStudentIDFn func(ctx context.Context, studentID uint) ([]models.Academic, error)
+	GetAllFn         func(ctx context.Context, filter AcademicFilter) ([]models.Academic, error)
+	GetByStudentIDFn func(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error)
 	GetByIDFn        func(ctx context.Context, id int) (*models.Academic, error)
+	GetUniversityFn  func(ctx context.Context, id uint) (*models.University, error)
+	GetTermFn        func(ctx context.Context, id uint) (*models.Term, error)
+	ExistsForTermFn  func(ctx context.Context, studentID, termID uint) (bool, error)
+	FuzzyConfigFn    func(ctx context.Context, universityID uint) (*models.FuzzyConfig, error)
 }
 
-func (m *mockAcademicRepo) Create(ctx context.Context, academic models.Academic) error {
-	return m.CreateFn(ctx, academic)
+// Create and Update run the recorder after a successful write, like the
+// repository does inside its transaction
+func (m *mockAcademicRepo) Create(ctx context.Context, academic *models.Academic, record audit.Recorder) error {
+	if err := m.CreateFn(ctx, *academic); err != nil {
+		return err
+	}
+	return record.Run(nil)
 }
-func (m *mockAcademicRepo) Update(ctx context.Context, id int, academic models.Academic) error {
-	return m.UpdateFn(ctx, id, academic)
+func (m *mockAcademicRepo) Update(ctx context.Context, id int, academic models.Academic, record audit.Recorder) error {
+	if err := m.UpdateFn(ctx, id, academic); err != nil {
+		return err
+	}
+	return record.Run(nil)
 }
-func (m *mockAcademicRepo) GetAll(ctx context.Context) ([]models.Academic, error) {
-	return m.GetAllFn(ctx)
+func (m *mockAcademicRepo) GetAll(ctx context.Context, filter AcademicFilter) ([]models.Academic, error) {
+	return m.GetAllFn(ctx, filter)
 }
-func (m *mockAcademicRepo) GetByStudentID(ctx context.Context, studentID uint) ([]models.Academic, error) {
-	return m.GetByStudentIDFn(ctx, studentID)
+func (m *mockAcademicRepo) GetByStudentID(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error) {
+	return m.GetByStudentIDFn(ctx, studentID, filter)
 }
 func (m *mockAcademicRepo) GetByID(ctx context.Context, id int) (*models.Academic, error) {
 	return m.GetByIDFn(ctx, id)
 }
+func (m *mockAcademicRepo) GetUniversityByID(ctx context.Context, id uint) (*models.University, error) {
+	return m.GetUniversityFn(ctx, id)
+}
+func (m *mockAcademicRepo) GetTermByID(ctx context.Context, id uint) (*models.Term, error) {
+	return m.GetTermFn(ctx, id)
+}
+func (m *mockAcademicRepo) ExistsForTerm(ctx context.Context, studentID, termID uint) (bool, error) {
+	return m.ExistsForTermFn(ctx, studentID, termID)
+}
+func (m *mockAcademicRepo) GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error) {
+	// Tanpa FuzzyConfigFn universitas memakai model bawaan
+	if m.FuzzyConfigFn == nil {
+		return nil, nil
+	}
+	return m.FuzzyConfigFn(ctx, universityID)
+}
+
+// percentUniversity reports attendance as a percentage on a 4.0 GPA scale
+func percentUniversity(ctx context.Context, id uint) (*models.University, error) {
+	return &models.University{ID: int(id), GPAScale: 4, AttendanceScale: 100, ScoreScale: 100}, nil
+}
+
+// existingAcademic returns a stored record belonging to a university on the model scale
+func existingAcademic(ctx context.Context, id int) (*models.Academic, error) {
+	return &models.Academic{ID: id, University: models.University{ID: 2, GPAScale: 4, AttendanceScale: 1, ScoreScale: 100}}, nil
+}
 
 func TestAcademicHandlerCreate(t *testing.T) {
 	tests := []struct {
@@ -124,6 +165,9 @@ func TestAcademicHandlerCreate(t *testing.T) {
 				MidtermExamScore:  75,
 			},
 			mockCreateFn: func(ctx context.Context, a models.Academic) error {
+				if a.AttendanceRate != 0.9 {
+					return errors.New("attendance was not normalized")
+				}
 				return nil
 			},
 			expectedCode: http.StatusCreated,
@@ -161,8 +205,8 @@ func TestAcademicHandlerCreate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := &mockAcademicRepo{CreateFn: tt.mockCreateFn}
-			handler := NewAcademicHandler(mockRepo)
+			mockRepo := &mockAcademicRepo{CreateFn: tt.mockCreateFn, GetUniversityFn: percentUniversity}
+			handler := NewAcademicHandler(mockRepo, nil, nil)
 
 			var body []byte
 			if s, ok := tt.reqBody.(string); ok {
@@ -182,7 +226,7 @@ func TestAcademicHandlerCreate(t *testing.T) {
 
 func TestAcademicHandlerCreateBadRequest(t *testing.T) {
 	mockRepo := &mockAcademicRepo{}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("POST", academicPath, bytes.NewReader([]byte("invalid json")))
 	w := httptest.NewRecorder()
@@ -196,8 +240,9 @@ func TestAcademicHandlerCreateInternalError(t *testing.T) {
 		CreateFn: func(ctx context.Context, academic models.Academic) error {
 			return errors.New(dbErrorMsg)
 		},
+		GetUniversityFn: percentUniversity,
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	reqBody := CreateAcademicRequest{
 		StudentID:         1,
@@ -216,17 +261,156 @@ func TestAcademicHandlerCreateInternalError(t *testing.T) {
 	assertEqual(t, http.StatusInternalServerError, w.Code, "Create internal error status")
 }
 
+func TestAcademicHandlerCreateValidationErrors(t *testing.T) {
+	mockRepo := &mockAcademicRepo{GetUniversityFn: percentUniversity}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	reqBody := CreateAcademicRequest{
+		StudentID:         1,
+		UniversityID:      2,
+		CoreCourseAverage: 120,
+		AttendanceRate:    90,
+		GPA:               4.5,
+	}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", academicPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+	assertEqual(t, http.StatusBadRequest, w.Code, "Create validation status")
+
+	var resp utils.Response
+	json.NewDecoder(w.Body).Decode(&resp)
+	fields := map[string]bool{}
+	for _, e := range resp.Errors {
+		fields[e.Field] = true
+	}
+	if !fields["gpa"] || !fields["core_course_average"] || len(resp.Errors) != 2 {
+		t.Errorf("expected errors for gpa and core_course_average, got %v", resp.Errors)
+	}
+}
+
+func TestAcademicHandlerCreateUniversityNotFound(t *testing.T) {
+	mockRepo := &mockAcademicRepo{
+		GetUniversityFn: func(ctx context.Context, id uint) (*models.University, error) {
+			return nil, errors.New("university not found")
+		},
+	}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	body, _ := json.Marshal(CreateAcademicRequest{StudentID: 1, UniversityID: 99})
+	req := httptest.NewRequest("POST", academicPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+	assertEqual(t, http.StatusBadRequest, w.Code, "Create university not found status")
+}
+
+func TestAcademicHandlerCreateFiveScale(t *testing.T) {
+	var created models.Academic
+	mockRepo := &mockAcademicRepo{
+		CreateFn: func(ctx context.Context, academic models.Academic) error {
+			created = academic
+			return nil
+		},
+		GetUniversityFn: func(ctx context.Context, id uint) (*models.University, error) {
+			return &models.University{ID: int(id), GPAScale: 5, AttendanceScale: 1, ScoreScale: 100}, nil
+		},
+	}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	body, _ := json.Marshal(CreateAcademicRequest{StudentID: 1, UniversityID: 2, GPA: 4.5, AttendanceRate: 0.8})
+	req := httptest.NewRequest("POST", academicPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+	assertEqual(t, http.StatusCreated, w.Code, "Create five scale status")
+	if created.GPA != 3.6 {
+		t.Errorf("expected GPA 4.5/5 to be stored as 3.6, got %v", created.GPA)
+	}
+}
+
+func TestAcademicHandlerCreateWithTerm(t *testing.T) {
+	var created models.Academic
+	mockRepo := &mockAcademicRepo{
+		CreateFn: func(ctx context.Context, academic models.Academic) error {
+			created = academic
+			return nil
+		},
+		GetUniversityFn: percentUniversity,
+		GetTermFn: func(ctx context.Context, id uint) (*models.Term, error) {
+			return &models.Term{ID: int(id), AcademicYear: "2025/2026", Semester: models.SemesterOdd}, nil
+		},
+		ExistsForTermFn: func(ctx context.Context, studentID, termID uint) (bool, error) {
+			return false, nil
+		},
+	}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	body, _ := json.Marshal(CreateAcademicRequest{StudentID: 1, UniversityID: 2, TermID: 3, GPA: 3})
+	req := httptest.NewRequest("POST", academicPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+	assertEqual(t, http.StatusCreated, w.Code, "Create with term status")
+	if created.TermID == nil || *created.TermID != 3 {
+		t.Errorf("expected record in term 3, got %v", created.TermID)
+	}
+}
+
+func TestAcademicHandlerCreateTermErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		termFn       func(context.Context, uint) (*models.Term, error)
+		existsFn     func(context.Context, uint, uint) (bool, error)
+		expectedCode int
+	}{
+		{
+			name: "term not found",
+			termFn: func(ctx context.Context, id uint) (*models.Term, error) {
+				return nil, errors.New("term not found")
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "duplicate student and term",
+			termFn: func(ctx context.Context, id uint) (*models.Term, error) {
+				return &models.Term{ID: int(id)}, nil
+			},
+			existsFn: func(ctx context.Context, studentID, termID uint) (bool, error) {
+				return true, nil
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockAcademicRepo{GetUniversityFn: percentUniversity, GetTermFn: tt.termFn, ExistsForTermFn: tt.existsFn}
+			handler := NewAcademicHandler(mockRepo, nil, nil)
+
+			body, _ := json.Marshal(CreateAcademicRequest{StudentID: 1, UniversityID: 2, TermID: 3})
+			req := httptest.NewRequest("POST", academicPath, bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler.Create(w, req)
+			assertEqual(t, tt.expectedCode, w.Code, tt.name)
+		})
+	}
+}
+
 func TestAcademicHandlerUpdate(t *testing.T) {
 	mockRepo := &mockAcademicRepo{
 		UpdateFn: func(ctx context.Context, id int, academic models.Academic) error {
 			return nil
 		},
+		GetByIDFn: existingAcademic,
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	reqBody := UpdateAcademicRequest{
 		CoreCourseAverage: 80,
-		AttendanceRate:    90,
+		AttendanceRate:    0.9,
 		FinalExamScore:    85,
 		GPA:               3.5,
 		MidtermExamScore:  75,
@@ -240,9 +424,102 @@ func TestAcademicHandlerUpdate(t *testing.T) {
 	assertEqual(t, http.StatusOK, w.Code, "Update status")
 }
 
+func TestAcademicHandlerUpdatePublishesChange(t *testing.T) {
+	mockRepo := &mockAcademicRepo{
+		UpdateFn: func(ctx context.Context, id int, academic models.Academic) error {
+			return nil
+		},
+		GetByIDFn: func(ctx context.Context, id int) (*models.Academic, error) {
+			academic, _ := existingAcademic(ctx, id)
+			academic.UserID = 5
+			return academic, nil
+		},
+	}
+	bus := events.NewBus()
+	var published []events.AcademicChangedPayload
+	bus.Subscribe(events.AcademicChanged, func(_ context.Context, event events.Event) {
+		published = append(published, event.Payload.(events.AcademicChangedPayload))
+	})
+	handler := NewAcademicHandler(mockRepo, bus, nil)
+
+	body, _ := json.Marshal(UpdateAcademicRequest{GPA: 3.1, AttendanceRate: 0.8, CoreCourseAverage: 70, MidtermExamScore: 65, FinalExamScore: 72})
+	req := httptest.NewRequest("PUT", academicPathID, bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+	assertEqual(t, http.StatusOK, w.Code, "Update status")
+	if len(published) != 1 || len(published[0].UserIDs) != 1 || published[0].UserIDs[0] != 5 {
+		t.Errorf("expected academic change for user 5, got %+v", published)
+	}
+}
+
+func TestAcademicHandlerUpdateRecordsAudit(t *testing.T) {
+	mockRepo := &mockAcademicRepo{
+		UpdateFn: func(ctx context.Context, id int, academic models.Academic) error { return nil },
+		GetByIDFn: func(ctx context.Context, id int) (*models.Academic, error) {
+			academic, _ := existingAcademic(ctx, id)
+			academic.GPA = 3.0
+			return academic, nil
+		},
+	}
+	store := &audit.MemoryStore{}
+	handler := NewAcademicHandler(mockRepo, nil, audit.NewLog(store))
+
+	body, _ := json.Marshal(UpdateAcademicRequest{GPA: 3.5})
+	req := httptest.NewRequest("PUT", academicPathID, bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+	assertEqual(t, http.StatusOK, w.Code, "Update status")
+	if len(store.Entries) != 1 {
+		t.Fatalf("expected one audit entry, got %d", len(store.Entries))
+	}
+	entry := store.Entries[0]
+	if entry.Action != models.AuditUpdate || entry.EntityType != models.AuditEntityAcademic || entry.EntityID != "1" {
+		t.Errorf("unexpected audit entry %+v", entry)
+	}
+	var changes map[string]audit.Change
+	entry.Changes.Decode(&changes)
+	if changes["gpa"].From != 3.0 || changes["gpa"].To != 3.5 {
+		t.Errorf("expected the GPA change, got %+v", changes)
+	}
+	if _, ok := changes["university"]; ok {
+		t.Errorf("relations that were not written should not appear: %+v", changes)
+	}
+}
+
+func TestAcademicHandlerUpdateMarksManualOverrides(t *testing.T) {
+	var updated models.Academic
+	mockRepo := &mockAcademicRepo{
+		UpdateFn: func(ctx context.Context, id int, academic models.Academic) error {
+			updated = academic
+			return nil
+		},
+		GetByIDFn: func(ctx context.Context, id int) (*models.Academic, error) {
+			academic, _ := existingAcademic(ctx, id)
+			academic.Provenance = models.StringMap{"gpa": models.ProvenanceComputed, "final_exam_score": models.ProvenanceComputed}
+			return academic, nil
+		},
+	}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	// Hanya GPA yang diketik ulang
+	body, _ := json.Marshal(UpdateAcademicRequest{GPA: 3.4})
+	req := httptest.NewRequest("PUT", academicPathID, bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+	assertEqual(t, http.StatusOK, w.Code, "Update status")
+	assertEqual(t, models.ProvenanceManual, updated.Source("gpa"), "gpa provenance")
+	assertEqual(t, models.ProvenanceComputed, updated.Source("final_exam_score"), "final exam provenance")
+}
+
 func TestAcademicHandlerUpdateBadID(t *testing.T) {
 	mockRepo := &mockAcademicRepo{}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("PUT", "/academic/abc", nil)
 	req = mux.SetURLVars(req, map[string]string{"id": "abc"})
@@ -254,7 +531,7 @@ func TestAcademicHandlerUpdateBadID(t *testing.T) {
 
 func TestAcademicHandlerUpdateBadRequest(t *testing.T) {
 	mockRepo := &mockAcademicRepo{}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("PUT", academicPathID, bytes.NewReader([]byte("invalid json")))
 	req = mux.SetURLVars(req, map[string]string{"id": "1"})
@@ -264,17 +541,48 @@ func TestAcademicHandlerUpdateBadRequest(t *testing.T) {
 	assertEqual(t, http.StatusBadRequest, w.Code, "Update bad request status")
 }
 
+func TestAcademicHandlerUpdateNotFound(t *testing.T) {
+	mockRepo := &mockAcademicRepo{
+		GetByIDFn: func(ctx context.Context, id int) (*models.Academic, error) {
+			return nil, errors.New("academic record not found")
+		},
+	}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	body, _ := json.Marshal(UpdateAcademicRequest{GPA: 3})
+	req := httptest.NewRequest("PUT", academicPathID, bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+	assertEqual(t, http.StatusNotFound, w.Code, "Update not found status")
+}
+
+func TestAcademicHandlerUpdateValidationError(t *testing.T) {
+	mockRepo := &mockAcademicRepo{GetByIDFn: existingAcademic}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	body, _ := json.Marshal(UpdateAcademicRequest{AttendanceRate: 90})
+	req := httptest.NewRequest("PUT", academicPathID, bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+	assertEqual(t, http.StatusBadRequest, w.Code, "Update validation status")
+}
+
 func TestAcademicHandlerUpdateInternalError(t *testing.T) {
 	mockRepo := &mockAcademicRepo{
 		UpdateFn: func(ctx context.Context, id int, academic models.Academic) error {
 			return errors.New(dbErrorMsg)
 		},
+		GetByIDFn: existingAcademic,
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	reqBody := UpdateAcademicRequest{
 		CoreCourseAverage: 80,
-		AttendanceRate:    90,
+		AttendanceRate:    0.9,
 		FinalExamScore:    85,
 		GPA:               3.5,
 		MidtermExamScore:  75,
@@ -290,11 +598,11 @@ func TestAcademicHandlerUpdateInternalError(t *testing.T) {
 
 func TestAcademicHandlerGetAll(t *testing.T) {
 	mockRepo := &mockAcademicRepo{
-		GetAllFn: func(ctx context.Context) ([]models.Academic, error) {
+		GetAllFn: func(ctx context.Context, filter AcademicFilter) ([]models.Academic, error) {
 			return []models.Academic{{ID: 1}}, nil
 		},
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("GET", academicPath, nil)
 	w := httptest.NewRecorder()
@@ -304,13 +612,33 @@ func TestAcademicHandlerGetAll(t *testing.T) {
 	assertEqual(t, "application/json", w.Header().Get("Content-Type"), "GetAll content-type")
 }
 
+func TestAcademicHandlerGetAllByTerm(t *testing.T) {
+	var got AcademicFilter
+	mockRepo := &mockAcademicRepo{
+		GetAllFn: func(ctx context.Context, filter AcademicFilter) ([]models.Academic, error) {
+			got = filter
+			return []models.Academic{}, nil
+		},
+	}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.GetAll(w, httptest.NewRequest("GET", academicPath+"?term_id=4", nil))
+	assertEqual(t, http.StatusOK, w.Code, "GetAll by term status")
+	assertEqual(t, uint(4), got.TermID, "GetAll term filter")
+
+	w = httptest.NewRecorder()
+	handler.GetAll(w, httptest.NewRequest("GET", academicPath+"?term_id=abc", nil))
+	assertEqual(t, http.StatusBadRequest, w.Code, "GetAll invalid term status")
+}
+
 func TestAcademicHandlerGetAllInternalError(t *testing.T) {
 	mockRepo := &mockAcademicRepo{
-		GetAllFn: func(ctx context.Context) ([]models.Academic, error) {
+		GetAllFn: func(ctx context.Context, filter AcademicFilter) ([]models.Academic, error) {
 			return nil, errors.New(dbErrorMsg)
 		},
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("GET", academicPath, nil)
 	w := httptest.NewRecorder()
@@ -321,11 +649,11 @@ func TestAcademicHandlerGetAllInternalError(t *testing.T) {
 
 func TestAcademicHandlerGetByStudentID(t *testing.T) {
 	mockRepo := &mockAcademicRepo{
-		GetByStudentIDFn: func(ctx context.Context, studentID uint) ([]models.Academic, error) {
+		GetByStudentIDFn: func(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error) {
 			return []models.Academic{{ID: 1, UserID: studentID}}, nil
 		},
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("GET", academicStudentID, nil)
 	req = mux.SetURLVars(req, map[string]string{"student_id": "1"})
@@ -338,7 +666,7 @@ func TestAcademicHandlerGetByStudentID(t *testing.T) {
 
 func TestAcademicHandlerGetByStudentIDBadID(t *testing.T) {
 	mockRepo := &mockAcademicRepo{}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("GET", "/academic/student/abc", nil)
 	req = mux.SetURLVars(req, map[string]string{"student_id": "abc"})
@@ -350,11 +678,11 @@ func TestAcademicHandlerGetByStudentIDBadID(t *testing.T) {
 
 func TestAcademicHandlerGetByStudentIDInternalError(t *testing.T) {
 	mockRepo := &mockAcademicRepo{
-		GetByStudentIDFn: func(ctx context.Context, studentID uint) ([]models.Academic, error) {
+		GetByStudentIDFn: func(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error) {
 			return nil, errors.New(dbErrorMsg)
 		},
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("GET", academicStudentID, nil)
 	req = mux.SetURLVars(req, map[string]string{"student_id": "1"})
@@ -373,7 +701,7 @@ func TestAcademicHandlerGetByID(t *testing.T) {
 			return expectedAcademic, nil
 		},
 	}
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil)
 
 	req := httptest.NewRequest("GET", academicPathID, nil)
 	req = mux.SetURLVars(req, map[string]string{"id": "1"})
@@ -402,3 +730,85 @@ func TestAcademicHandlerGetByID(t *testing.T) {
 		t.Errorf("Expected UserID %d, got %d", expectedAcademic.UserID, actualAcademic.UserID)
 	}
 }
+
+func termAcademic(id int, termID uint, label string, gpa, cca, attendance, midterm, finalExam float32) models.Academic {
+	return models.Academic{
+		ID:                id,
+		UserID:            1,
+		TermID:            &termID,
+		Term:              &models.Term{ID: int(termID), AcademicYear: label, Semester: models.SemesterOdd},
+		GPA:               gpa,
+		CoreCourseAverage: cca,
+		AttendanceRate:    attendance,
+		MidtermExamScore:  midterm,
+		FinalExamScore:    finalExam,
+	}
+}
+
+func TestAcademicHandlerTrendByStudentID(t *testing.T) {
+	mockRepo := &mockAcademicRepo{
+		GetByStudentIDFn: func(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error) {
+			// Term terbaru lebih dulu, seperti repository
+			return []models.Academic{
+				termAcademic(3, 3, "2025/2026", 3.4, 82, 0.9, 80, 85),
+				{ID: 9, UserID: 1, GPA: 7},
+				termAcademic(2, 2, "2024/2025", 2.8, 68, 0.75, 65, 70),
+				termAcademic(1, 1, "2023/2024", 2.2, 55, 0.6, 50, 55),
+			}, nil
+		},
+	}
+	handler := NewAcademicHandler(mockRepo, nil, nil)
+
+	req := httptest.NewRequest("GET", academicStudentID+"/trend", nil)
+	req = mux.SetURLVars(req, map[string]string{"student_id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.TrendByStudentID(w, req)
+	assertEqual(t, http.StatusOK, w.Code, "Trend status")
+
+	var response struct {
+		Data TrendResponse `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	trend := response.Data
+	if len(trend.Series) != 3 || trend.Series[0].AcademicID != 1 || trend.Series[2].Label != "2025/2026 odd" {
+		t.Fatalf("expected chronological series, got %+v", trend.Series)
+	}
+	if len(trend.SkippedAcademicIDs) != 1 || trend.SkippedAcademicIDs[0] != 9 {
+		t.Errorf("expected invalid record 9 to be skipped, got %v", trend.SkippedAcademicIDs)
+	}
+	if trend.Slopes["gpa"] <= 0 || len(trend.Transitions) != 2 || trend.Projection == nil {
+		t.Errorf("unexpected trend %+v", trend)
+	}
+}
+
+func TestAcademicHandlerTrendByStudentIDErrors(t *testing.T) {
+	empty := &mockAcademicRepo{
+		GetByStudentIDFn: func(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error) {
+			return nil, nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		studentID    string
+		expectedCode int
+	}{
+		{"invalid student", academicStudentID + "/trend", "abc", http.StatusBadRequest},
+		{"invalid method", academicStudentID + "/trend?method=fuzzy", "1", http.StatusBadRequest},
+		{"no records", academicStudentID + "/trend", "1", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAcademicHandler(empty, nil, nil)
+			req := mux.SetURLVars(httptest.NewRequest("GET", tt.path, nil), map[string]string{"student_id": tt.studentID})
+			w := httptest.NewRecorder()
+
+			handler.TrendByStudentID(w, req)
+			assertEqual(t, tt.expectedCode, w.Code, tt.name)
+		})
+	}
+}
@@ -3,15 +3,25 @@ package academic
 import (
 	"context"
 	"net/http"
+	"tsukamoto/internal/audit"
 	"tsukamoto/internal/models"
 )
 
 type AcademicRepository interface {
-	Create(ctx context.Context, academic models.Academic) error
-	Update(ctx context.Context, id int, academic models.Academic) error
-	GetAll(ctx context.Context) ([]models.Academic, error)
-	GetByStudentID(ctx context.Context, studentID uint) ([]models.Academic, error)
+	// Create and Update write the audit entry from record in the same
+	// transaction as the record; record may be nil
+	Create(ctx context.Context, academic *models.Academic, record audit.Recorder) error
+	Update(ctx context.Context, id int, academic models.Academic, record audit.Recorder) error
+	// GetAll and GetByStudentID return records from the most recent term
+	GetAll(ctx context.Context, filter AcademicFilter) ([]models.Academic, error)
+	GetByStudentID(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error)
 	GetByID(ctx context.Context, id int) (*models.Academic, error)
+	GetUniversityByID(ctx context.Context, id uint) (*models.University, error)
+	GetTermByID(ctx context.Context, id uint) (*models.Term, error)
+	// ExistsForTerm reports whether the student already has a record in the term
+	ExistsForTerm(ctx context.Context, studentID, termID uint) (bool, error)
+	// GetFuzzyConfig returns the university's fuzzy model, or nil when it uses the default
+	GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error)
 }
 
 type AcademicHandler interface {
@@ -20,4 +30,5 @@ type AcademicHandler interface {
 	GetAll(w http.ResponseWriter, r *http.Request)
 	GetByStudentID(w http.ResponseWriter, r *http.Request)
 	GetByID(w http.ResponseWriter, r *http.Request)
+	TrendByStudentID(w http.ResponseWriter, r *http.Request)
 }
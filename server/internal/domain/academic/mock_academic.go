@@ -8,6 +8,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	audit "tsukamoto/internal/audit"
 	models "tsukamoto/internal/models"
 
 	gomock "github.com/golang/mock/gomock"
@@ -37,32 +38,47 @@ func (m *MockAcademicRepository) EXPECT() *MockAcademicRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockAcademicRepository) Create(ctx context.Context, academic models.Academic) error {
+func (m *MockAcademicRepository) Create(ctx context.Context, academic *models.Academic, record audit.Recorder) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, academic)
+	ret := m.ctrl.Call(m, "Create", ctx, academic, record)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockAcademicRepositoryMockRecorder) Create(ctx, academic interface{}) *gomock.Call {
+func (mr *MockAcademicRepositoryMockRecorder) Create(ctx, academic, record interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAcademicRepository)(nil).Create), ctx, academic)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAcademicRepository)(nil).Create), ctx, academic, record)
+}
+
+// ExistsForTerm mocks base method.
+func (m *MockAcademicRepository) ExistsForTerm(ctx context.Context, studentID uint, termID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsForTerm", ctx, studentID, termID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsForTerm indicates an expected call of ExistsForTerm.
+func (mr *MockAcademicRepositoryMockRecorder) ExistsForTerm(ctx, studentID, termID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsForTerm", reflect.TypeOf((*MockAcademicRepository)(nil).ExistsForTerm), ctx, studentID, termID)
 }
 
 // GetAll mocks base method.
-func (m *MockAcademicRepository) GetAll(ctx context.Context) ([]models.Academic, error) {
+func (m *MockAcademicRepository) GetAll(ctx context.Context, filter AcademicFilter) ([]models.Academic, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
 	ret0, _ := ret[0].([]models.Academic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockAcademicRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockAcademicRepositoryMockRecorder) GetAll(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAcademicRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAcademicRepository)(nil).GetAll), ctx, filter)
 }
 
 // GetByID mocks base method.
@@ -81,32 +97,77 @@ func (mr *MockAcademicRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomo
 }
 
 // GetByStudentID mocks base method.
-func (m *MockAcademicRepository) GetByStudentID(ctx context.Context, studentID uint) ([]models.Academic, error) {
+func (m *MockAcademicRepository) GetByStudentID(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByStudentID", ctx, studentID)
+	ret := m.ctrl.Call(m, "GetByStudentID", ctx, studentID, filter)
 	ret0, _ := ret[0].([]models.Academic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByStudentID indicates an expected call of GetByStudentID.
-func (mr *MockAcademicRepositoryMockRecorder) GetByStudentID(ctx, studentID interface{}) *gomock.Call {
+func (mr *MockAcademicRepositoryMockRecorder) GetByStudentID(ctx, studentID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudentID", reflect.TypeOf((*MockAcademicRepository)(nil).GetByStudentID), ctx, studentID, filter)
+}
+
+// GetFuzzyConfig mocks base method.
+func (m *MockAcademicRepository) GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFuzzyConfig", ctx, universityID)
+	ret0, _ := ret[0].(*models.FuzzyConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFuzzyConfig indicates an expected call of GetFuzzyConfig.
+func (mr *MockAcademicRepositoryMockRecorder) GetFuzzyConfig(ctx, universityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFuzzyConfig", reflect.TypeOf((*MockAcademicRepository)(nil).GetFuzzyConfig), ctx, universityID)
+}
+
+// GetTermByID mocks base method.
+func (m *MockAcademicRepository) GetTermByID(ctx context.Context, id uint) (*models.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTermByID", ctx, id)
+	ret0, _ := ret[0].(*models.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTermByID indicates an expected call of GetTermByID.
+func (mr *MockAcademicRepositoryMockRecorder) GetTermByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudentID", reflect.TypeOf((*MockAcademicRepository)(nil).GetByStudentID), ctx, studentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTermByID", reflect.TypeOf((*MockAcademicRepository)(nil).GetTermByID), ctx, id)
+}
+
+// GetUniversityByID mocks base method.
+func (m *MockAcademicRepository) GetUniversityByID(ctx context.Context, id uint) (*models.University, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUniversityByID", ctx, id)
+	ret0, _ := ret[0].(*models.University)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUniversityByID indicates an expected call of GetUniversityByID.
+func (mr *MockAcademicRepositoryMockRecorder) GetUniversityByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUniversityByID", reflect.TypeOf((*MockAcademicRepository)(nil).GetUniversityByID), ctx, id)
 }
 
 // Update mocks base method.
-func (m *MockAcademicRepository) Update(ctx context.Context, id int, academic models.Academic) error {
+func (m *MockAcademicRepository) Update(ctx context.Context, id int, academic models.Academic, record audit.Recorder) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, academic)
+	ret := m.ctrl.Call(m, "Update", ctx, id, academic, record)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockAcademicRepositoryMockRecorder) Update(ctx, id, academic interface{}) *gomock.Call {
+func (mr *MockAcademicRepositoryMockRecorder) Update(ctx, id, academic, record interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAcademicRepository)(nil).Update), ctx, id, academic)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAcademicRepository)(nil).Update), ctx, id, academic, record)
 }
 
 // MockAcademicHandler is a mock of AcademicHandler interface.
@@ -180,6 +241,18 @@ func (mr *MockAcademicHandlerMockRecorder) GetByStudentID(w, r interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudentID", reflect.TypeOf((*MockAcademicHandler)(nil).GetByStudentID), w, r)
 }
 
+// TrendByStudentID mocks base method.
+func (m *MockAcademicHandler) TrendByStudentID(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrendByStudentID", w, r)
+}
+
+// TrendByStudentID indicates an expected call of TrendByStudentID.
+func (mr *MockAcademicHandlerMockRecorder) TrendByStudentID(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrendByStudentID", reflect.TypeOf((*MockAcademicHandler)(nil).TrendByStudentID), w, r)
+}
+
 // Update mocks base method.
 func (m *MockAcademicHandler) Update(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -3,7 +3,9 @@ package academic
 import (
 	"context"
 	"errors"
+	"tsukamoto/internal/audit"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/tenant"
 
 	"gorm.io/gorm"
 )
@@ -16,56 +18,86 @@ func NewAcademicRepository(db *gorm.DB) AcademicRepository {
 	return &academicRepository{db: db}
 }
 
-func (r *academicRepository) Create(ctx context.Context, academic models.Academic) error {
-	return r.db.WithContext(ctx).Create(&academic).Error
+func (r *academicRepository) Create(ctx context.Context, academic *models.Academic, record audit.Recorder) error {
+	if !tenant.FromContext(ctx).Allows(academic.UniversityID) {
+		return tenant.ErrOutsideScope
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(academic).Error; err != nil {
+			return err
+		}
+		return record.Run(tx)
+	})
 }
 
-func (r *academicRepository) Update(ctx context.Context, id int, academic models.Academic) error {
+func (r *academicRepository) Update(ctx context.Context, id int, academic models.Academic, record audit.Recorder) error {
 	// Cek apakah record ada
 	var existing models.Academic
-	if err := r.db.WithContext(ctx).First(&existing, id).Error; err != nil {
+	scope := tenant.FromContext(ctx)
+	if err := r.db.WithContext(ctx).Scopes(scope.Filter("university_id")).First(&existing, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("academic record not found")
 		}
 		return err
 	}
 
-	// Update hanya field yang tidak null
-	result := r.db.WithContext(ctx).Model(&existing).Updates(academic)
-	if result.Error != nil {
-		return result.Error
+	// Data tidak boleh dipindahkan ke universitas di luar jangkauan pemanggil
+	if academic.UniversityID != 0 && !scope.Allows(academic.UniversityID) {
+		return tenant.ErrOutsideScope
 	}
 
-	if result.RowsAffected == 0 {
-		return errors.New("no rows were updated")
-	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Update hanya field yang tidak null
+		result := tx.Model(&existing).Updates(academic)
+		if result.Error != nil {
+			return result.Error
+		}
 
-	return nil
+		if result.RowsAffected == 0 {
+			return errors.New("no rows were updated")
+		}
+
+		return record.Run(tx)
+	})
 }
 
-func (r *academicRepository) GetAll(ctx context.Context) ([]models.Academic, error) {
+func (r *academicRepository) GetAll(ctx context.Context, filter AcademicFilter) ([]models.Academic, error) {
 	var academics []models.Academic
 	err := r.db.WithContext(ctx).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, username, name, role, created_at, updated_at")
 		}).
 		Preload("University").
+		Preload("Term").
+		Scopes(models.LatestTermFirst, filterByTerm(filter), tenant.Scoped(ctx, "academics.university_id")).
 		Find(&academics).Error
 	return academics, err
 }
 
-func (r *academicRepository) GetByStudentID(ctx context.Context, studentID uint) ([]models.Academic, error) {
+func (r *academicRepository) GetByStudentID(ctx context.Context, studentID uint, filter AcademicFilter) ([]models.Academic, error) {
 	var academics []models.Academic
 	err := r.db.WithContext(ctx).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, username, name, role, created_at, updated_at")
 		}).
 		Preload("University").
-		Where("user_id = ?", studentID).
+		Preload("Term").
+		Scopes(models.LatestTermFirst, filterByTerm(filter), tenant.Scoped(ctx, "academics.university_id")).
+		Where("academics.user_id = ?", studentID).
 		Find(&academics).Error
 	return academics, err
 }
 
+// filterByTerm limits the records to the term of the filter, if any
+func filterByTerm(filter AcademicFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.TermID == 0 {
+			return db
+		}
+		return db.Where("academics.term_id = ?", filter.TermID)
+	}
+}
+
 // Tambahkan method untuk get by ID
 func (r *academicRepository) GetByID(ctx context.Context, id int) (*models.Academic, error) {
 	var academic models.Academic
@@ -74,6 +106,8 @@ func (r *academicRepository) GetByID(ctx context.Context, id int) (*models.Acade
 			return db.Select("id, username, name, role, created_at, updated_at")
 		}).
 		Preload("University").
+		Preload("Term").
+		Scopes(tenant.Scoped(ctx, "academics.university_id")).
 		First(&academic, id).Error
 	
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -81,4 +115,46 @@ func (r *academicRepository) GetByID(ctx context.Context, id int) (*models.Acade
 	}
 	
 	return &academic, err
-}
\ No newline at end of file
+}
+
+// GetUniversityByID mengambil universitas untuk menentukan skala penilaian
+func (r *academicRepository) GetUniversityByID(ctx context.Context, id uint) (*models.University, error) {
+	var university models.University
+	err := r.db.WithContext(ctx).First(&university, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("university not found")
+	}
+	return &university, err
+}
+
+// GetFuzzyConfig mengambil model fuzzy universitas, nil jika memakai model bawaan
+func (r *academicRepository) GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error) {
+	var config models.FuzzyConfig
+	err := r.db.WithContext(ctx).Where("university_id = ?", universityID).First(&config).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// GetTermByID mengambil term tempat nilai berlaku
+func (r *academicRepository) GetTermByID(ctx context.Context, id uint) (*models.Term, error) {
+	var term models.Term
+	err := r.db.WithContext(ctx).First(&term, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("term not found")
+	}
+	return &term, err
+}
+
+// ExistsForTerm memeriksa apakah mahasiswa sudah punya data akademik pada term tersebut
+func (r *academicRepository) ExistsForTerm(ctx context.Context, studentID, termID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Academic{}).
+		Where("user_id = ? AND term_id = ?", studentID, termID).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -1,16 +1,20 @@
 package academic
 
 import (
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
+
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
 )
 
-func AcademicRoute(r *mux.Router, db *gorm.DB) {
+func AcademicRoute(r *mux.Router, db *gorm.DB, bus *events.Bus, log *audit.Log) {
 	repo := NewAcademicRepository(db)
-	handler := NewAcademicHandler(repo)
+	handler := NewAcademicHandler(repo, bus, log)
 
 	r.HandleFunc("/academic", handler.Create).Methods("POST")
 	r.HandleFunc("/academic/{id}", handler.Update).Methods("PUT")
 	r.HandleFunc("/academic", handler.GetAll).Methods("GET")
 	r.HandleFunc("/academic/student/{student_id}", handler.GetByStudentID).Methods("GET")
+	r.HandleFunc("/academic/student/{student_id}/trend", handler.TrendByStudentID).Methods("GET")
 }
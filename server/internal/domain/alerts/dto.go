@@ -0,0 +1,24 @@
+package alerts
+
+// AlertFilter membatasi daftar alert; nilai kosong berarti tanpa filter
+type AlertFilter struct {
+	Status    string
+	UserID    uint
+	AdvisorID uint
+}
+
+// UpdateAlertRequest mengubah status, advisor atau catatan alert; field nil tidak diubah
+type UpdateAlertRequest struct {
+	Status    *string `json:"status"`
+	AdvisorID *uint   `json:"advisor_id"`
+	Note      *string `json:"note"`
+}
+
+// EvaluateRequest menjalankan early-warning untuk mahasiswa tertentu atau semua
+// mahasiswa bila UserIDs kosong; threshold kosong memakai konfigurasi server
+type EvaluateRequest struct {
+	UserIDs  []uint   `json:"user_ids"`
+	MinScore *float64 `json:"min_score"`
+	MaxDrop  *float64 `json:"max_drop"`
+	Method   string   `json:"method"`
+}
@@ -0,0 +1,224 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/asesmen"
+	"tsukamoto/internal/modules/inferensi"
+	"tsukamoto/internal/modules/normalisasi"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Thresholds decide when an assessment opens an alert: a crisp score below
+// MinScore, or a fall of more than MaxDrop points since the previous assessment made
+// with the same Method.
+type Thresholds struct {
+	MinScore float64          `json:"min_score"`
+	MaxDrop  float64          `json:"max_drop"`
+	Method   inferensi.Method `json:"method"`
+}
+
+// DefaultThresholds flags students below Satisfactory or falling by more than ten points.
+func DefaultThresholds() Thresholds {
+	return Thresholds{MinScore: 60, MaxDrop: 10, Method: inferensi.MethodTsukamoto}
+}
+
+// ThresholdsFromConfig reads the early-warning settings, keeping the default
+// for any value that is missing or invalid.
+func ThresholdsFromConfig(cfg *config.Config) Thresholds {
+	thresholds := DefaultThresholds()
+	if value, err := strconv.ParseFloat(cfg.AlertScoreThreshold, 64); err == nil {
+		thresholds.MinScore = value
+	}
+	if value, err := strconv.ParseFloat(cfg.AlertDropThreshold, 64); err == nil && value >= 0 {
+		thresholds.MaxDrop = value
+	}
+	if method, err := inferensi.ParseMethod(cfg.AlertMethod); err == nil {
+		thresholds.Method = method
+	}
+	return thresholds
+}
+
+// Evaluation is the outcome of evaluating one student.
+type Evaluation struct {
+	UserID     uint               `json:"user_id"`
+	Assessment *models.Assessment `json:"assessment,omitempty"`
+	Alerts     []models.Alert     `json:"alerts"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// Evaluator stores a fresh assessment for a student and opens alerts when it
+// crosses the thresholds. A student never has more than one open or
+// acknowledged alert per reason.
+type Evaluator struct {
+	repo       AlertRepository
+	bus        *events.Bus
+	thresholds Thresholds
+
+	// Mahasiswa yang menunggu dievaluasi oleh worker; set menggabungkan
+	// perubahan berulang untuk mahasiswa yang sama
+	mu      sync.Mutex
+	pending map[uint]struct{}
+	wake    chan struct{}
+}
+
+// NewEvaluator creates an Evaluator using thresholds for evaluations triggered by data changes
+func NewEvaluator(repo AlertRepository, bus *events.Bus, thresholds Thresholds) *Evaluator {
+	return &Evaluator{
+		repo:       repo,
+		bus:        bus,
+		thresholds: thresholds,
+		pending:    make(map[uint]struct{}),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Thresholds returns the configured thresholds
+func (e *Evaluator) Thresholds() Thresholds {
+	return e.thresholds
+}
+
+// EvaluateStudent assesses the student's academic record and opens the alerts it warrants.
+// Inputs that fire no rule return inferensi.ErrNoRuleActivated; nothing is
+// stored and no alert is opened, because a score of 0 would read as failing.
+func (e *Evaluator) EvaluateStudent(ctx context.Context, userID uint, thresholds Thresholds) (Evaluation, error) {
+	evaluation := Evaluation{UserID: userID, Alerts: []models.Alert{}}
+
+	academic, err := e.repo.GetAcademicByUserID(ctx, userID)
+	if err != nil {
+		return evaluation, err
+	}
+	if errs := normalisasi.Validate(normalisasi.FromAcademic(*academic)); len(errs) > 0 {
+		return evaluation, errors.New("invalid academic data: " + errs[0].Message)
+	}
+
+	// Bandingkan hanya dengan asesmen metode yang sama; skor metode lain tidak sebanding
+	previous, err := e.repo.GetLatestAssessment(ctx, userID, string(thresholds.Method))
+	if err != nil {
+		return evaluation, err
+	}
+
+	config, err := e.repo.GetFuzzyConfig(ctx, academic.UniversityID)
+	if err != nil {
+		return evaluation, err
+	}
+	model, err := asesmen.ModelFrom(config)
+	if err != nil {
+		return evaluation, err
+	}
+	assessment, _, err := asesmen.Evaluate(model, *academic, thresholds.Method)
+	if err != nil {
+		return evaluation, err
+	}
+	if err := e.repo.CreateAssessment(ctx, assessment); err != nil {
+		return evaluation, err
+	}
+	evaluation.Assessment = assessment
+	e.bus.Publish(ctx, events.AssessmentCreated, assessment)
+
+	var candidates []models.Alert
+	if assessment.CrispOutput < thresholds.MinScore {
+		candidates = append(candidates, models.Alert{
+			Reason:    models.AlertReasonBelowThreshold,
+			Threshold: thresholds.MinScore,
+		})
+	}
+	if previous != nil && previous.CrispOutput-assessment.CrispOutput > thresholds.MaxDrop {
+		previousScore := previous.CrispOutput
+		candidates = append(candidates, models.Alert{
+			Reason:        models.AlertReasonScoreDrop,
+			Threshold:     thresholds.MaxDrop,
+			PreviousScore: &previousScore,
+		})
+	}
+
+	for _, alert := range candidates {
+		active, err := e.repo.FindActiveAlert(ctx, userID, alert.Reason)
+		if err != nil {
+			return evaluation, err
+		}
+		if active != nil {
+			continue
+		}
+
+		alert.UserID = userID
+		alert.AssessmentID = assessment.ID
+		alert.CrispOutput = assessment.CrispOutput
+		alert.Status = models.AlertStatusOpen
+		if err := e.repo.CreateAlert(ctx, &alert); err != nil {
+			return evaluation, err
+		}
+		evaluation.Alerts = append(evaluation.Alerts, alert)
+		e.bus.Publish(ctx, events.AlertOpened, &alert)
+	}
+
+	return evaluation, nil
+}
+
+// HandleAcademicChanged queues the students of an events.AcademicChanged
+// event for the worker started by Run, so the publishing request does not
+// wait for their evaluations
+func (e *Evaluator) HandleAcademicChanged(_ context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.AcademicChangedPayload)
+	if !ok || len(payload.UserIDs) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	for _, userID := range payload.UserIDs {
+		e.pending[userID] = struct{}{}
+	}
+	e.mu.Unlock()
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ProcessPending evaluates every queued student and returns how many were
+// evaluated successfully. Failures are logged and not retried; the next
+// change of the student's data queues them again.
+func (e *Evaluator) ProcessPending(ctx context.Context) int {
+	e.mu.Lock()
+	userIDs := make([]uint, 0, len(e.pending))
+	for userID := range e.pending {
+		userIDs = append(userIDs, userID)
+	}
+	e.pending = make(map[uint]struct{})
+	e.mu.Unlock()
+
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+	evaluated := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			break
+		}
+		if _, err := e.EvaluateStudent(ctx, userID, e.thresholds); err != nil {
+			logrus.WithError(err).WithField("user_id", userID).Warn("Early-warning evaluation failed")
+			continue
+		}
+		evaluated++
+	}
+	return evaluated
+}
+
+// Run evaluates queued students until ctx is cancelled. It is given a context
+// of its own, detached from the requests that queued the students.
+func (e *Evaluator) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.wake:
+			e.ProcessPending(ctx)
+		}
+	}
+}
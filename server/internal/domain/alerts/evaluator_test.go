@@ -0,0 +1,243 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/inferensi"
+
+	"github.com/golang/mock/gomock"
+)
+
+// atRiskAcademic scores around 54 (Needs Improvement) with the default rule base
+func atRiskAcademic() *models.Academic {
+	return &models.Academic{ID: 3, UserID: 9, GPA: 2.6, CoreCourseAverage: 65, AttendanceRate: 0.62, MidtermExamScore: 60, FinalExamScore: 70}
+}
+
+func TestThresholdsFromConfig(t *testing.T) {
+	thresholds := ThresholdsFromConfig(&config.Config{AlertScoreThreshold: "55", AlertDropThreshold: "x", AlertMethod: "sugeno"})
+	if thresholds.MinScore != 55 || thresholds.MaxDrop != DefaultThresholds().MaxDrop || thresholds.Method != "sugeno" {
+		t.Errorf("unexpected thresholds %+v", thresholds)
+	}
+}
+
+func TestEvaluator_OpensBelowThresholdAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	bus := events.NewBus()
+	var opened []*models.Alert
+	bus.Subscribe(events.AlertOpened, func(_ context.Context, event events.Event) {
+		opened = append(opened, event.Payload.(*models.Alert))
+	})
+	evaluator := NewEvaluator(mockRepo, bus, DefaultThresholds())
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(nil, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *models.Assessment) error {
+		a.ID = 21
+		return nil
+	})
+	mockRepo.EXPECT().FindActiveAlert(gomock.Any(), uint(9), models.AlertReasonBelowThreshold).Return(nil, nil)
+	mockRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(nil)
+
+	evaluation, err := evaluator.EvaluateStudent(context.Background(), 9, DefaultThresholds())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %+v", evaluation.Alerts)
+	}
+	alert := evaluation.Alerts[0]
+	if alert.Reason != models.AlertReasonBelowThreshold || alert.Status != models.AlertStatusOpen || alert.AssessmentID != 21 || alert.UserID != 9 {
+		t.Errorf("unexpected alert %+v", alert)
+	}
+	if len(opened) != 1 {
+		t.Errorf("expected alert.opened to be published once, got %d", len(opened))
+	}
+}
+
+func TestEvaluator_OpensScoreDropAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	evaluator := NewEvaluator(mockRepo, nil, DefaultThresholds())
+	thresholds := Thresholds{MinScore: 0, MaxDrop: 10, Method: "tsukamoto"}
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(&models.Assessment{CrispOutput: 85}, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().FindActiveAlert(gomock.Any(), uint(9), models.AlertReasonScoreDrop).Return(nil, nil)
+	mockRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(nil)
+
+	evaluation, err := evaluator.EvaluateStudent(context.Background(), 9, thresholds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Alerts) != 1 || evaluation.Alerts[0].Reason != models.AlertReasonScoreDrop {
+		t.Fatalf("expected a score drop alert, got %+v", evaluation.Alerts)
+	}
+	if previous := evaluation.Alerts[0].PreviousScore; previous == nil || *previous != 85 {
+		t.Errorf("expected previous score 85, got %v", previous)
+	}
+}
+
+func TestEvaluator_ComparesWithSameMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	evaluator := NewEvaluator(mockRepo, nil, DefaultThresholds())
+	thresholds := Thresholds{MinScore: 0, MaxDrop: 10, Method: "sugeno"}
+
+	// Asesmen terakhir dicari dengan metode evaluator, bukan asesmen metode apa pun
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "sugeno").Return(nil, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(nil)
+
+	evaluation, err := evaluator.EvaluateStudent(context.Background(), 9, thresholds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Alerts) != 0 {
+		t.Errorf("expected no alert without a previous sugeno assessment, got %+v", evaluation.Alerts)
+	}
+}
+
+func TestEvaluator_SkipsWhenAlertAlreadyActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	evaluator := NewEvaluator(mockRepo, nil, DefaultThresholds())
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(nil, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().FindActiveAlert(gomock.Any(), uint(9), models.AlertReasonBelowThreshold).
+		Return(&models.Alert{ID: 4, Status: models.AlertStatusAcknowledged}, nil)
+
+	evaluation, err := evaluator.EvaluateStudent(context.Background(), 9, DefaultThresholds())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Alerts) != 0 {
+		t.Errorf("expected no new alert, got %+v", evaluation.Alerts)
+	}
+}
+
+func TestEvaluator_NoAlertForHealthyStudent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	evaluator := NewEvaluator(mockRepo, nil, DefaultThresholds())
+
+	healthy := &models.Academic{ID: 4, UserID: 9, GPA: 3.6, CoreCourseAverage: 85, AttendanceRate: 0.95, MidtermExamScore: 85, FinalExamScore: 88}
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(healthy, nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(&models.Assessment{CrispOutput: 90}, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(nil)
+
+	evaluation, err := evaluator.EvaluateStudent(context.Background(), 9, DefaultThresholds())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Alerts) != 0 {
+		t.Errorf("expected no alert, got %+v", evaluation.Alerts)
+	}
+}
+
+func TestEvaluator_NoAlertWhenNoRuleFires(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	bus := events.NewBus()
+	published := 0
+	bus.Subscribe(events.AlertOpened, func(context.Context, events.Event) { published++ })
+	bus.Subscribe(events.AssessmentCreated, func(context.Context, events.Event) { published++ })
+	evaluator := NewEvaluator(mockRepo, bus, DefaultThresholds())
+
+	// Kombinasi term ini tidak tercakup aturan bawaan; tidak ada assessment maupun alert
+	uncovered := &models.Academic{ID: 4, UserID: 9, GPA: 3.8, CoreCourseAverage: 40, AttendanceRate: 0.5, MidtermExamScore: 40, FinalExamScore: 40}
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(uncovered, nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(&models.Assessment{CrispOutput: 85}, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+
+	evaluation, err := evaluator.EvaluateStudent(context.Background(), 9, DefaultThresholds())
+	if !errors.Is(err, inferensi.ErrNoRuleActivated) {
+		t.Fatalf("expected ErrNoRuleActivated, got %v", err)
+	}
+	if len(evaluation.Alerts) != 0 || evaluation.Assessment != nil {
+		t.Errorf("expected no assessment or alerts, got %+v", evaluation)
+	}
+	if published != 0 {
+		t.Errorf("expected no events, got %d", published)
+	}
+}
+
+func TestEvaluator_HandleAcademicChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	bus := events.NewBus()
+	evaluator := NewEvaluator(mockRepo, bus, Thresholds{MinScore: 0, MaxDrop: 100, Method: "tsukamoto"})
+	bus.Subscribe(events.AcademicChanged, evaluator.HandleAcademicChanged)
+
+	// Publish hanya mengantrekan; repository belum boleh disentuh
+	bus.Publish(context.Background(), events.AcademicChanged, events.AcademicChangedPayload{UserIDs: []uint{9}})
+	bus.Publish(context.Background(), events.AcademicChanged, events.AcademicChangedPayload{UserIDs: []uint{9}})
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(nil, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Perubahan berulang untuk mahasiswa yang sama dievaluasi sekali
+	if evaluated := evaluator.ProcessPending(context.Background()); evaluated != 1 {
+		t.Errorf("expected 1 evaluation, got %d", evaluated)
+	}
+	if evaluated := evaluator.ProcessPending(context.Background()); evaluated != 0 {
+		t.Errorf("expected an empty queue, got %d evaluations", evaluated)
+	}
+}
+
+func TestEvaluator_RunDrainsQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAlertRepository(ctrl)
+	evaluator := NewEvaluator(mockRepo, nil, Thresholds{MinScore: 0, MaxDrop: 100, Method: "tsukamoto"})
+
+	done := make(chan struct{})
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(nil, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *models.Assessment) error {
+		close(done)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go evaluator.Run(ctx)
+	evaluator.HandleAcademicChanged(context.Background(), events.Event{Payload: events.AcademicChangedPayload{UserIDs: []uint{9}}})
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued student was not evaluated")
+	}
+}
@@ -0,0 +1,213 @@
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/inferensi"
+	"tsukamoto/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+type alertHandler struct {
+	repo      AlertRepository
+	evaluator *Evaluator
+}
+
+func NewAlertHandler(repo AlertRepository, evaluator *Evaluator) AlertHandler {
+	return &alertHandler{repo: repo, evaluator: evaluator}
+}
+
+func validStatus(status string) bool {
+	switch status {
+	case models.AlertStatusOpen, models.AlertStatusAcknowledged, models.AlertStatusResolved:
+		return true
+	}
+	return false
+}
+
+// parseUintQuery reads an optional numeric query parameter
+func parseUintQuery(r *http.Request, name string) (uint, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// List handles GET /alerts?status=&user_id=&advisor_id=
+func (h *alertHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter := AlertFilter{Status: r.URL.Query().Get("status")}
+	if filter.Status != "" && !validStatus(filter.Status) {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "status", Message: "Status alert tidak valid"}}, nil)
+		return
+	}
+
+	var ok bool
+	if filter.UserID, ok = parseUintQuery(r, "user_id"); !ok {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "user_id", Message: "ID user tidak valid"}}, nil)
+		return
+	}
+	if filter.AdvisorID, ok = parseUintQuery(r, "advisor_id"); !ok {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "advisor_id", Message: "ID advisor tidak valid"}}, nil)
+		return
+	}
+
+	alerts, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data alert"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, alerts)
+}
+
+// GetByID handles GET /alerts/:id
+func (h *alertHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	alert, ok := h.loadAlert(w, r)
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, alert)
+}
+
+// Update handles PUT /alerts/:id to change the status, assigned advisor or note
+func (h *alertHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+	if req.Status != nil && !validStatus(*req.Status) {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "status", Message: "Status alert tidak valid"}}, nil)
+		return
+	}
+
+	alert, ok := h.loadAlert(w, r)
+	if !ok {
+		return
+	}
+
+	if req.AdvisorID != nil {
+		advisor, err := h.repo.GetUserByID(r.Context(), *req.AdvisorID)
+		if err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "advisor_id", Message: "Advisor tidak ditemukan"}}, nil)
+			return
+		}
+		if advisor.Role != models.RoleAdvisor {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "advisor_id", Message: "User bukan advisor"}}, nil)
+			return
+		}
+		// Advisor harus dari universitas yang sama dengan mahasiswa alert
+		if alert.User == nil || alert.User.UniversityID == nil || advisor.UniversityID == nil || *advisor.UniversityID != *alert.User.UniversityID {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "advisor_id", Message: "Advisor harus dari universitas mahasiswa"}}, nil)
+			return
+		}
+		alert.AdvisorID = req.AdvisorID
+		alert.Advisor = nil
+	}
+	if req.Note != nil {
+		alert.Note = *req.Note
+	}
+	if req.Status != nil && *req.Status != alert.Status {
+		alert.Status = *req.Status
+		alert.ResolvedAt = nil
+		if alert.Status == models.AlertStatusResolved {
+			now := time.Now()
+			alert.ResolvedAt = &now
+		}
+	}
+
+	if err := h.repo.Update(r.Context(), alert); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memperbarui alert"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, alert)
+}
+
+// Evaluate handles POST /alerts/evaluate and runs the early-warning check on
+// demand for the given students, or for every student with academic data
+func (h *alertHandler) Evaluate(w http.ResponseWriter, r *http.Request) {
+	var req EvaluateRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+			return
+		}
+	}
+
+	thresholds := h.evaluator.Thresholds()
+	if req.MinScore != nil {
+		thresholds.MinScore = *req.MinScore
+	}
+	if req.MaxDrop != nil {
+		if *req.MaxDrop < 0 {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "max_drop", Message: "Batas penurunan tidak boleh negatif"}}, nil)
+			return
+		}
+		thresholds.MaxDrop = *req.MaxDrop
+	}
+	if req.Method != "" {
+		method, err := inferensi.ParseMethod(req.Method)
+		if err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "method", Message: "Metode inferensi tidak valid"}}, nil)
+			return
+		}
+		thresholds.Method = method
+	}
+
+	userIDs := req.UserIDs
+	if len(userIDs) == 0 {
+		var err error
+		userIDs, err = h.repo.ListStudentIDs(r.Context())
+		if err != nil {
+			utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data mahasiswa"}}, nil)
+			return
+		}
+	}
+
+	results := make([]Evaluation, 0, len(userIDs))
+	opened := 0
+	for _, userID := range userIDs {
+		evaluation, err := h.evaluator.EvaluateStudent(r.Context(), userID, thresholds)
+		if err != nil {
+			evaluation.Error = err.Error()
+		}
+		opened += len(evaluation.Alerts)
+		results = append(results, evaluation)
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, map[string]interface{}{
+		"thresholds":    thresholds,
+		"evaluated":     len(results),
+		"alerts_opened": opened,
+		"results":       results,
+	})
+}
+
+// loadAlert reads the alert in the URL, writing the error response itself
+func (h *alertHandler) loadAlert(w http.ResponseWriter, r *http.Request) (*models.Alert, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "ID alert tidak valid"}}, nil)
+		return nil, false
+	}
+
+	alert, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrAlertNotFound) {
+			utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Alert tidak ditemukan"}}, nil)
+			return nil, false
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data alert"}}, nil)
+		return nil, false
+	}
+	return alert, true
+}
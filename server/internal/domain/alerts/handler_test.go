@@ -0,0 +1,225 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const (
+	alertsPath   = "/alerts"
+	alertsPathID = "/alerts/1"
+)
+
+func newAlertHandler(ctrl *gomock.Controller) (*MockAlertRepository, AlertHandler) {
+	mockRepo := NewMockAlertRepository(ctrl)
+	return mockRepo, NewAlertHandler(mockRepo, NewEvaluator(mockRepo, nil, DefaultThresholds()))
+}
+
+func updateRequest(body string) *http.Request {
+	req := httptest.NewRequest("PUT", alertsPathID, bytes.NewReader([]byte(body)))
+	return mux.SetURLVars(req, map[string]string{"id": "1"})
+}
+
+func TestAlertHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newAlertHandler(ctrl)
+
+	mockRepo.EXPECT().
+		List(gomock.Any(), AlertFilter{Status: "open", AdvisorID: 3}).
+		Return([]models.Alert{{ID: 1, Status: "open"}}, nil)
+
+	w := httptest.NewRecorder()
+	handler.List(w, httptest.NewRequest("GET", alertsPath+"?status=open&advisor_id=3", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestAlertHandler_List_InvalidStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, handler := newAlertHandler(ctrl)
+
+	w := httptest.NewRecorder()
+	handler.List(w, httptest.NewRequest("GET", alertsPath+"?status=closed", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAlertHandler_List_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newAlertHandler(ctrl)
+
+	mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	w := httptest.NewRecorder()
+	handler.List(w, httptest.NewRequest("GET", alertsPath, nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestAlertHandler_GetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newAlertHandler(ctrl)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, ErrAlertNotFound)
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", alertsPathID, nil), map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+	handler.GetByID(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestAlertHandler_Update_ResolveAndAssign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newAlertHandler(ctrl)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(alertOfUniversity(5), nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(3)).Return(&models.User{ID: 3, Role: "advisor", UniversityID: uintPtr(5)}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, alert *models.Alert) error {
+		if alert.Status != models.AlertStatusResolved || alert.ResolvedAt == nil {
+			t.Errorf("expected resolved alert with timestamp, got %+v", alert)
+		}
+		if alert.AdvisorID == nil || *alert.AdvisorID != 3 || alert.Note != "Met with student" {
+			t.Errorf("expected advisor and note to be set, got %+v", alert)
+		}
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.Update(w, updateRequest(`{"status":"resolved","advisor_id":3,"note":"Met with student"}`))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAlertHandler_Update_InvalidStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, handler := newAlertHandler(ctrl)
+
+	w := httptest.NewRecorder()
+	handler.Update(w, updateRequest(`{"status":"closed"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+// alertOfUniversity is an open alert of a student of the university
+func alertOfUniversity(universityID uint) *models.Alert {
+	return &models.Alert{ID: 1, UserID: 9, User: &models.User{ID: 9, Role: "student", UniversityID: uintPtr(universityID)}, Status: models.AlertStatusOpen}
+}
+
+func uintPtr(value uint) *uint {
+	return &value
+}
+
+func TestAlertHandler_Update_InvalidAdvisor(t *testing.T) {
+	tests := []struct {
+		name    string
+		advisor *models.User
+	}{
+		{"student", &models.User{ID: 4, Role: "student", UniversityID: uintPtr(5)}},
+		{"admin", &models.User{ID: 4, Role: "admin", UniversityID: uintPtr(5)}},
+		{"advisor of another university", &models.User{ID: 4, Role: "advisor", UniversityID: uintPtr(6)}},
+		{"advisor without university", &models.User{ID: 4, Role: "advisor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo, handler := newAlertHandler(ctrl)
+
+			mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(alertOfUniversity(5), nil)
+			mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(4)).Return(tt.advisor, nil)
+
+			w := httptest.NewRecorder()
+			handler.Update(w, updateRequest(`{"advisor_id":4}`))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestAlertHandler_Evaluate_AllStudents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newAlertHandler(ctrl)
+
+	mockRepo.EXPECT().ListStudentIDs(gomock.Any()).Return([]uint{9, 10}, nil)
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(nil, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().FindActiveAlert(gomock.Any(), uint(9), models.AlertReasonBelowThreshold).Return(nil, nil)
+	mockRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(10)).Return(nil, errors.New("academic record not found"))
+
+	w := httptest.NewRecorder()
+	handler.Evaluate(w, httptest.NewRequest("POST", alertsPath+"/evaluate", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var body struct {
+		Data struct {
+			Evaluated    int          `json:"evaluated"`
+			AlertsOpened int          `json:"alerts_opened"`
+			Results      []Evaluation `json:"results"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Data.Evaluated != 2 || body.Data.AlertsOpened != 1 || body.Data.Results[1].Error == "" {
+		t.Errorf("unexpected evaluation summary %+v", body.Data)
+	}
+}
+
+func TestAlertHandler_Evaluate_CustomThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newAlertHandler(ctrl)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), uint(9)).Return(atRiskAcademic(), nil)
+	mockRepo.EXPECT().GetLatestAssessment(gomock.Any(), uint(9), "tsukamoto").Return(nil, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(nil)
+
+	body := bytes.NewReader([]byte(`{"user_ids":[9],"min_score":40}`))
+	w := httptest.NewRecorder()
+	handler.Evaluate(w, httptest.NewRequest("POST", alertsPath+"/evaluate", body))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestAlertHandler_Evaluate_InvalidMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, handler := newAlertHandler(ctrl)
+
+	w := httptest.NewRecorder()
+	handler.Evaluate(w, httptest.NewRequest("POST", alertsPath+"/evaluate", bytes.NewReader([]byte(`{"method":"x"}`))))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
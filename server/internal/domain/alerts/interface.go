@@ -0,0 +1,32 @@
+package alerts
+
+import (
+	"context"
+	"net/http"
+	"tsukamoto/internal/models"
+)
+
+type AlertRepository interface {
+	ListStudentIDs(ctx context.Context) ([]uint, error)
+	GetAcademicByUserID(ctx context.Context, userID uint) (*models.Academic, error)
+	// GetLatestAssessment returns the latest assessment of the user made with
+	// method, or nil when there is none
+	GetLatestAssessment(ctx context.Context, userID uint, method string) (*models.Assessment, error)
+	CreateAssessment(ctx context.Context, assessment *models.Assessment) error
+	FindActiveAlert(ctx context.Context, userID uint, reason string) (*models.Alert, error)
+	CreateAlert(ctx context.Context, alert *models.Alert) error
+	List(ctx context.Context, filter AlertFilter) ([]models.Alert, error)
+	GetByID(ctx context.Context, id int) (*models.Alert, error)
+	Update(ctx context.Context, alert *models.Alert) error
+	// GetUserByID returns a user inside the caller's university scope
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	// GetFuzzyConfig returns the university's fuzzy model, or nil when it uses the default
+	GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error)
+}
+
+type AlertHandler interface {
+	List(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Evaluate(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,146 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+// ErrAlertNotFound is returned by GetByID when no alert has the given ID
+var ErrAlertNotFound = errors.New("alert not found")
+
+type alertRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertRepository(db *gorm.DB) AlertRepository {
+	return &alertRepository{db: db}
+}
+
+func (r *alertRepository) ListStudentIDs(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.Academic{}).Scopes(tenant.Scoped(ctx, "university_id")).Distinct().Order("user_id").Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+func (r *alertRepository) GetAcademicByUserID(ctx context.Context, userID uint) (*models.Academic, error) {
+	var academic models.Academic
+	// Evaluasi selalu memakai data term terbaru
+	err := r.db.WithContext(ctx).Where("academics.user_id = ?", userID).Scopes(models.LatestTermFirst).First(&academic).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("academic record not found")
+		}
+		return nil, err
+	}
+	return &academic, nil
+}
+
+func (r *alertRepository) GetLatestAssessment(ctx context.Context, userID uint, method string) (*models.Assessment, error) {
+	var assessment models.Assessment
+	err := r.db.WithContext(ctx).Where("user_id = ? AND method = ?", userID, method).Order("created_at DESC, id DESC").First(&assessment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+func (r *alertRepository) CreateAssessment(ctx context.Context, assessment *models.Assessment) error {
+	return r.db.WithContext(ctx).Create(assessment).Error
+}
+
+func (r *alertRepository) FindActiveAlert(ctx context.Context, userID uint, reason string) (*models.Alert, error) {
+	var alert models.Alert
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND reason = ? AND status IN ?", userID, reason, []string{models.AlertStatusOpen, models.AlertStatusAcknowledged}).
+		First(&alert).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+func (r *alertRepository) CreateAlert(ctx context.Context, alert *models.Alert) error {
+	return r.db.WithContext(ctx).Create(alert).Error
+}
+
+func (r *alertRepository) List(ctx context.Context, filter AlertFilter) ([]models.Alert, error) {
+	query := r.db.WithContext(ctx).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username, name, role, created_at, updated_at")
+		}).
+		Preload("Advisor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username, name, role, created_at, updated_at")
+		})
+	query = query.Scopes(tenant.FromContext(ctx).FilterUsers("user_id"))
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.AdvisorID != 0 {
+		query = query.Where("advisor_id = ?", filter.AdvisorID)
+	}
+
+	var alerts []models.Alert
+	err := query.Order("created_at DESC").Find(&alerts).Error
+	return alerts, err
+}
+
+func (r *alertRepository) GetByID(ctx context.Context, id int) (*models.Alert, error) {
+	var alert models.Alert
+	err := r.db.WithContext(ctx).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username, name, role, university_id, created_at, updated_at")
+		}).
+		Preload("Advisor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username, name, role, created_at, updated_at")
+		}).
+		First(&alert, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAlertNotFound
+		}
+		return nil, err
+	}
+	return &alert, nil
+}
+
+func (r *alertRepository) Update(ctx context.Context, alert *models.Alert) error {
+	return r.db.WithContext(ctx).Model(alert).
+		Select("status", "advisor_id", "note", "resolved_at").
+		Updates(alert).Error
+}
+
+func (r *alertRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scoped(ctx, "university_id")).First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *alertRepository) GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error) {
+	var config models.FuzzyConfig
+	err := r.db.WithContext(ctx).Where("university_id = ?", universityID).First(&config).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
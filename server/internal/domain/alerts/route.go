@@ -0,0 +1,28 @@
+package alerts
+
+import (
+	"context"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/tenant"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// AlertRoute registers the early-warning routes and starts the worker that
+// re-evaluates students whenever their academic data changes
+func AlertRoute(r *mux.Router, db *gorm.DB, bus *events.Bus, cfg *config.Config) {
+	repo := NewAlertRepository(db)
+	evaluator := NewEvaluator(repo, bus, ThresholdsFromConfig(cfg))
+	handler := NewAlertHandler(repo, evaluator)
+
+	bus.Subscribe(events.AcademicChanged, evaluator.HandleAcademicChanged)
+	// Worker menilai mahasiswa dari semua universitas
+	go evaluator.Run(tenant.WithSystem(context.Background()))
+
+	r.HandleFunc("/alerts", handler.List).Methods("GET")
+	r.HandleFunc("/alerts/evaluate", handler.Evaluate).Methods("POST")
+	r.HandleFunc("/alerts/{id}", handler.GetByID).Methods("GET")
+	r.HandleFunc("/alerts/{id}", handler.Update).Methods("PUT")
+}
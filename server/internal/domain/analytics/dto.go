@@ -0,0 +1,106 @@
+package analytics
+
+// Dimensi pembanding kohort
+const (
+	ByUniversity = "university"
+	ByTerm       = "term"
+)
+
+// VariableCrispOutput is the crisp output of the latest assessment
+const VariableCrispOutput = "crisp_output"
+
+// Variables lists the aggregated variables: the five model inputs in the order
+// of inferensi.DefaultSystem followed by the crisp output
+func Variables() []string {
+	return []string{"gpa", "cca", "attendance", "midterm", "final_exam", VariableCrispOutput}
+}
+
+// ValidVariable reports whether name is one of Variables
+func ValidVariable(name string) bool {
+	for _, variable := range Variables() {
+		if variable == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CohortFilter selects the academic records to aggregate. Zero IDs select all
+// universities or terms.
+type CohortFilter struct {
+	UniversityID uint
+	TermID       uint
+	Method       string
+}
+
+// VariableStats describes the distribution of one variable. The statistics
+// are nil when the cohort has no value for the variable.
+type VariableStats struct {
+	Variable string   `json:"variable"`
+	Count    int64    `json:"count"`
+	Mean     *float64 `json:"mean"`
+	StdDev   *float64 `json:"std_dev"`
+	Min      *float64 `json:"min"`
+	P10      *float64 `json:"p10"`
+	P25      *float64 `json:"p25"`
+	Median   *float64 `json:"median"`
+	P75      *float64 `json:"p75"`
+	P90      *float64 `json:"p90"`
+	Max      *float64 `json:"max"`
+}
+
+// CategoryShare is the number and share of assessed records in a category
+type CategoryShare struct {
+	Category string  `json:"category"`
+	Count    int64   `json:"count"`
+	Share    float64 `json:"share"`
+}
+
+// SummaryResponse is the overview of a cohort. Assessed is the number of
+// records with a stored assessment of Method; categories cover only those.
+type SummaryResponse struct {
+	Method     string          `json:"method"`
+	Count      int64           `json:"count"`
+	Assessed   int64           `json:"assessed"`
+	Variables  []VariableStats `json:"variables"`
+	Categories []CategoryShare `json:"categories"`
+}
+
+// Bin is one histogram bin covering [From, To)
+type Bin struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int64   `json:"count"`
+}
+
+// HistogramResponse is the distribution of one variable
+type HistogramResponse struct {
+	Variable string `json:"variable"`
+	Method   string `json:"method"`
+	Bins     []Bin  `json:"bins"`
+}
+
+// CorrelationResponse is the correlation matrix in the order of Variables
+type CorrelationResponse struct {
+	Method    string       `json:"method"`
+	Variables []string     `json:"variables"`
+	Matrix    [][]*float64 `json:"matrix"`
+}
+
+// GroupStats aggregates the cohort of one university or term
+type GroupStats struct {
+	ID          uint                `json:"id"`
+	Label       string              `json:"label"`
+	Count       int64               `json:"count"`
+	Assessed    int64               `json:"assessed"`
+	Means       map[string]*float64 `json:"means"`
+	MedianScore *float64            `json:"median_crisp_output"`
+	Categories  map[string]int64    `json:"categories"`
+}
+
+// CompareResponse compares the groups of a cohort
+type CompareResponse struct {
+	By     string       `json:"by"`
+	Method string       `json:"method"`
+	Groups []GroupStats `json:"groups"`
+}
@@ -0,0 +1,230 @@
+package analytics
+
+import (
+	"net/http"
+	"strconv"
+	"tsukamoto/internal/modules/deffuzifikasi"
+	"tsukamoto/internal/modules/inferensi"
+	"tsukamoto/internal/utils"
+)
+
+// Batas jumlah bin histogram
+const (
+	defaultBins = 10
+	maxBins     = 50
+)
+
+type analyticsHandler struct {
+	repo AnalyticsRepository
+}
+
+func NewAnalyticsHandler(repo AnalyticsRepository) AnalyticsHandler {
+	return &analyticsHandler{repo: repo}
+}
+
+// variableRange returns the universe of discourse of a variable, which also
+// bounds its histogram
+func variableRange(variable string) (float64, float64) {
+	system := inferensi.DefaultSystem()
+	for _, input := range system.Inputs {
+		if input.Name == variable {
+			return input.Min, input.Max
+		}
+	}
+	return system.OutputMin, system.OutputMax
+}
+
+// parseUintQuery reads an optional numeric query parameter
+func parseUintQuery(r *http.Request, name string) (uint, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseFilter reads ?university_id=&term_id=&method= shared by every endpoint
+func parseFilter(r *http.Request) (CohortFilter, []utils.ErrorDetail) {
+	var filter CohortFilter
+	var errs []utils.ErrorDetail
+	var ok bool
+	if filter.UniversityID, ok = parseUintQuery(r, "university_id"); !ok {
+		errs = append(errs, utils.ErrorDetail{Field: "university_id", Message: "ID universitas tidak valid"})
+	}
+	if filter.TermID, ok = parseUintQuery(r, "term_id"); !ok {
+		errs = append(errs, utils.ErrorDetail{Field: "term_id", Message: "ID term tidak valid"})
+	}
+	method, err := inferensi.ParseMethod(r.URL.Query().Get("method"))
+	if err != nil {
+		errs = append(errs, utils.ErrorDetail{Field: "method", Message: "Metode inferensi tidak valid"})
+	}
+	filter.Method = string(method)
+	return filter, errs
+}
+
+// Summary handles GET /analytics/summary?university_id=&term_id=&method=
+func (h *analyticsHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	filter, errs := parseFilter(r)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	total, assessed, err := h.repo.Count(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghitung kohort"}}, nil)
+		return
+	}
+	stats, err := h.repo.Summary(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghitung statistik"}}, nil)
+		return
+	}
+	counts, err := h.repo.CategoryCounts(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghitung distribusi kategori"}}, nil)
+		return
+	}
+
+	// Urutkan statistik mengikuti Variables, apa pun urutan baris dari database
+	byVariable := make(map[string]VariableStats, len(stats))
+	for _, stat := range stats {
+		byVariable[stat.Variable] = stat
+	}
+	variables := make([]VariableStats, 0, len(Variables()))
+	for _, variable := range Variables() {
+		stat, ok := byVariable[variable]
+		if !ok {
+			stat = VariableStats{Variable: variable}
+		}
+		variables = append(variables, stat)
+	}
+
+	// Semua kategori selalu muncul agar klien tidak perlu melengkapi sendiri
+	categories := make([]CategoryShare, 0, len(deffuzifikasi.Categories()))
+	for _, category := range deffuzifikasi.Categories() {
+		share := CategoryShare{Category: category, Count: counts[category]}
+		if assessed > 0 {
+			share.Share = float64(share.Count) / float64(assessed)
+		}
+		categories = append(categories, share)
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, SummaryResponse{
+		Method:     filter.Method,
+		Count:      total,
+		Assessed:   assessed,
+		Variables:  variables,
+		Categories: categories,
+	})
+}
+
+// Histogram handles GET /analytics/histogram?variable=&bins=&university_id=&term_id=&method=
+func (h *analyticsHandler) Histogram(w http.ResponseWriter, r *http.Request) {
+	filter, errs := parseFilter(r)
+	variable := r.URL.Query().Get("variable")
+	if variable == "" {
+		variable = VariableCrispOutput
+	}
+	if !ValidVariable(variable) {
+		errs = append(errs, utils.ErrorDetail{Field: "variable", Message: "Variabel tidak dikenal"})
+	}
+	bins := defaultBins
+	if value := r.URL.Query().Get("bins"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxBins {
+			errs = append(errs, utils.ErrorDetail{Field: "bins", Message: "Jumlah bin harus antara 1 dan " + strconv.Itoa(maxBins)})
+		}
+		bins = n
+	}
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	min, max := variableRange(variable)
+	counts, err := h.repo.Histogram(r.Context(), filter, variable, min, max, bins)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghitung histogram"}}, nil)
+		return
+	}
+
+	width := (max - min) / float64(bins)
+	response := HistogramResponse{Variable: variable, Method: filter.Method, Bins: make([]Bin, bins)}
+	for i := range response.Bins {
+		response.Bins[i] = Bin{From: min + float64(i)*width, To: min + float64(i+1)*width, Count: counts[i+1]}
+	}
+	// Hindari galat pembulatan pada batas atas
+	response.Bins[bins-1].To = max
+
+	utils.WriteResponse(w, http.StatusOK, nil, response)
+}
+
+// Correlation handles GET /analytics/correlation?university_id=&term_id=&method=
+func (h *analyticsHandler) Correlation(w http.ResponseWriter, r *http.Request) {
+	filter, errs := parseFilter(r)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	correlations, err := h.repo.Correlations(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghitung korelasi"}}, nil)
+		return
+	}
+
+	variables := Variables()
+	matrix := make([][]*float64, len(variables))
+	for i := range variables {
+		matrix[i] = make([]*float64, len(variables))
+		for j := range variables {
+			switch {
+			case i == j:
+				one := 1.0
+				matrix[i][j] = &one
+			case i < j:
+				matrix[i][j] = correlations[[2]string{variables[i], variables[j]}]
+			default:
+				matrix[i][j] = correlations[[2]string{variables[j], variables[i]}]
+			}
+		}
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, CorrelationResponse{Method: filter.Method, Variables: variables, Matrix: matrix})
+}
+
+// Compare handles GET /analytics/compare?by=university|term&university_id=&term_id=&method=
+func (h *analyticsHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	filter, errs := parseFilter(r)
+	by := r.URL.Query().Get("by")
+	if by == "" {
+		by = ByUniversity
+	}
+	if by != ByUniversity && by != ByTerm {
+		errs = append(errs, utils.ErrorDetail{Field: "by", Message: "Dimensi perbandingan harus university atau term"})
+	}
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	groups, err := h.repo.Compare(r.Context(), filter, by)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal membandingkan kohort"}}, nil)
+		return
+	}
+	for i := range groups {
+		categories := make(map[string]int64, len(deffuzifikasi.Categories()))
+		for _, category := range deffuzifikasi.Categories() {
+			categories[category] = groups[i].Categories[category]
+		}
+		groups[i].Categories = categories
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, CompareResponse{By: by, Method: filter.Method, Groups: groups})
+}
@@ -0,0 +1,250 @@
+package analytics
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func decodeData(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response body: %v", err)
+	}
+	if err := json.Unmarshal(body.Data, v); err != nil {
+		t.Fatalf("invalid response data: %v", err)
+	}
+}
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestAnalyticsHandler_Summary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAnalyticsRepository(ctrl)
+	handler := NewAnalyticsHandler(mockRepo)
+
+	filter := CohortFilter{UniversityID: 2, TermID: 3, Method: "mamdani"}
+	mockRepo.EXPECT().Count(gomock.Any(), filter).Return(int64(5), int64(4), nil)
+	// Database boleh mengembalikan baris dalam urutan apa pun
+	mockRepo.EXPECT().Summary(gomock.Any(), filter).Return([]VariableStats{
+		{Variable: VariableCrispOutput, Count: 4, Mean: float(70)},
+		{Variable: "gpa", Count: 5, Mean: float(3.1), Median: float(3.2)},
+	}, nil)
+	mockRepo.EXPECT().CategoryCounts(gomock.Any(), filter).Return(map[string]int64{"Good": 3, "Poor": 1}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Summary(w, httptest.NewRequest("GET", "/analytics/summary?university_id=2&term_id=3&method=mamdani", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response SummaryResponse
+	decodeData(t, w, &response)
+	if response.Count != 5 || response.Assessed != 4 || response.Method != "mamdani" {
+		t.Errorf("unexpected totals %+v", response)
+	}
+	if len(response.Variables) != len(Variables()) {
+		t.Fatalf("expected %d variables, got %d", len(Variables()), len(response.Variables))
+	}
+	if response.Variables[0].Variable != "gpa" || *response.Variables[0].Median != 3.2 {
+		t.Errorf("variables not in canonical order: %+v", response.Variables[0])
+	}
+	if response.Variables[1].Variable != "cca" || response.Variables[1].Mean != nil {
+		t.Errorf("missing variable should have empty statistics: %+v", response.Variables[1])
+	}
+	if len(response.Categories) != 5 {
+		t.Fatalf("expected every category, got %+v", response.Categories)
+	}
+	for _, share := range response.Categories {
+		switch share.Category {
+		case "Good":
+			if share.Count != 3 || share.Share != 0.75 {
+				t.Errorf("unexpected Good share %+v", share)
+			}
+		case "Excellent":
+			if share.Count != 0 || share.Share != 0 {
+				t.Errorf("unexpected Excellent share %+v", share)
+			}
+		}
+	}
+}
+
+func TestAnalyticsHandler_Summary_InvalidFilter(t *testing.T) {
+	for _, query := range []string{"university_id=abc", "term_id=-1", "method=fuzzy"} {
+		t.Run(query, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			handler := NewAnalyticsHandler(NewMockAnalyticsRepository(ctrl))
+
+			w := httptest.NewRecorder()
+			handler.Summary(w, httptest.NewRequest("GET", "/analytics/summary?"+query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_Histogram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAnalyticsRepository(ctrl)
+	handler := NewAnalyticsHandler(mockRepo)
+
+	filter := CohortFilter{Method: "tsukamoto"}
+	mockRepo.EXPECT().Histogram(gomock.Any(), filter, "gpa", 0.0, 4.0, 4).Return(map[int]int64{1: 2, 4: 7}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Histogram(w, httptest.NewRequest("GET", "/analytics/histogram?variable=gpa&bins=4", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response HistogramResponse
+	decodeData(t, w, &response)
+	expected := []Bin{{0, 1, 2}, {1, 2, 0}, {2, 3, 0}, {3, 4, 7}}
+	if len(response.Bins) != len(expected) {
+		t.Fatalf("expected %d bins, got %+v", len(expected), response.Bins)
+	}
+	for i, bin := range expected {
+		if response.Bins[i] != bin {
+			t.Errorf("bin %d: expected %+v, got %+v", i, bin, response.Bins[i])
+		}
+	}
+}
+
+func TestAnalyticsHandler_Histogram_DefaultsToCrispOutput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAnalyticsRepository(ctrl)
+	handler := NewAnalyticsHandler(mockRepo)
+
+	mockRepo.EXPECT().Histogram(gomock.Any(), gomock.Any(), VariableCrispOutput, 0.0, 100.0, defaultBins).Return(map[int]int64{}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Histogram(w, httptest.NewRequest("GET", "/analytics/histogram", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAnalyticsHandler_Histogram_InvalidParameters(t *testing.T) {
+	for _, query := range []string{"variable=name", "bins=0", "bins=51", "bins=ten"} {
+		t.Run(query, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			handler := NewAnalyticsHandler(NewMockAnalyticsRepository(ctrl))
+
+			w := httptest.NewRecorder()
+			handler.Histogram(w, httptest.NewRequest("GET", "/analytics/histogram?"+query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_Correlation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAnalyticsRepository(ctrl)
+	handler := NewAnalyticsHandler(mockRepo)
+
+	mockRepo.EXPECT().Correlations(gomock.Any(), gomock.Any()).Return(map[[2]string]*float64{
+		{"gpa", "cca"}:               float(0.8),
+		{"gpa", VariableCrispOutput}: float(0.9),
+		{"attendance", "final_exam"}: nil,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Correlation(w, httptest.NewRequest("GET", "/analytics/correlation", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response CorrelationResponse
+	decodeData(t, w, &response)
+	if len(response.Matrix) != len(Variables()) {
+		t.Fatalf("expected %dx%d matrix", len(Variables()), len(Variables()))
+	}
+	for i := range response.Matrix {
+		if *response.Matrix[i][i] != 1 {
+			t.Errorf("diagonal %d should be 1", i)
+		}
+	}
+	if *response.Matrix[0][1] != 0.8 || *response.Matrix[1][0] != 0.8 {
+		t.Errorf("matrix should be symmetric, got %v and %v", *response.Matrix[0][1], *response.Matrix[1][0])
+	}
+	if *response.Matrix[5][0] != 0.9 {
+		t.Errorf("expected gpa/crisp_output correlation 0.9, got %v", *response.Matrix[5][0])
+	}
+	if response.Matrix[2][4] != nil {
+		t.Errorf("undefined correlation should be null")
+	}
+}
+
+func TestAnalyticsHandler_Compare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAnalyticsRepository(ctrl)
+	handler := NewAnalyticsHandler(mockRepo)
+
+	mockRepo.EXPECT().Compare(gomock.Any(), gomock.Any(), ByTerm).Return([]GroupStats{
+		{ID: 1, Label: "2024/2025 even", Count: 3, Assessed: 2, Categories: map[string]int64{"Good": 2}},
+		{ID: 0, Label: "(no term)", Count: 1},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Compare(w, httptest.NewRequest("GET", "/analytics/compare?by=term", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response CompareResponse
+	decodeData(t, w, &response)
+	if response.By != ByTerm || len(response.Groups) != 2 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	if len(response.Groups[1].Categories) != 5 || response.Groups[0].Categories["Good"] != 2 {
+		t.Errorf("every group should list every category: %+v", response.Groups)
+	}
+}
+
+func TestAnalyticsHandler_Compare_Errors(t *testing.T) {
+	t.Run("unknown dimension", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := NewAnalyticsHandler(NewMockAnalyticsRepository(ctrl))
+
+		w := httptest.NewRecorder()
+		handler.Compare(w, httptest.NewRequest("GET", "/analytics/compare?by=faculty", nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockAnalyticsRepository(ctrl)
+		handler := NewAnalyticsHandler(mockRepo)
+
+		mockRepo.EXPECT().Compare(gomock.Any(), gomock.Any(), ByUniversity).Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		handler.Compare(w, httptest.NewRequest("GET", "/analytics/compare", nil))
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", w.Code)
+		}
+	})
+}
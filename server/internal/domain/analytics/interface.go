@@ -0,0 +1,33 @@
+package analytics
+
+import (
+	"context"
+	"net/http"
+)
+
+// AnalyticsRepository aggregates the cohort selected by a CohortFilter in the
+// database. Scores and categories come from the latest assessment of each
+// academic record with the filter's method; records without one only count
+// towards the inputs.
+type AnalyticsRepository interface {
+	// Count returns the number of academic records and how many of them are assessed
+	Count(ctx context.Context, filter CohortFilter) (total, assessed int64, err error)
+	// Summary returns the descriptive statistics of every variable
+	Summary(ctx context.Context, filter CohortFilter) ([]VariableStats, error)
+	CategoryCounts(ctx context.Context, filter CohortFilter) (map[string]int64, error)
+	// Histogram counts the values of variable in bins equal-width bins over
+	// [min, max], keyed by the 1-based bin number
+	Histogram(ctx context.Context, filter CohortFilter, variable string, min, max float64, bins int) (map[int]int64, error)
+	// Correlations returns the Pearson correlation of every pair of variables,
+	// nil when it is undefined
+	Correlations(ctx context.Context, filter CohortFilter) (map[[2]string]*float64, error)
+	// Compare aggregates the cohort per university or per term
+	Compare(ctx context.Context, filter CohortFilter, by string) ([]GroupStats, error)
+}
+
+type AnalyticsHandler interface {
+	Summary(w http.ResponseWriter, r *http.Request)
+	Histogram(w http.ResponseWriter, r *http.Request)
+	Correlation(w http.ResponseWriter, r *http.Request)
+	Compare(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,268 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"tsukamoto/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownVariable is returned for a variable outside Variables
+var ErrUnknownVariable = errors.New("unknown variable")
+
+// ErrUnknownDimension is returned by Compare for a dimension other than ByUniversity or ByTerm
+var ErrUnknownDimension = errors.New("unknown comparison dimension")
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// cohortSQL selects the filtered academic records with their inputs named as
+// in Variables, plus the score and category of the latest assessment made
+// with the filter's method. Callers wrap it in a CTE named c so that every
+// aggregate runs in the database. Records outside the caller's university
+// are never part of the cohort.
+//
+// Only stored assessments count: the alerts evaluator stores one whenever a
+// record changes (with ALERT_METHOD), POST /alerts/evaluate stores one for
+// every student and GET /fuzzy/{id}?store=true stores one on request. Reading
+// a score never stores it, so records without an assessment of the method
+// have no score or category and are reported through the assessed count.
+func cohortSQL(ctx context.Context, filter CohortFilter) (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString(`WITH c AS (
+	SELECT a.id, a.university_id, a.term_id,
+		a.gpa, a.core_course_average AS cca, a.attendance_rate AS attendance,
+		a.midterm_exam_score AS midterm, a.final_exam_score AS final_exam,
+		s.crisp_output, s.category
+	FROM academics a
+	LEFT JOIN LATERAL (
+		SELECT crisp_output, category FROM assessments
+		WHERE academic_id = a.id AND method = ?
+		ORDER BY id DESC LIMIT 1
+	) s ON true
+	WHERE a.deleted_at IS NULL`)
+	args := []interface{}{filter.Method}
+	if filter.UniversityID != 0 {
+		sb.WriteString(" AND a.university_id = ?")
+		args = append(args, filter.UniversityID)
+	}
+	if scope := tenant.FromContext(ctx); !scope.All {
+		sb.WriteString(" AND a.university_id = ?")
+		args = append(args, scope.UniversityID)
+	}
+	if filter.TermID != 0 {
+		sb.WriteString(" AND a.term_id = ?")
+		args = append(args, filter.TermID)
+	}
+	sb.WriteString("\n)\n")
+	return sb.String(), args
+}
+
+func (r *analyticsRepository) Count(ctx context.Context, filter CohortFilter) (int64, int64, error) {
+	cte, args := cohortSQL(ctx, filter)
+	var row struct {
+		Total    int64
+		Assessed int64
+	}
+	err := r.db.WithContext(ctx).Raw(cte+"SELECT COUNT(*) AS total, COUNT(category) AS assessed FROM c", args...).Scan(&row).Error
+	return row.Total, row.Assessed, err
+}
+
+func (r *analyticsRepository) Summary(ctx context.Context, filter CohortFilter) ([]VariableStats, error) {
+	cte, args := cohortSQL(ctx, filter)
+	selects := make([]string, 0, len(Variables()))
+	for _, variable := range Variables() {
+		// Nama variabel berasal dari daftar tetap, bukan dari request
+		selects = append(selects, fmt.Sprintf(`SELECT '%[1]s' AS variable, COUNT(%[1]s) AS count,
+	AVG(%[1]s) AS mean, STDDEV_SAMP(%[1]s) AS std_dev, MIN(%[1]s) AS min,
+	percentile_cont(0.1) WITHIN GROUP (ORDER BY %[1]s) AS p10,
+	percentile_cont(0.25) WITHIN GROUP (ORDER BY %[1]s) AS p25,
+	percentile_cont(0.5) WITHIN GROUP (ORDER BY %[1]s) AS median,
+	percentile_cont(0.75) WITHIN GROUP (ORDER BY %[1]s) AS p75,
+	percentile_cont(0.9) WITHIN GROUP (ORDER BY %[1]s) AS p90,
+	MAX(%[1]s) AS max FROM c`, variable))
+	}
+
+	var stats []VariableStats
+	err := r.db.WithContext(ctx).Raw(cte+strings.Join(selects, "\nUNION ALL\n"), args...).Scan(&stats).Error
+	return stats, err
+}
+
+func (r *analyticsRepository) CategoryCounts(ctx context.Context, filter CohortFilter) (map[string]int64, error) {
+	cte, args := cohortSQL(ctx, filter)
+	var rows []struct {
+		Category string
+		Count    int64
+	}
+	err := r.db.WithContext(ctx).
+		Raw(cte+"SELECT category, COUNT(*) AS count FROM c WHERE category IS NOT NULL GROUP BY category", args...).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Category] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *analyticsRepository) Histogram(ctx context.Context, filter CohortFilter, variable string, min, max float64, bins int) (map[int]int64, error) {
+	if !ValidVariable(variable) {
+		return nil, ErrUnknownVariable
+	}
+	cte, args := cohortSQL(ctx, filter)
+	// width_bucket menaruh nilai di luar [min, max) pada bucket 0 atau bins+1;
+	// nilai tepat di batas atas masuk ke bin terakhir
+	query := cte + fmt.Sprintf(`SELECT GREATEST(1, LEAST(width_bucket(%[1]s, ?, ?, ?), ?)) AS bin, COUNT(*) AS count
+FROM c WHERE %[1]s IS NOT NULL GROUP BY bin`, variable)
+	args = append(args, min, max, bins, bins)
+
+	var rows []struct {
+		Bin   int
+		Count int64
+	}
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Bin] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *analyticsRepository) Correlations(ctx context.Context, filter CohortFilter) (map[[2]string]*float64, error) {
+	variables := Variables()
+	var pairs [][2]string
+	var selects []string
+	for i := range variables {
+		for j := i + 1; j < len(variables); j++ {
+			pairs = append(pairs, [2]string{variables[i], variables[j]})
+			selects = append(selects, fmt.Sprintf("corr(%s, %s)", variables[i], variables[j]))
+		}
+	}
+
+	cte, args := cohortSQL(ctx, filter)
+	rows, err := r.db.WithContext(ctx).Raw(cte+"SELECT "+strings.Join(selects, ", ")+" FROM c", args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]*float64, len(pairs))
+	dest := make([]interface{}, len(pairs))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	correlations := make(map[[2]string]*float64, len(pairs))
+	for i, pair := range pairs {
+		correlations[pair] = values[i]
+	}
+	return correlations, nil
+}
+
+// groupSQL returns the group key, label expression, join and ordering of a comparison dimension
+func groupSQL(by string) (key, label, join, order string, err error) {
+	switch by {
+	case ByUniversity:
+		return "c.university_id", "COALESCE(MIN(u.name), '')",
+			"LEFT JOIN universities u ON u.id = c.university_id", "c.university_id", nil
+	case ByTerm:
+		// Record tanpa term dikelompokkan dengan ID 0 di urutan terakhir
+		return "COALESCE(c.term_id, 0)", "COALESCE(MIN(t.academic_year) || ' ' || MIN(t.semester), '(no term)')",
+			"LEFT JOIN terms t ON t.id = c.term_id", "MIN(t.start_date) NULLS LAST, 1", nil
+	}
+	return "", "", "", "", ErrUnknownDimension
+}
+
+func (r *analyticsRepository) Compare(ctx context.Context, filter CohortFilter, by string) ([]GroupStats, error) {
+	key, label, join, order, err := groupSQL(by)
+	if err != nil {
+		return nil, err
+	}
+	cte, args := cohortSQL(ctx, filter)
+
+	var selects []string
+	for _, variable := range Variables() {
+		selects = append(selects, fmt.Sprintf("AVG(c.%[1]s) AS %[1]s", variable))
+	}
+	query := cte + fmt.Sprintf(`SELECT %s AS id, %s AS label, COUNT(*) AS count, COUNT(c.category) AS assessed,
+	%s, percentile_cont(0.5) WITHIN GROUP (ORDER BY c.crisp_output) AS median
+FROM c %s GROUP BY 1 ORDER BY %s`, key, label, strings.Join(selects, ", "), join, order)
+
+	var rows []struct {
+		ID          uint
+		Label       string
+		Count       int64
+		Assessed    int64
+		GPA         *float64 `gorm:"column:gpa"`
+		CCA         *float64 `gorm:"column:cca"`
+		Attendance  *float64
+		Midterm     *float64
+		FinalExam   *float64
+		CrispOutput *float64
+		Median      *float64
+	}
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var categoryRows []struct {
+		ID       uint
+		Category string
+		Count    int64
+	}
+	categoryQuery := cte + fmt.Sprintf(`SELECT %s AS id, c.category, COUNT(*) AS count
+FROM c WHERE c.category IS NOT NULL GROUP BY 1, 2`, key)
+	if err := r.db.WithContext(ctx).Raw(categoryQuery, args...).Scan(&categoryRows).Error; err != nil {
+		return nil, err
+	}
+	categories := make(map[uint]map[string]int64)
+	for _, row := range categoryRows {
+		if categories[row.ID] == nil {
+			categories[row.ID] = make(map[string]int64)
+		}
+		categories[row.ID][row.Category] = row.Count
+	}
+
+	groups := make([]GroupStats, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, GroupStats{
+			ID:       row.ID,
+			Label:    row.Label,
+			Count:    row.Count,
+			Assessed: row.Assessed,
+			Means: map[string]*float64{
+				"gpa":               row.GPA,
+				"cca":               row.CCA,
+				"attendance":        row.Attendance,
+				"midterm":           row.Midterm,
+				"final_exam":        row.FinalExam,
+				VariableCrispOutput: row.CrispOutput,
+			},
+			MedianScore: row.Median,
+			Categories:  categories[row.ID],
+		})
+	}
+	return groups, nil
+}
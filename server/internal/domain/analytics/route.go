@@ -0,0 +1,16 @@
+package analytics
+
+import (
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+func AnalyticsRoute(r *mux.Router, db *gorm.DB) {
+	repo := NewAnalyticsRepository(db)
+	handler := NewAnalyticsHandler(repo)
+
+	r.HandleFunc("/analytics/summary", handler.Summary).Methods("GET")
+	r.HandleFunc("/analytics/histogram", handler.Histogram).Methods("GET")
+	r.HandleFunc("/analytics/correlation", handler.Correlation).Methods("GET")
+	r.HandleFunc("/analytics/compare", handler.Compare).Methods("GET")
+}
@@ -0,0 +1,22 @@
+package auditlog
+
+import "time"
+
+// AuditFilter narrows the audit log listing; entries are returned newest first
+type AuditFilter struct {
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   string
+	From       time.Time
+	To         time.Time // Eksklusif
+	BeforeID   int       // Untuk halaman berikutnya
+	Limit      int
+}
+
+// VerifyResponse reports whether the hash chain is intact
+type VerifyResponse struct {
+	Valid    bool `json:"valid"`
+	Entries  int  `json:"entries"`             // Jumlah entri yang terverifikasi
+	BrokenID int  `json:"broken_id,omitempty"` // Entri pertama yang diubah atau kehilangan pendahulunya
+}
@@ -0,0 +1,137 @@
+package auditlog
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/utils"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+	// verifyBatchSize is how many entries are loaded at a time while verifying
+	verifyBatchSize = 500
+)
+
+type auditHandler struct {
+	repo AuditRepository
+}
+
+func NewAuditHandler(repo AuditRepository) AuditHandler {
+	return &auditHandler{repo: repo}
+}
+
+// parseTime accepts RFC 3339 timestamps and plain dates. A plain date used as
+// the end of the range covers the whole day.
+func parseTime(value string, end bool) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, true
+}
+
+// List handles GET /audit?actor_id=&action=&entity_type=&entity_id=&from=&to=&before_id=&limit=
+func (h *auditHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := AuditFilter{
+		Action:     query.Get("action"),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		Limit:      defaultListLimit,
+	}
+
+	var validationErrors []utils.ErrorDetail
+	if value := query.Get("actor_id"); value != "" {
+		actorID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || actorID == 0 {
+			validationErrors = append(validationErrors, utils.ErrorDetail{Field: "actor_id", Message: "ID aktor tidak valid"})
+		}
+		filter.ActorID = uint(actorID)
+	}
+	if value := query.Get("from"); value != "" {
+		from, ok := parseTime(value, false)
+		if !ok {
+			validationErrors = append(validationErrors, utils.ErrorDetail{Field: "from", Message: "Tanggal harus berformat YYYY-MM-DD atau RFC 3339"})
+		}
+		filter.From = from
+	}
+	if value := query.Get("to"); value != "" {
+		to, ok := parseTime(value, true)
+		if !ok {
+			validationErrors = append(validationErrors, utils.ErrorDetail{Field: "to", Message: "Tanggal harus berformat YYYY-MM-DD atau RFC 3339"})
+		}
+		filter.To = to
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		validationErrors = append(validationErrors, utils.ErrorDetail{Field: "to", Message: "Akhir rentang harus setelah awal rentang"})
+	}
+	if value := query.Get("before_id"); value != "" {
+		beforeID, err := strconv.Atoi(value)
+		if err != nil || beforeID <= 0 {
+			validationErrors = append(validationErrors, utils.ErrorDetail{Field: "before_id", Message: "ID tidak valid"})
+		}
+		filter.BeforeID = beforeID
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			validationErrors = append(validationErrors, utils.ErrorDetail{Field: "limit", Message: "Limit tidak valid"})
+		}
+		filter.Limit = limit
+	}
+	if len(validationErrors) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, validationErrors, nil)
+		return
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	entries, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil audit log"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, entries)
+}
+
+// Verify handles GET /audit/verify and walks the whole hash chain
+func (h *auditHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	response := VerifyResponse{Valid: true}
+	prevHash, afterID := "", 0
+	for {
+		entries, err := h.repo.ListAfter(r.Context(), afterID, verifyBatchSize)
+		if err != nil {
+			utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memverifikasi audit log"}}, nil)
+			return
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		brokenID, lastHash := audit.Verify(prevHash, entries)
+		if brokenID != 0 {
+			response.Valid = false
+			response.BrokenID = brokenID
+			for _, entry := range entries {
+				if entry.ID == brokenID {
+					break
+				}
+				response.Entries++
+			}
+			break
+		}
+		response.Entries += len(entries)
+		prevHash, afterID = lastHash, entries[len(entries)-1].ID
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, response)
+}
@@ -0,0 +1,127 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+)
+
+func chainedEntries(n int) []models.AuditLog {
+	store := &audit.MemoryStore{}
+	for i := 0; i < n; i++ {
+		store.Append(context.Background(), nil, &models.AuditLog{
+			Action:     models.AuditUpdate,
+			EntityType: models.AuditEntityAcademic,
+			EntityID:   "1",
+			CreatedAt:  time.Date(2026, 3, 1, 8, i, 0, 0, time.UTC),
+		})
+	}
+	return store.Entries
+}
+
+func decodeVerify(t *testing.T, w *httptest.ResponseRecorder) VerifyResponse {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data VerifyResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	return response.Data
+}
+
+func TestAuditHandler_List_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuditRepository(ctrl)
+
+	mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter AuditFilter) ([]models.AuditLog, error) {
+		if filter.ActorID != 3 || filter.EntityType != "user" || filter.Limit != maxListLimit {
+			t.Errorf("unexpected filter %+v", filter)
+		}
+		if !filter.From.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !filter.To.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected the whole of 1 March, got %v - %v", filter.From, filter.To)
+		}
+		return chainedEntries(1), nil
+	})
+
+	w := httptest.NewRecorder()
+	NewAuditHandler(mockRepo).List(w, httptest.NewRequest("GET", "/audit?actor_id=3&entity_type=user&from=2026-03-01&to=2026-03-01&limit=1000", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAuditHandler_List_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuditRepository(ctrl)
+
+	w := httptest.NewRecorder()
+	NewAuditHandler(mockRepo).List(w, httptest.NewRequest("GET", "/audit?actor_id=x&from=kemarin&before_id=-1&limit=0", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	var response struct {
+		Errors []map[string]string `json:"errors"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	if len(response.Errors) != 4 {
+		t.Errorf("expected actor_id, from, before_id and limit errors, got %v", response.Errors)
+	}
+}
+
+func TestAuditHandler_Verify_Valid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuditRepository(ctrl)
+
+	entries := chainedEntries(3)
+	mockRepo.EXPECT().ListAfter(gomock.Any(), 0, verifyBatchSize).Return(entries, nil)
+	mockRepo.EXPECT().ListAfter(gomock.Any(), 3, verifyBatchSize).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	NewAuditHandler(mockRepo).Verify(w, httptest.NewRequest("GET", "/audit/verify", nil))
+	if response := decodeVerify(t, w); !response.Valid || response.Entries != 3 || response.BrokenID != 0 {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestAuditHandler_Verify_Tampered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuditRepository(ctrl)
+
+	entries := chainedEntries(3)
+	entries[2].ActorRole = "admin"
+	mockRepo.EXPECT().ListAfter(gomock.Any(), 0, verifyBatchSize).Return(entries, nil)
+
+	w := httptest.NewRecorder()
+	NewAuditHandler(mockRepo).Verify(w, httptest.NewRequest("GET", "/audit/verify", nil))
+	if response := decodeVerify(t, w); response.Valid || response.Entries != 2 || response.BrokenID != 3 {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestAuditHandler_Verify_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuditRepository(ctrl)
+
+	mockRepo.EXPECT().ListAfter(gomock.Any(), 0, verifyBatchSize).Return(nil, errors.New("db error"))
+
+	w := httptest.NewRecorder()
+	NewAuditHandler(mockRepo).Verify(w, httptest.NewRequest("GET", "/audit/verify", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
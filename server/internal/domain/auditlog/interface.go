@@ -0,0 +1,23 @@
+package auditlog
+
+import (
+	"context"
+	"net/http"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	// Append chains the entry to the latest one and stores it, inside tx
+	// when it is not nil
+	Append(ctx context.Context, tx *gorm.DB, entry *models.AuditLog) error
+	List(ctx context.Context, filter AuditFilter) ([]models.AuditLog, error)
+	// ListAfter returns up to limit entries with an ID above afterID in ID order
+	ListAfter(ctx context.Context, afterID, limit int) ([]models.AuditLog, error)
+}
+
+type AuditHandler interface {
+	List(w http.ResponseWriter, r *http.Request)
+	Verify(w http.ResponseWriter, r *http.Request)
+}
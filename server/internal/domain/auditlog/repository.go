@@ -0,0 +1,78 @@
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Append joins tx when the entry records a change made in that transaction,
+// so the entry is only committed together with the change
+func (r *auditRepository) Append(ctx context.Context, tx *gorm.DB, entry *models.AuditLog) error {
+	if tx != nil {
+		return appendEntry(tx, entry)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return appendEntry(tx, entry)
+	})
+}
+
+func appendEntry(tx *gorm.DB, entry *models.AuditLog) error {
+	// Kunci tabel agar dua entri tidak merantai ke hash yang sama
+	if err := tx.Exec("LOCK TABLE audit_logs IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+		return err
+	}
+	var last models.AuditLog
+	err := tx.Select("hash").Order("id DESC").Take(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	audit.Chain(entry, last.Hash)
+	return tx.Create(entry).Error
+}
+
+func (r *auditRepository) List(ctx context.Context, filter AuditFilter) ([]models.AuditLog, error) {
+	query := r.db.WithContext(ctx).Order("id DESC").Limit(filter.Limit)
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if filter.BeforeID != 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var entries []models.AuditLog
+	err := query.Find(&entries).Error
+	return entries, err
+}
+
+func (r *auditRepository) ListAfter(ctx context.Context, afterID, limit int) ([]models.AuditLog, error) {
+	var entries []models.AuditLog
+	err := r.db.WithContext(ctx).Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&entries).Error
+	return entries, err
+}
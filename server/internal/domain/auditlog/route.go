@@ -0,0 +1,14 @@
+package auditlog
+
+import (
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+func AuditRoute(r *mux.Router, db *gorm.DB) {
+	repo := NewAuditRepository(db)
+	handler := NewAuditHandler(repo)
+
+	r.HandleFunc("/audit", handler.List).Methods("GET")
+	r.HandleFunc("/audit/verify", handler.Verify).Methods("GET")
+}
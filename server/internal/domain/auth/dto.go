@@ -6,9 +6,28 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 type RegisterRequest struct {
 	Username          string `json:"username"`
 	Name              string `json:"name"`
+	Email             string `json:"email,omitempty"` // Alamat untuk notifikasi email
 	Password          string `json:"password"`
 	Role              string `json:"role"`
 	UniversityID      uint   `json:"university_id,omitempty"`      // For selecting existing university
@@ -17,8 +36,14 @@ type RegisterRequest struct {
 }
 
 // Response structs
-type LoginResponse struct {
-	Token string `json:"token"`
+
+// TokenPair is a short-lived access token with the refresh token that renews it
+type TokenPair struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // Umur access token dalam detik
+	// Token hanya bisa dipakai untuk POST /auth/password sampai password diganti
+	MustChangePassword bool `json:"must_change_password,omitempty"`
 }
 
 type RegisterResponse struct {
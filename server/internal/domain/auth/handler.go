@@ -2,18 +2,48 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+	"tsukamoto/internal/middleware"
 	"tsukamoto/internal/models"
 	"tsukamoto/internal/utils"
+
+	"github.com/sirupsen/logrus"
 )
 
 type authHandler struct {
-	repo AuthRepository
+	repo     AuthRepository
+	sessions *SessionService
+	resets   *PasswordResetService
+	throttle *LoginThrottle
+	policy   utils.PasswordPolicy
+}
+
+func NewAuthHandler(repo AuthRepository, sessions *SessionService, resets *PasswordResetService, throttle *LoginThrottle, policy utils.PasswordPolicy) AuthHandler {
+	return &authHandler{repo: repo, sessions: sessions, resets: resets, throttle: throttle, policy: policy}
+}
+
+func tooManyAttempts(w http.ResponseWriter, wait time.Duration) {
+	tooManyRequests(w, wait, "Too many failed attempts, try again later")
+}
+
+func tooManyRequests(w http.ResponseWriter, wait time.Duration, message string) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	utils.WriteResponse(w, http.StatusTooManyRequests, []utils.ErrorDetail{
+		{Message: message},
+	}, nil)
 }
 
-func NewAuthHandler(repo AuthRepository) AuthHandler {
-	return &authHandler{repo: repo}
+func (h *authHandler) passwordErrors(password, username string) []utils.ErrorDetail {
+	var details []utils.ErrorDetail
+	for _, message := range h.policy.Validate(password, username) {
+		details = append(details, utils.ErrorDetail{Field: "password", Message: message})
+	}
+	return details
 }
 
 func (h *authHandler) Login(w http.ResponseWriter, r *http.Request) {
@@ -25,39 +55,227 @@ func (h *authHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ip := middleware.ClientIP(r)
+	if wait := h.throttle.Locked(req.Username, ip); wait > 0 {
+		tooManyAttempts(w, wait)
+		return
+	}
+
 	user, err := h.repo.GetUserByUsername(r.Context(), req.Username)
-	if err != nil {
+	if err != nil || !utils.CheckPassword(req.Password, user.Password) {
+		// Username yang tidak ada dihitung sama agar akun tidak bisa ditebak
+		h.throttle.Fail(req.Username, ip)
 		utils.WriteResponse(w, http.StatusUnauthorized, []utils.ErrorDetail{
 			{Message: "Invalid credentials"},
 		}, nil)
 		return
 	}
+	h.throttle.Succeed(req.Username)
+
+	// Akun yang masih memakai password bawaan (seed atau impor) wajib menggantinya
+	if !user.MustChangePassword && utils.IsDefaultPassword(req.Password, user.Username) {
+		if err := h.repo.RequirePasswordChange(r.Context(), user.ID); err != nil {
+			logrus.WithError(err).WithField("user_id", user.ID).Warn("Failed to flag default password")
+		}
+		user.MustChangePassword = true
+	}
+
+	pair, err := h.sessions.Issue(r.Context(), user, "")
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{
+			{Message: "Error generating token"},
+		}, nil)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, pair)
+}
+
+// Refresh exchanges a refresh token for a new token pair
+func (h *authHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Field: "refresh_token", Message: "Refresh token is required"},
+		}, nil)
+		return
+	}
 
-	if !utils.CheckPassword(req.Password, user.Password) {
+	pair, err := h.sessions.Refresh(r.Context(), req.RefreshToken)
+	if errors.Is(err, ErrInvalidRefreshToken) || errors.Is(err, ErrRefreshTokenReused) {
 		utils.WriteResponse(w, http.StatusUnauthorized, []utils.ErrorDetail{
-			{Message: "Invalid credentials"},
+			{Field: "refresh_token", Message: "Invalid refresh token"},
+		}, nil)
+		return
+	}
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{
+			{Message: "Error refreshing token"},
+		}, nil)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, pair)
+}
+
+// ChangePassword replaces the caller's password after checking the current
+// one. Every other session is ended and a fresh token pair is returned.
+func (h *authHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteResponse(w, http.StatusUnauthorized, []utils.ErrorDetail{{Message: "Authentication required"}}, nil)
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Message: "Invalid request format"},
+		}, nil)
+		return
+	}
+
+	ip := middleware.ClientIP(r)
+	if wait := h.throttle.Locked(caller.Username, ip); wait > 0 {
+		tooManyAttempts(w, wait)
+		return
+	}
+
+	user, err := h.repo.GetUserByID(r.Context(), int(caller.ID))
+	if err != nil {
+		utils.WriteResponse(w, http.StatusUnauthorized, []utils.ErrorDetail{{Message: "Authentication required"}}, nil)
+		return
+	}
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		h.throttle.Fail(user.Username, ip)
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Field: "current_password", Message: "Current password is incorrect"},
 		}, nil)
 		return
 	}
 
-	// Get academic info if exists
-	academic, err := h.repo.GetUserAcademic(r.Context(), user.ID)
-	var universityID int
-	var universityName string
-	if err == nil && academic.University.ID != 0 {
-		universityID = int(academic.University.ID)
-		universityName = academic.University.Name
+	details := h.passwordErrors(req.NewPassword, user.Username)
+	if req.NewPassword == req.CurrentPassword {
+		details = append(details, utils.ErrorDetail{Field: "new_password", Message: "New password must differ from the current password"})
+	}
+	if len(details) > 0 {
+		for i := range details {
+			details[i].Field = "new_password"
+		}
+		utils.WriteResponse(w, http.StatusBadRequest, details, nil)
+		return
+	}
+
+	if err := h.repo.UpdatePassword(r.Context(), user.ID, utils.HashPassword(req.NewPassword)); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Failed to change password"}}, nil)
+		return
+	}
+	if err := h.sessions.RevokeAll(r.Context(), uint(user.ID)); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Failed to revoke sessions"}}, nil)
+		return
 	}
 
-	token, err := utils.GenerateJWT(user.ID, user.Username, user.Role, universityID, universityName)
+	user.MustChangePassword = false
+	pair, err := h.sessions.Issue(r.Context(), user, "")
 	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Error generating token"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, pair)
+}
+
+// ForgotPassword queues an email with a reset token. The response is the same
+// whether or not the address belongs to an account; requests are limited per
+// address and per IP.
+func (h *authHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Email) == "" {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Field: "email", Message: "Email is required"},
+		}, nil)
+		return
+	}
+
+	if wait := h.resets.Throttle(req.Email, middleware.ClientIP(r)); wait > 0 {
+		tooManyRequests(w, wait, "Too many reset requests, try again later")
+		return
+	}
+
+	err := h.resets.Request(r.Context(), req.Email)
+	if errors.Is(err, ErrResetUnavailable) {
+		utils.WriteResponse(w, http.StatusServiceUnavailable, []utils.ErrorDetail{
+			{Message: "Password reset is not available"},
+		}, nil)
+		return
+	}
+	if err != nil {
+		// Kegagalan tidak dibedakan agar keberadaan akun tidak terbongkar
+		logrus.WithError(err).Error("Failed to queue password reset email")
+	}
+
+	utils.WriteResponse(w, http.StatusAccepted, nil, map[string]string{
+		"message": "If the email belongs to an account, a reset link has been sent",
+	})
+}
+
+// ResetPassword sets a new password using an emailed reset token
+func (h *authHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Message: "Invalid request format"},
+		}, nil)
+		return
+	}
+	if req.Token == "" {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Field: "token", Message: "Reset token is required"},
+		}, nil)
+		return
+	}
+
+	err := h.resets.Reset(r.Context(), req.Token, req.NewPassword)
+	var weak *WeakPasswordError
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.As(err, &weak):
+		var details []utils.ErrorDetail
+		for _, message := range weak.Problems {
+			details = append(details, utils.ErrorDetail{Field: "new_password", Message: message})
+		}
+		utils.WriteResponse(w, http.StatusBadRequest, details, nil)
+	case errors.Is(err, ErrInvalidResetToken):
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Field: "token", Message: "Reset token is invalid or has expired"},
+		}, nil)
+	default:
 		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{
-			{Message: "Error generating token"},
+			{Message: "Failed to reset password"},
+		}, nil)
+	}
+}
+
+// Logout revokes the session of the refresh token
+func (h *authHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+			{Field: "refresh_token", Message: "Refresh token is required"},
+		}, nil)
+		return
+	}
+
+	err := h.sessions.Logout(r.Context(), req.RefreshToken)
+	if err != nil && !errors.Is(err, ErrInvalidRefreshToken) {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{
+			{Message: "Error revoking session"},
 		}, nil)
 		return
 	}
 
-	utils.WriteResponse(w, http.StatusOK, nil, LoginResponse{Token: token})
+	// Token yang tidak dikenal tetap dianggap sudah logout
+	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *authHandler) Register(w http.ResponseWriter, r *http.Request) {
@@ -108,7 +326,7 @@ func (h *authHandler) Register(w http.ResponseWriter, r *http.Request) {
 			}, nil)
 			return
 		}
-	} else if req.Role == "student" {
+	} else if req.Role == models.RoleStudent {
 		// University is required for students
 		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
 			{Message: "University information is required for students"},
@@ -120,9 +338,14 @@ func (h *authHandler) Register(w http.ResponseWriter, r *http.Request) {
 	user := &models.User{
 		Username: req.Username,
 		Name:     req.Name,
+		Email:    req.Email,
 		Password: utils.HashPassword(req.Password),
 		Role:     req.Role,
 	}
+	if university != nil {
+		universityID := uint(university.ID)
+		user.UniversityID = &universityID
+	}
 
 	createdUser, err := h.repo.CreateUser(r.Context(), user)
 	if err != nil {
@@ -133,7 +356,7 @@ func (h *authHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create academic record for students
-	if req.Role == "student" && university != nil {
+	if req.Role == models.RoleStudent && university != nil {
 		academic := &models.Academic{
 			UserID:       uint(createdUser.ID),
 			UniversityID: uint(university.ID),
@@ -220,11 +443,8 @@ func (h *authHandler) validateRegisterRequest(req RegisterRequest) []utils.Error
 			Field:   "password",
 			Message: "Password is required",
 		})
-	} else if len(req.Password) < 6 {
-		errors = append(errors, utils.ErrorDetail{
-			Field:   "password",
-			Message: "Password must be at least 6 characters",
-		})
+	} else {
+		errors = append(errors, h.passwordErrors(req.Password, req.Username)...)
 	}
 
 	// Validate role
@@ -233,15 +453,16 @@ func (h *authHandler) validateRegisterRequest(req RegisterRequest) []utils.Error
 			Field:   "role",
 			Message: "Role is required",
 		})
-	} else if req.Role != "admin" && req.Role != "student" {
+	} else if req.Role != models.RoleStudent {
+		// Peran lain hanya boleh diberikan oleh admin lewat /users
 		errors = append(errors, utils.ErrorDetail{
 			Field:   "role",
-			Message: "Role must be either 'admin' or 'student'",
+			Message: "Only students can register themselves",
 		})
 	}
 
 	// Validate university for students
-	if req.Role == "student" {
+	if req.Role == models.RoleStudent {
 		if req.UniversityID == 0 && (req.UniversityName == "" || req.UniversityAddress == "") {
 			errors = append(errors, utils.ErrorDetail{
 				Field:   "university",
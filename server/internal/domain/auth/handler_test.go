@@ -7,6 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/middleware"
 	"tsukamoto/internal/models"
 	"tsukamoto/internal/utils"
 
@@ -22,6 +25,21 @@ func bcryptHash(password string) string {
 	return string(hash)
 }
 
+func testTokens(t *testing.T) *utils.JWTUtil {
+	t.Helper()
+	tokens, err := utils.NewJWTUtil(&config.Config{JWTSecret: "test-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+// newTestHandler builds a handler with the default throttle and password policy
+func newTestHandler(repo AuthRepository, sessions *SessionService) AuthHandler {
+	resets := NewPasswordResetService(repo, sessions, false, utils.DefaultPasswordPolicy(), DefaultResetOptions())
+	return NewAuthHandler(repo, sessions, resets, NewLoginThrottle(DefaultThrottleOptions()), utils.DefaultPasswordPolicy())
+}
+
 // Helper function to parse response
 func parseResponse(w *httptest.ResponseRecorder) utils.Response {
 	var resp utils.Response
@@ -35,7 +53,8 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	tokens := testTokens(t)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, tokens, time.Hour))
 
 	// password yang akan diinput user
 	rawPassword := "mypassword"
@@ -48,6 +67,9 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 	mockRepo.EXPECT().
 		GetUserByUsername(gomock.Any(), "user").
 		Return(user, nil)
+	mockRepo.EXPECT().
+		CreateRefreshToken(gomock.Any(), gomock.Any()).
+		Return(nil)
 	mockRepo.EXPECT().
 		GetUserAcademic(gomock.Any(), 1).
 		Return(academic, nil)
@@ -73,13 +95,52 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 		t.Errorf("expected data, got nil")
 	}
 
-	// Parse the data as LoginResponse
+	// Parse the data as TokenPair
 	dataBytes, _ := json.Marshal(resp.Data)
-	var loginResp LoginResponse
+	var loginResp TokenPair
 	json.Unmarshal(dataBytes, &loginResp)
 
-	if loginResp.Token == "" {
-		t.Errorf("expected token, got empty")
+	if loginResp.Token == "" || loginResp.RefreshToken == "" {
+		t.Errorf("expected access and refresh token, got %+v", loginResp)
+	}
+
+	claims, err := tokens.ValidateJWT(loginResp.Token)
+	if err != nil {
+		t.Fatalf("issued token should validate: %v", err)
+	}
+	if claims.UserID != 1 || claims.Role != "student" || claims.UniversityID != 2 || claims.UniversityName != "Univ" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+}
+
+func TestAuthHandler_Login_FlagsDefaultPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAuthRepository(ctrl)
+	tokens := testTokens(t)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, tokens, time.Hour))
+
+	// Mahasiswa hasil impor masih memakai username sebagai password
+	user := &models.User{ID: 3, Username: "student42", Password: bcryptHash("student42"), Role: "student"}
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "student42").Return(user, nil)
+	mockRepo.EXPECT().RequirePasswordChange(gomock.Any(), 3).Return(nil)
+	mockRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetUserAcademic(gomock.Any(), 3).Return(nil, errors.New("not found")).AnyTimes()
+
+	body, _ := json.Marshal(LoginRequest{Username: "student42", Password: "student42"})
+	w := httptest.NewRecorder()
+	handler.Login(w, httptest.NewRequest("POST", loginPath, bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	dataBytes, _ := json.Marshal(parseResponse(w).Data)
+	var pair TokenPair
+	json.Unmarshal(dataBytes, &pair)
+	claims, err := tokens.ValidateJWT(pair.Token)
+	if err != nil || !claims.MustChangePassword || !pair.MustChangePassword {
+		t.Errorf("expected a token limited to changing the password, got %+v, %v", pair, err)
 	}
 }
 
@@ -88,7 +149,7 @@ func TestAuthHandler_Login_BadRequest(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	req := httptest.NewRequest("POST", loginPath, bytes.NewReader([]byte("invalid")))
 	w := httptest.NewRecorder()
@@ -114,7 +175,7 @@ func TestAuthHandler_Login_UserNotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	mockRepo.EXPECT().
 		GetUserByUsername(gomock.Any(), "user").
@@ -146,7 +207,7 @@ func TestAuthHandler_Login_WrongPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	// Simpan hash dari password yang benar
 	hashedPassword := bcryptHash("mypassword")
@@ -183,7 +244,7 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	// Mock university
 	university := &models.University{ID: 1, Name: "Test University"}
@@ -254,53 +315,30 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	}
 }
 
-func TestAuthHandler_Register_Success_Admin(t *testing.T) {
+func TestAuthHandler_Register_AdminRejected(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
-
-	// Mock user not found (username available)
-	mockRepo.EXPECT().
-		GetUserByUsername(gomock.Any(), "adminuser").
-		Return(nil, errors.New("not found"))
-
-	// Mock successful user creation
-	expectedUser := &models.User{
-		ID:       1,
-		Username: "adminuser",
-		Name:     "Admin User",
-		Role:     "admin",
-	}
-	mockRepo.EXPECT().
-		CreateUser(gomock.Any(), gomock.Any()).
-		Return(expectedUser, nil)
-
-	reqBody := RegisterRequest{
-		Username: "adminuser",
-		Name:     "Admin User",
-		Password: "password123",
-		Role:     "admin",
-		// No university required for admin
-	}
-	body, _ := json.Marshal(reqBody)
-	req := httptest.NewRequest("POST", registerPath, bytes.NewReader(body))
-	w := httptest.NewRecorder()
-
-	handler.Register(w, req)
-
-	if w.Code != http.StatusCreated {
-		t.Errorf("expected 201, got %d", w.Code)
-	}
-
-	resp := parseResponse(w)
-	if resp.Errors != nil {
-		t.Errorf("expected no errors, got %v", resp.Errors)
-	}
-
-	if resp.Data == nil {
-		t.Errorf("expected data, got nil")
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
+
+	// Privileged roles are granted by an admin, never through self-registration
+	for _, role := range []string{"admin", "lecturer", "advisor"} {
+		reqBody := RegisterRequest{
+			Username: "adminuser",
+			Name:     "Admin User",
+			Password: "password123",
+			Role:     role,
+		}
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", registerPath, bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.Register(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", role, w.Code)
+		}
 	}
 }
 
@@ -309,7 +347,7 @@ func TestAuthHandler_Register_BadRequest(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	req := httptest.NewRequest("POST", registerPath, bytes.NewReader([]byte("invalid")))
 	w := httptest.NewRecorder()
@@ -331,7 +369,7 @@ func TestAuthHandler_Register_ValidationErrors(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	// Test with invalid data
 	reqBody := RegisterRequest{
@@ -366,7 +404,7 @@ func TestAuthHandler_Register_UsernameExists(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	// Hapus mockRepo.EXPECT().GetUniversityByID karena tidak dipanggil sebelum username check
 
@@ -412,7 +450,7 @@ func TestAuthHandler_Register_DatabaseError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	// Mock university
 	university := &models.University{ID: 1, Name: "Test University"}
@@ -465,7 +503,7 @@ func TestAuthHandler_Register_CreateNewUniversity(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAuthRepository(ctrl)
-	handler := NewAuthHandler(mockRepo)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
 
 	// Mock new university creation
 	newUniversity := &models.University{ID: 2, Name: "New University", Address: "New Address"}
@@ -523,3 +561,120 @@ func TestAuthHandler_Register_CreateNewUniversity(t *testing.T) {
 		t.Errorf("expected data, got nil")
 	}
 }
+
+func TestAuthHandler_Login_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAuthRepository(ctrl)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
+
+	user := &models.User{ID: 1, Username: "user", Password: bcryptHash("mypassword1"), Role: "student"}
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "user").Return(user, nil).Times(DefaultThrottleOptions().MaxAccountAttempts)
+
+	body, _ := json.Marshal(LoginRequest{Username: "user", Password: "wrong"})
+	for i := 0; i < DefaultThrottleOptions().MaxAccountAttempts; i++ {
+		w := httptest.NewRecorder()
+		handler.Login(w, httptest.NewRequest("POST", loginPath, bytes.NewReader(body)))
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d", i+1, w.Code)
+		}
+	}
+
+	// Password yang benar pun ditolak selama akun terkunci
+	body, _ = json.Marshal(LoginRequest{Username: "user", Password: "mypassword1"})
+	w := httptest.NewRecorder()
+	handler.Login(w, httptest.NewRequest("POST", loginPath, bytes.NewReader(body)))
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+}
+
+func TestAuthHandler_Register_PasswordPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAuthRepository(ctrl)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, testTokens(t), time.Hour))
+
+	for _, password := range []string{"short1", "onlyletters", "12345678", "budi2024x"} {
+		body, _ := json.Marshal(RegisterRequest{Username: "budi", Name: "Budi", Email: "budi@example.com", Password: password, Role: "student"})
+		w := httptest.NewRecorder()
+		handler.Register(w, httptest.NewRequest("POST", registerPath, bytes.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", password, w.Code)
+			continue
+		}
+		resp := parseResponse(w)
+		if len(resp.Errors) == 0 || resp.Errors[0].Field != "password" {
+			t.Errorf("%q: expected a password error, got %+v", password, resp.Errors)
+		}
+	}
+}
+
+func TestAuthHandler_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAuthRepository(ctrl)
+	tokens := testTokens(t)
+	handler := newTestHandler(mockRepo, NewSessionService(mockRepo, tokens, time.Hour))
+
+	user := &models.User{ID: 1, Username: "user", Password: bcryptHash("initial123"), Role: "student", MustChangePassword: true}
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest("POST", "/auth/password", bytes.NewReader([]byte(body)))
+		return req.WithContext(middleware.WithUser(req.Context(), middleware.AuthUser{ID: 1, Username: "user", Role: "student", MustChangePassword: true}))
+	}
+
+	w := httptest.NewRecorder()
+	handler.ChangePassword(w, httptest.NewRequest("POST", "/auth/password", bytes.NewReader([]byte(`{}`))))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("without caller: expected 401, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil).Times(4)
+
+	w = httptest.NewRecorder()
+	handler.ChangePassword(w, newRequest(`{"current_password":"wrong","new_password":"brandnew123"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong current password: expected 400, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ChangePassword(w, newRequest(`{"current_password":"initial123","new_password":"weak"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("weak new password: expected 400, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ChangePassword(w, newRequest(`{"current_password":"initial123","new_password":"initial123"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unchanged password: expected 400, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().UpdatePassword(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(_ interface{}, _ int, hash string) error {
+		if !utils.CheckPassword("brandnew123", hash) {
+			t.Error("new password should be stored hashed")
+		}
+		return nil
+	})
+	mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), uint(1), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetUserAcademic(gomock.Any(), 1).Return(nil, errors.New("not found"))
+
+	w = httptest.NewRecorder()
+	handler.ChangePassword(w, newRequest(`{"current_password":"initial123","new_password":"brandnew123"}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	dataBytes, _ := json.Marshal(parseResponse(w).Data)
+	var pair TokenPair
+	json.Unmarshal(dataBytes, &pair)
+	claims, err := tokens.ValidateJWT(pair.Token)
+	if err != nil || claims.MustChangePassword || pair.MustChangePassword {
+		t.Errorf("new token should no longer require a password change: %+v, %v", claims, err)
+	}
+}
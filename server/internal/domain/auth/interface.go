@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"net/http"
+	"time"
 	"tsukamoto/internal/models"
 )
 
@@ -14,10 +15,39 @@ type AuthRepository interface {
 	CreateUniversity(ctx context.Context, university *models.University) (*models.University, error)
 	GetAllUniversities(ctx context.Context) ([]models.University, error)
 	CreateAcademic(ctx context.Context, academic *models.Academic) (*models.Academic, error)
+	GetUserByID(ctx context.Context, id int) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	// UpdatePassword stores the new hash and clears the forced change flag
+	UpdatePassword(ctx context.Context, userID int, hash string) error
+	// RequirePasswordChange sets the forced change flag
+	RequirePasswordChange(ctx context.Context, userID int) error
+
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error)
+	// RotateRefreshToken marks the token as used; false means it was already
+	// rotated or revoked by a concurrent request
+	RotateRefreshToken(ctx context.Context, id int, at time.Time) (bool, error)
+	RevokeRefreshFamily(ctx context.Context, familyID string, at time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uint, at time.Time) error
+	// RefreshFamilyActive reports whether the family still has a usable token
+	RefreshFamilyActive(ctx context.Context, familyID string, now time.Time) (bool, error)
+
+	// CreatePasswordReset stores the token and invalidates the user's older ones
+	CreatePasswordReset(ctx context.Context, reset *models.PasswordReset) error
+	GetPasswordResetByHash(ctx context.Context, hash string) (*models.PasswordReset, error)
+	// UsePasswordReset marks the token as used; false means it was already used
+	UsePasswordReset(ctx context.Context, id int, at time.Time) (bool, error)
+	// CreateNotification queues an email for the notification worker
+	CreateNotification(ctx context.Context, notification *models.Notification) error
 }
 
 type AuthHandler interface {
 	Login(w http.ResponseWriter, r *http.Request)
 	Register(w http.ResponseWriter, r *http.Request)
 	GetUniversities(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+	Logout(w http.ResponseWriter, r *http.Request)
+	ChangePassword(w http.ResponseWriter, r *http.Request)
+	ForgotPassword(w http.ResponseWriter, r *http.Request)
+	ResetPassword(w http.ResponseWriter, r *http.Request)
 }
@@ -8,6 +8,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 	models "tsukamoto/internal/models"
 
 	gomock "github.com/golang/mock/gomock"
@@ -51,6 +52,48 @@ func (mr *MockAuthRepositoryMockRecorder) CreateAcademic(ctx, academic interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAcademic", reflect.TypeOf((*MockAuthRepository)(nil).CreateAcademic), ctx, academic)
 }
 
+// CreateNotification mocks base method.
+func (m *MockAuthRepository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockAuthRepositoryMockRecorder) CreateNotification(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockAuthRepository)(nil).CreateNotification), ctx, notification)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockAuthRepository) CreatePasswordReset(ctx context.Context, reset *models.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", ctx, reset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockAuthRepositoryMockRecorder) CreatePasswordReset(ctx, reset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockAuthRepository)(nil).CreatePasswordReset), ctx, reset)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) CreateRefreshToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateRefreshToken), ctx, token)
+}
+
 // CreateUniversity mocks base method.
 func (m *MockAuthRepository) CreateUniversity(ctx context.Context, university *models.University) (*models.University, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +139,36 @@ func (mr *MockAuthRepositoryMockRecorder) GetAllUniversities(ctx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUniversities", reflect.TypeOf((*MockAuthRepository)(nil).GetAllUniversities), ctx)
 }
 
+// GetPasswordResetByHash mocks base method.
+func (m *MockAuthRepository) GetPasswordResetByHash(ctx context.Context, hash string) (*models.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetByHash", ctx, hash)
+	ret0, _ := ret[0].(*models.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetByHash indicates an expected call of GetPasswordResetByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetPasswordResetByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetPasswordResetByHash), ctx, hash)
+}
+
+// GetRefreshTokenByHash mocks base method.
+func (m *MockAuthRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByHash", ctx, hash)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenByHash indicates an expected call of GetRefreshTokenByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetRefreshTokenByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetRefreshTokenByHash), ctx, hash)
+}
+
 // GetUniversityByID mocks base method.
 func (m *MockAuthRepository) GetUniversityByID(ctx context.Context, id int) (*models.University, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +199,36 @@ func (mr *MockAuthRepositoryMockRecorder) GetUserAcademic(ctx, userID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAcademic", reflect.TypeOf((*MockAuthRepository)(nil).GetUserAcademic), ctx, userID)
 }
 
+// GetUserByEmail mocks base method.
+func (m *MockAuthRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockAuthRepositoryMockRecorder) GetUserByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockAuthRepository)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByID mocks base method.
+func (m *MockAuthRepository) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockAuthRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAuthRepository)(nil).GetUserByID), ctx, id)
+}
+
 // GetUserByUsername mocks base method.
 func (m *MockAuthRepository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +244,107 @@ func (mr *MockAuthRepositoryMockRecorder) GetUserByUsername(ctx, username interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockAuthRepository)(nil).GetUserByUsername), ctx, username)
 }
 
+// RefreshFamilyActive mocks base method.
+func (m *MockAuthRepository) RefreshFamilyActive(ctx context.Context, familyID string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshFamilyActive", ctx, familyID, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshFamilyActive indicates an expected call of RefreshFamilyActive.
+func (mr *MockAuthRepositoryMockRecorder) RefreshFamilyActive(ctx, familyID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshFamilyActive", reflect.TypeOf((*MockAuthRepository)(nil).RefreshFamilyActive), ctx, familyID, now)
+}
+
+// RequirePasswordChange mocks base method.
+func (m *MockAuthRepository) RequirePasswordChange(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequirePasswordChange", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequirePasswordChange indicates an expected call of RequirePasswordChange.
+func (mr *MockAuthRepositoryMockRecorder) RequirePasswordChange(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequirePasswordChange", reflect.TypeOf((*MockAuthRepository)(nil).RequirePasswordChange), ctx, userID)
+}
+
+// RevokeRefreshFamily mocks base method.
+func (m *MockAuthRepository) RevokeRefreshFamily(ctx context.Context, familyID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshFamily", ctx, familyID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshFamily indicates an expected call of RevokeRefreshFamily.
+func (mr *MockAuthRepositoryMockRecorder) RevokeRefreshFamily(ctx, familyID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshFamily", reflect.TypeOf((*MockAuthRepository)(nil).RevokeRefreshFamily), ctx, familyID, at)
+}
+
+// RevokeUserRefreshTokens mocks base method.
+func (m *MockAuthRepository) RevokeUserRefreshTokens(ctx context.Context, userID uint, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, userID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens.
+func (mr *MockAuthRepositoryMockRecorder) RevokeUserRefreshTokens(ctx, userID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*MockAuthRepository)(nil).RevokeUserRefreshTokens), ctx, userID, at)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockAuthRepository) RotateRefreshToken(ctx context.Context, id int, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) RotateRefreshToken(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).RotateRefreshToken), ctx, id, at)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockAuthRepository) UpdatePassword(ctx context.Context, userID int, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockAuthRepositoryMockRecorder) UpdatePassword(ctx, userID, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockAuthRepository)(nil).UpdatePassword), ctx, userID, hash)
+}
+
+// UsePasswordReset mocks base method.
+func (m *MockAuthRepository) UsePasswordReset(ctx context.Context, id int, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordReset", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockAuthRepositoryMockRecorder) UsePasswordReset(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockAuthRepository)(nil).UsePasswordReset), ctx, id, at)
+}
+
 // MockAuthHandler is a mock of AuthHandler interface.
 type MockAuthHandler struct {
 	ctrl     *gomock.Controller
@@ -164,6 +368,30 @@ func (m *MockAuthHandler) EXPECT() *MockAuthHandlerMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockAuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ChangePassword", w, r)
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthHandlerMockRecorder) ChangePassword(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthHandler)(nil).ChangePassword), w, r)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockAuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForgotPassword", w, r)
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockAuthHandlerMockRecorder) ForgotPassword(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAuthHandler)(nil).ForgotPassword), w, r)
+}
+
 // GetUniversities mocks base method.
 func (m *MockAuthHandler) GetUniversities(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -188,6 +416,30 @@ func (mr *MockAuthHandlerMockRecorder) Login(w, r interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthHandler)(nil).Login), w, r)
 }
 
+// Logout mocks base method.
+func (m *MockAuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Logout", w, r)
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthHandlerMockRecorder) Logout(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthHandler)(nil).Logout), w, r)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Refresh", w, r)
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthHandlerMockRecorder) Refresh(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthHandler)(nil).Refresh), w, r)
+}
+
 // Register mocks base method.
 func (m *MockAuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -199,3 +451,15 @@ func (mr *MockAuthHandlerMockRecorder) Register(w, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthHandler)(nil).Register), w, r)
 }
+
+// ResetPassword mocks base method.
+func (m *MockAuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetPassword", w, r)
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthHandlerMockRecorder) ResetPassword(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthHandler)(nil).ResetPassword), w, r)
+}
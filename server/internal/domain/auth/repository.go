@@ -2,6 +2,9 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 	"tsukamoto/internal/models"
 
 	"gorm.io/gorm"
@@ -41,8 +44,19 @@ func (r *authRepository) GetUniversityByID(ctx context.Context, id int) (*models
 	return &university, err
 }
 
+// CreateUniversity returns the university with the same name, ignoring case,
+// instead of creating a duplicate
 func (r *authRepository) CreateUniversity(ctx context.Context, university *models.University) (*models.University, error) {
-	err := r.db.WithContext(ctx).Create(university).Error
+	var existing models.University
+	err := r.db.WithContext(ctx).Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(university.Name))).First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	university.Name = strings.TrimSpace(university.Name)
+	err = r.db.WithContext(ctx).Create(university).Error
 	return university, err
 }
 
@@ -57,6 +71,91 @@ func (r *authRepository) CreateAcademic(ctx context.Context, academic *models.Ac
 	return academic, err
 }
 
+func (r *authRepository) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	return &user, err
+}
+
+func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("LOWER(email) = LOWER(?)", email).First(&user).Error
+	return &user, err
+}
+
+func (r *authRepository) UpdatePassword(ctx context.Context, userID int, hash string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"password": hash, "must_change_password": false}).Error
+}
+
+func (r *authRepository) RequirePasswordChange(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).
+		Update("must_change_password", true).Error
+}
+
+func (r *authRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *authRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error
+	return &token, err
+}
+
+func (r *authRepository) RotateRefreshToken(ctx context.Context, id int, at time.Time) (bool, error) {
+	// Update bersyarat agar dua refresh bersamaan tidak sama-sama berhasil
+	result := r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Update("rotated_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *authRepository) RevokeRefreshFamily(ctx context.Context, familyID string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+func (r *authRepository) RevokeUserRefreshTokens(ctx context.Context, userID uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+func (r *authRepository) RefreshFamilyActive(ctx context.Context, familyID string, now time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("family_id = ? AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > ?", familyID, now).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *authRepository) CreatePasswordReset(ctx context.Context, reset *models.PasswordReset) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Hanya link terakhir yang berlaku
+		if err := tx.Model(&models.PasswordReset{}).
+			Where("user_id = ? AND used_at IS NULL", reset.UserID).
+			Update("used_at", reset.CreatedAt).Error; err != nil {
+			return err
+		}
+		return tx.Create(reset).Error
+	})
+}
+
+func (r *authRepository) GetPasswordResetByHash(ctx context.Context, hash string) (*models.PasswordReset, error) {
+	var reset models.PasswordReset
+	err := r.db.WithContext(ctx).Where("token_hash = ?", hash).First(&reset).Error
+	return &reset, err
+}
+
+func (r *authRepository) UsePasswordReset(ctx context.Context, id int, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.PasswordReset{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
 // Method terpisah untuk get university info (tetap dipertahankan untuk backward compatibility)
 func (r *authRepository) GetUserUniversity(ctx context.Context, userID int) (*models.Academic, error) {
 	var academic models.Academic
@@ -66,3 +165,7 @@ func (r *authRepository) GetUserUniversity(ctx context.Context, userID int) (*mo
 		First(&academic).Error
 	return &academic, err
 }
+
+func (r *authRepository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidResetToken is returned for unknown, expired or used reset tokens
+	ErrInvalidResetToken = errors.New("invalid password reset token")
+	// ErrResetUnavailable is returned when no email channel is configured
+	ErrResetUnavailable = errors.New("password reset is not available")
+)
+
+// WeakPasswordError lists the password policy rules a new password breaks
+type WeakPasswordError struct {
+	Problems []string
+}
+
+func (e *WeakPasswordError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// ResetOptions configures the password reset emails
+type ResetOptions struct {
+	Expire time.Duration
+	// URL is the page that completes the reset; the token is appended as
+	// the token query parameter. Without it the email carries the bare token.
+	URL string
+	// MaxPerEmail and MaxPerIP bound the requests accepted per hour
+	MaxPerEmail int
+	MaxPerIP    int
+}
+
+// DefaultResetOptions keeps reset links valid for one hour and accepts three
+// requests per address and ten per IP each hour
+func DefaultResetOptions() ResetOptions {
+	return ResetOptions{Expire: time.Hour, MaxPerEmail: 3, MaxPerIP: 10}
+}
+
+// ResetOptionsFromConfig reads the PASSWORD_RESET_* settings
+func ResetOptionsFromConfig(cfg *config.Config) ResetOptions {
+	options := DefaultResetOptions()
+	options.URL = cfg.PasswordResetURL
+	if value, err := time.ParseDuration(cfg.PasswordResetExpire); err == nil && value > 0 {
+		options.Expire = value
+	}
+	if value, err := strconv.Atoi(cfg.PasswordResetMaxPerEmail); err == nil && value > 0 {
+		options.MaxPerEmail = value
+	}
+	if value, err := strconv.Atoi(cfg.PasswordResetMaxPerIP); err == nil && value > 0 {
+		options.MaxPerIP = value
+	}
+	return options
+}
+
+// PasswordResetService issues single-use reset tokens by email and replaces
+// the password of whoever presents one. Emails go through the notification
+// queue, so a request never waits for the mail server.
+type PasswordResetService struct {
+	repo     AuthRepository
+	sessions *SessionService
+	enabled  bool
+	policy   utils.PasswordPolicy
+	options  ResetOptions
+	throttle *LoginThrottle
+	now      func() time.Time
+}
+
+// NewPasswordResetService creates the service; enabled is false when email is
+// not configured, in which case every request fails with ErrResetUnavailable
+func NewPasswordResetService(repo AuthRepository, sessions *SessionService, enabled bool, policy utils.PasswordPolicy, options ResetOptions) *PasswordResetService {
+	// Setiap permintaan dihitung, bukan hanya yang gagal, dan kunci berlaku sampai window habis
+	throttle := NewLoginThrottle(ThrottleOptions{
+		MaxAccountAttempts: options.MaxPerEmail,
+		MaxIPAttempts:      options.MaxPerIP,
+		Window:             time.Hour,
+		Lockout:            time.Hour,
+	})
+	return &PasswordResetService{repo: repo, sessions: sessions, enabled: enabled, policy: policy, options: options, throttle: throttle, now: time.Now}
+}
+
+// Throttle records a request for the address from ip and returns how long
+// further requests must wait, zero when this one may proceed
+func (s *PasswordResetService) Throttle(email, ip string) time.Duration {
+	if wait := s.throttle.Locked(email, ip); wait > 0 {
+		return wait
+	}
+	s.throttle.Fail(email, ip)
+	return 0
+}
+
+// Request queues an email with a reset token for the account with the
+// address. Unknown addresses succeed silently so the endpoint cannot be used
+// to find accounts.
+func (s *PasswordResetService) Request(ctx context.Context, email string) error {
+	if !s.enabled {
+		return ErrResetUnavailable
+	}
+	user, err := s.repo.GetUserByEmail(ctx, strings.TrimSpace(email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	raw, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	reset := &models.PasswordReset{
+		UserID:    uint(user.ID),
+		TokenHash: hashToken(raw),
+		ExpiresAt: now.Add(s.options.Expire),
+		CreatedAt: now,
+	}
+	if err := s.repo.CreatePasswordReset(ctx, reset); err != nil {
+		return err
+	}
+
+	return s.repo.CreateNotification(ctx, &models.Notification{
+		UserID:        uint(user.ID),
+		Recipient:     user.Email,
+		Kind:          models.NotificationPasswordReset,
+		Language:      models.LanguageIndonesian,
+		Subject:       "Reset password",
+		Body:          s.body(user, raw),
+		Status:        models.NotificationPending,
+		NextAttemptAt: now,
+	})
+}
+
+func (s *PasswordResetService) body(user *models.User, raw string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Halo %s,\n\n", user.Name)
+	b.WriteString("Kami menerima permintaan reset password untuk akun Anda.\n")
+	if s.options.URL != "" {
+		link := s.options.URL
+		separator := "?"
+		if strings.Contains(link, "?") {
+			separator = "&"
+		}
+		fmt.Fprintf(&b, "Buka tautan berikut untuk membuat password baru:\n\n%s%stoken=%s\n\n", link, separator, url.QueryEscape(raw))
+	} else {
+		fmt.Fprintf(&b, "Gunakan token berikut untuk membuat password baru:\n\n%s\n\n", raw)
+	}
+	fmt.Fprintf(&b, "Tautan ini berlaku sampai %s dan hanya bisa dipakai sekali.\n", s.now().Add(s.options.Expire).Format("02 Jan 2006 15:04 MST"))
+	b.WriteString("Abaikan email ini jika Anda tidak meminta reset password.\n")
+	return b.String()
+}
+
+// Reset replaces the password of the token's owner and ends all of their
+// sessions. A password breaking the policy is rejected with a
+// *WeakPasswordError without using up the token.
+func (s *PasswordResetService) Reset(ctx context.Context, raw, password string) error {
+	reset, err := s.repo.GetPasswordResetByHash(ctx, hashToken(raw))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	if !reset.Usable(now) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.repo.GetUserByID(ctx, int(reset.UserID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	if problems := s.policy.Validate(password, user.Username); len(problems) > 0 {
+		return &WeakPasswordError{Problems: problems}
+	}
+
+	// Update bersyarat agar token tidak bisa dipakai dua kali secara bersamaan
+	used, err := s.repo.UsePasswordReset(ctx, reset.ID, now)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.repo.UpdatePassword(ctx, user.ID, utils.HashPassword(password)); err != nil {
+		return err
+	}
+	logrus.WithField("user_id", user.ID).Info("Password reset, revoking sessions")
+	return s.sessions.RevokeAll(ctx, uint(user.ID))
+}
@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newTestResets(t *testing.T, repo AuthRepository, now time.Time) *PasswordResetService {
+	options := DefaultResetOptions()
+	options.URL = "https://app.example.com/reset"
+	resets := NewPasswordResetService(repo, newTestSessions(t, repo, now), true, utils.DefaultPasswordPolicy(), options)
+	resets.now = func() time.Time { return now }
+	return resets
+}
+
+func TestPasswordResetService_Request(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuthRepository(ctrl)
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	resets := newTestResets(t, mockRepo, now)
+
+	// Alamat yang tidak dikenal tidak dibedakan dari yang dikenal
+	mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+	if err := resets.Request(context.Background(), "nobody@example.com"); err != nil {
+		t.Errorf("unknown email should succeed silently, got %v", err)
+	}
+
+	var stored *models.PasswordReset
+	var queued *models.Notification
+	mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "budi@example.com").Return(&models.User{ID: 1, Name: "Budi", Email: "budi@example.com"}, nil)
+	mockRepo.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, reset *models.PasswordReset) error {
+		stored = reset
+		return nil
+	})
+	// Email masuk antrean notifikasi, tidak dikirim di dalam request
+	mockRepo.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notification *models.Notification) error {
+		queued = notification
+		return nil
+	})
+	if err := resets.Request(context.Background(), " budi@example.com "); err != nil {
+		t.Fatal(err)
+	}
+	if queued.Recipient != "budi@example.com" || queued.Kind != models.NotificationPasswordReset || queued.Status != models.NotificationPending || !queued.NextAttemptAt.Equal(now) {
+		t.Fatalf("expected a pending email to the account, got %+v", queued)
+	}
+
+	body := queued.Body
+	start := strings.Index(body, "token=")
+	if start < 0 {
+		t.Fatalf("email should contain the reset link: %s", body)
+	}
+	raw, _ := url.QueryUnescape(strings.Fields(body[start+len("token="):])[0])
+	if stored.TokenHash != hashToken(raw) || strings.Contains(body, stored.TokenHash) {
+		t.Error("only the hash of the emailed token should be stored")
+	}
+	if !stored.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected expiry after one hour, got %v", stored.ExpiresAt)
+	}
+}
+
+func TestPasswordResetService_Throttle(t *testing.T) {
+	options := DefaultResetOptions()
+	options.MaxPerEmail = 2
+	options.MaxPerIP = 3
+	resets := NewPasswordResetService(nil, nil, true, utils.DefaultPasswordPolicy(), options)
+
+	for i := 0; i < 2; i++ {
+		if wait := resets.Throttle("Budi@example.com", "10.0.0.1"); wait != 0 {
+			t.Fatalf("request %d should pass, got wait %v", i+1, wait)
+		}
+	}
+	if wait := resets.Throttle("budi@example.com", "10.0.0.2"); wait == 0 {
+		t.Error("a third request for the same address should be limited")
+	}
+	// Alamat lain dari IP yang sama dibatasi setelah kuota IP habis
+	if wait := resets.Throttle("sari@example.com", "10.0.0.1"); wait != 0 {
+		t.Errorf("another address should pass, got wait %v", wait)
+	}
+	if wait := resets.Throttle("eko@example.com", "10.0.0.1"); wait == 0 {
+		t.Error("the IP should be limited after its quota")
+	}
+}
+
+func TestPasswordResetService_Reset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuthRepository(ctrl)
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	resets := newTestResets(t, mockRepo, now)
+
+	stored := &models.PasswordReset{ID: 3, UserID: 1, TokenHash: hashToken("token"), ExpiresAt: now.Add(time.Minute)}
+	user := &models.User{ID: 1, Username: "budi", MustChangePassword: true}
+	mockRepo.EXPECT().GetPasswordResetByHash(gomock.Any(), hashToken("token")).Return(stored, nil).Times(3)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil).Times(3)
+
+	// Password lemah tidak menghabiskan token
+	if err, ok := resets.Reset(context.Background(), "token", "weak").(*WeakPasswordError); !ok || len(err.Problems) == 0 {
+		t.Errorf("expected weak password error, got %v", err)
+	}
+
+	mockRepo.EXPECT().UsePasswordReset(gomock.Any(), 3, now).Return(true, nil)
+	mockRepo.EXPECT().UpdatePassword(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(_ context.Context, _ int, hash string) error {
+		if !utils.CheckPassword("brandnew123", hash) {
+			t.Error("new password should be stored hashed")
+		}
+		return nil
+	})
+	mockRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), uint(1), now).Return(nil)
+	if err := resets.Reset(context.Background(), "token", "brandnew123"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pemakaian kedua yang kalah balapan ditolak
+	mockRepo.EXPECT().UsePasswordReset(gomock.Any(), 3, now).Return(false, nil)
+	if err := resets.Reset(context.Background(), "token", "another123"); err != ErrInvalidResetToken {
+		t.Errorf("expected invalid token, got %v", err)
+	}
+}
+
+func TestPasswordResetService_RejectsUnusableTokens(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	usedAt := now.Add(-time.Minute)
+
+	for name, stored := range map[string]*models.PasswordReset{
+		"expired": {ID: 1, UserID: 1, ExpiresAt: now},
+		"used":    {ID: 1, UserID: 1, ExpiresAt: now.Add(time.Hour), UsedAt: &usedAt},
+		"unknown": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := NewMockAuthRepository(ctrl)
+			resets := newTestResets(t, mockRepo, now)
+
+			if stored == nil {
+				mockRepo.EXPECT().GetPasswordResetByHash(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+			} else {
+				mockRepo.EXPECT().GetPasswordResetByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+			}
+
+			if err := resets.Reset(context.Background(), "token", "brandnew123"); err != ErrInvalidResetToken {
+				t.Errorf("expected invalid token, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthHandler_PasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuthRepository(ctrl)
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	sessions := newTestSessions(t, mockRepo, now)
+
+	// Tanpa kanal email reset password tidak tersedia
+	handler := newTestHandler(mockRepo, sessions)
+	w := httptest.NewRecorder()
+	handler.ForgotPassword(w, httptest.NewRequest("POST", "/auth/password/forgot", bytes.NewReader([]byte(`{"email":"budi@example.com"}`))))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without email: expected 503, got %d", w.Code)
+	}
+
+	handler = NewAuthHandler(mockRepo, sessions, newTestResets(t, mockRepo, now), NewLoginThrottle(DefaultThrottleOptions()), utils.DefaultPasswordPolicy())
+
+	w = httptest.NewRecorder()
+	handler.ForgotPassword(w, httptest.NewRequest("POST", "/auth/password/forgot", bytes.NewReader([]byte(`{}`))))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("missing email: expected 400, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+	w = httptest.NewRecorder()
+	handler.ForgotPassword(w, httptest.NewRequest("POST", "/auth/password/forgot", bytes.NewReader([]byte(`{"email":"nobody@example.com"}`))))
+	if w.Code != http.StatusAccepted {
+		t.Errorf("unknown email: expected 202, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetPasswordResetByHash(gomock.Any(), hashToken("bogus")).Return(nil, gorm.ErrRecordNotFound)
+	w = httptest.NewRecorder()
+	handler.ResetPassword(w, httptest.NewRequest("POST", "/auth/password/reset", bytes.NewReader([]byte(`{"token":"bogus","new_password":"brandnew123"}`))))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid token: expected 400, got %d", w.Code)
+	}
+	if resp := parseResponse(w); len(resp.Errors) != 1 || resp.Errors[0].Field != "token" {
+		t.Errorf("expected a token error, got %+v", resp.Errors)
+	}
+
+	// Permintaan berulang untuk alamat yang sama dibatasi
+	mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "nobody@example.com").Return(nil, gorm.ErrRecordNotFound).Times(2)
+	for i := 0; i < 3; i++ {
+		w = httptest.NewRecorder()
+		handler.ForgotPassword(w, httptest.NewRequest("POST", "/auth/password/forgot", bytes.NewReader([]byte(`{"email":"nobody@example.com"}`))))
+	}
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("repeated requests: expected 429 with Retry-After, got %d", w.Code)
+	}
+}
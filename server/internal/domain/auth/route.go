@@ -1,14 +1,25 @@
 package auth
 
 import (
+	"tsukamoto/config"
+	"tsukamoto/internal/mailer"
+	"tsukamoto/internal/utils"
+
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
 )
 
-func AuthRoute(r *mux.Router, db *gorm.DB) {
+func AuthRoute(r *mux.Router, db *gorm.DB, sessions *SessionService, cfg *config.Config) {
 	repo := NewAuthRepository(db)
-	handler := NewAuthHandler(repo)
+	passwords := utils.PasswordPolicyFromConfig(cfg)
+	resets := NewPasswordResetService(repo, sessions, mailer.FromConfig(cfg) != nil, passwords, ResetOptionsFromConfig(cfg))
+	handler := NewAuthHandler(repo, sessions, resets, NewLoginThrottle(ThrottleOptionsFromConfig(cfg)), passwords)
 
 	r.HandleFunc("/auth/login", handler.Login).Methods("POST")
 	r.HandleFunc("/auth/register", handler.Register).Methods("POST")
+	r.HandleFunc("/auth/refresh", handler.Refresh).Methods("POST")
+	r.HandleFunc("/auth/logout", handler.Logout).Methods("POST")
+	r.HandleFunc("/auth/password", handler.ChangePassword).Methods("POST")
+	r.HandleFunc("/auth/password/forgot", handler.ForgotPassword).Methods("POST")
+	r.HandleFunc("/auth/password/reset", handler.ResetPassword).Methods("POST")
 }
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const defaultRefreshExpire = 30 * 24 * time.Hour
+
+var (
+	// ErrInvalidRefreshToken is returned for unknown, expired or revoked refresh tokens
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when a rotated refresh token is presented
+	// again; the whole token family is revoked
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// RefreshExpireFromConfig reads JWT_REFRESH_EXPIRE, defaulting to 30 days
+func RefreshExpireFromConfig(cfg *config.Config) time.Duration {
+	if value, err := time.ParseDuration(cfg.JWTRefreshExpire); err == nil && value > 0 {
+		return value
+	}
+	return defaultRefreshExpire
+}
+
+// SessionService issues access tokens backed by rotating refresh tokens.
+// A session is a refresh token family; revoking it also invalidates the
+// access tokens carrying its ID.
+type SessionService struct {
+	repo          AuthRepository
+	tokens        *utils.JWTUtil
+	refreshExpire time.Duration
+	now           func() time.Time
+}
+
+func NewSessionService(repo AuthRepository, tokens *utils.JWTUtil, refreshExpire time.Duration) *SessionService {
+	return &SessionService{repo: repo, tokens: tokens, refreshExpire: refreshExpire, now: time.Now}
+}
+
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// claims builds the access token claims, taking the university from the
+// user or, for older accounts, from their academic record
+func (s *SessionService) claims(ctx context.Context, user *models.User) utils.Claims {
+	claims := utils.Claims{UserID: user.ID, Username: user.Username, Role: user.Role}
+	if user.UniversityID != nil {
+		claims.UniversityID = int(*user.UniversityID)
+	}
+	academic, err := s.repo.GetUserAcademic(ctx, user.ID)
+	if err == nil && academic.University.ID != 0 && (claims.UniversityID == 0 || claims.UniversityID == academic.University.ID) {
+		claims.UniversityID = academic.University.ID
+		claims.UniversityName = academic.University.Name
+	}
+	return claims
+}
+
+// Issue starts a new session for the user, or continues familyID when
+// rotating a refresh token
+func (s *SessionService) Issue(ctx context.Context, user *models.User, familyID string) (*TokenPair, error) {
+	if familyID == "" {
+		id, err := randomToken(16)
+		if err != nil {
+			return nil, err
+		}
+		familyID = id
+	}
+	raw, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh := &models.RefreshToken{
+		UserID:    uint(user.ID),
+		FamilyID:  familyID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: s.now().Add(s.refreshExpire),
+	}
+	if err := s.repo.CreateRefreshToken(ctx, refresh); err != nil {
+		return nil, err
+	}
+
+	claims := s.claims(ctx, user)
+	claims.SessionID = familyID
+	claims.MustChangePassword = user.MustChangePassword
+	token, err := s.tokens.GenerateJWT(claims)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		Token:              token,
+		RefreshToken:       raw,
+		ExpiresIn:          int64(s.tokens.Expire().Seconds()),
+		MustChangePassword: user.MustChangePassword,
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new pair. Presenting a token that
+// was already rotated revokes its whole family.
+func (s *SessionService) Refresh(ctx context.Context, raw string) (*TokenPair, error) {
+	stored, err := s.repo.GetRefreshTokenByHash(ctx, hashToken(raw))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if stored.RotatedAt != nil {
+		return nil, s.reused(ctx, stored, now)
+	}
+	if !stored.Usable(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	rotated, err := s.repo.RotateRefreshToken(ctx, stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Request lain sudah memakai token ini lebih dulu
+		return nil, s.reused(ctx, stored, now)
+	}
+
+	user, err := s.repo.GetUserByID(ctx, int(stored.UserID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Issue(ctx, user, stored.FamilyID)
+}
+
+func (s *SessionService) reused(ctx context.Context, stored *models.RefreshToken, now time.Time) error {
+	logrus.WithFields(logrus.Fields{"user_id": stored.UserID, "family_id": stored.FamilyID}).
+		Warn("Refresh token reuse detected, revoking session")
+	if err := s.repo.RevokeRefreshFamily(ctx, stored.FamilyID, now); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// Logout revokes the session the refresh token belongs to
+func (s *SessionService) Logout(ctx context.Context, raw string) error {
+	stored, err := s.repo.GetRefreshTokenByHash(ctx, hashToken(raw))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.RevokeRefreshFamily(ctx, stored.FamilyID, s.now())
+}
+
+// RevokeAll ends every session of the user
+func (s *SessionService) RevokeAll(ctx context.Context, userID uint) error {
+	return s.repo.RevokeUserRefreshTokens(ctx, userID, s.now())
+}
+
+// Active reports whether the session an access token belongs to has not
+// been revoked or expired
+func (s *SessionService) Active(sessionID string) (bool, error) {
+	return s.repo.RefreshFamilyActive(context.Background(), sessionID, s.now())
+}
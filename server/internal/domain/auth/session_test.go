@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newTestSessions(t *testing.T, repo AuthRepository, now time.Time) *SessionService {
+	sessions := NewSessionService(repo, testTokens(t), time.Hour)
+	sessions.now = func() time.Time { return now }
+	return sessions
+}
+
+func TestSessionService_RefreshRotates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuthRepository(ctrl)
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	sessions := newTestSessions(t, mockRepo, now)
+
+	stored := &models.RefreshToken{ID: 4, UserID: 1, FamilyID: "fam", TokenHash: hashToken("old"), ExpiresAt: now.Add(time.Hour)}
+	mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), hashToken("old")).Return(stored, nil)
+	mockRepo.EXPECT().RotateRefreshToken(gomock.Any(), 4, now).Return(true, nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), 1).Return(&models.User{ID: 1, Username: "budi", Role: "student"}, nil)
+	mockRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token *models.RefreshToken) error {
+		if token.FamilyID != "fam" || token.TokenHash == stored.TokenHash || !token.ExpiresAt.Equal(now.Add(time.Hour)) {
+			t.Errorf("rotated token should continue the family with a new hash: %+v", token)
+		}
+		return nil
+	})
+	mockRepo.EXPECT().GetUserAcademic(gomock.Any(), 1).Return(nil, gorm.ErrRecordNotFound)
+
+	pair, err := sessions.Refresh(context.Background(), "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pair.RefreshToken == "old" || pair.Token == "" {
+		t.Errorf("expected a new pair, got %+v", pair)
+	}
+	claims, err := sessions.tokens.ValidateJWT(pair.Token)
+	if err != nil || claims.SessionID != "fam" {
+		t.Errorf("access token should carry the session id: %+v, %v", claims, err)
+	}
+}
+
+func TestSessionService_ReuseRevokesFamily(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	rotatedAt := now.Add(-time.Minute)
+
+	t.Run("already rotated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockAuthRepository(ctrl)
+		sessions := newTestSessions(t, mockRepo, now)
+
+		stored := &models.RefreshToken{ID: 4, UserID: 1, FamilyID: "fam", ExpiresAt: now.Add(time.Hour), RotatedAt: &rotatedAt}
+		mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+		mockRepo.EXPECT().RevokeRefreshFamily(gomock.Any(), "fam", now).Return(nil)
+
+		if _, err := sessions.Refresh(context.Background(), "stolen"); err != ErrRefreshTokenReused {
+			t.Errorf("expected reuse error, got %v", err)
+		}
+	})
+
+	t.Run("concurrent rotation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockAuthRepository(ctrl)
+		sessions := newTestSessions(t, mockRepo, now)
+
+		stored := &models.RefreshToken{ID: 4, UserID: 1, FamilyID: "fam", ExpiresAt: now.Add(time.Hour)}
+		mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+		mockRepo.EXPECT().RotateRefreshToken(gomock.Any(), 4, now).Return(false, nil)
+		mockRepo.EXPECT().RevokeRefreshFamily(gomock.Any(), "fam", now).Return(nil)
+
+		if _, err := sessions.Refresh(context.Background(), "raced"); err != ErrRefreshTokenReused {
+			t.Errorf("expected reuse error, got %v", err)
+		}
+	})
+}
+
+func TestSessionService_RefreshRejectsUnusableTokens(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Minute)
+
+	for name, stored := range map[string]*models.RefreshToken{
+		"expired": {ID: 1, FamilyID: "fam", ExpiresAt: now.Add(-time.Second)},
+		"revoked": {ID: 1, FamilyID: "fam", ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt},
+		"unknown": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := NewMockAuthRepository(ctrl)
+			sessions := newTestSessions(t, mockRepo, now)
+
+			if stored == nil {
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+			} else {
+				mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+			}
+
+			if _, err := sessions.Refresh(context.Background(), "token"); err != ErrInvalidRefreshToken {
+				t.Errorf("expected invalid refresh token, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthHandler_RefreshAndLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockAuthRepository(ctrl)
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	handler := newTestHandler(mockRepo, newTestSessions(t, mockRepo, now))
+
+	w := httptest.NewRecorder()
+	handler.Refresh(w, httptest.NewRequest("POST", "/auth/refresh", bytes.NewReader([]byte(`{}`))))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("missing refresh token: expected 400, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), hashToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+	w = httptest.NewRecorder()
+	handler.Refresh(w, httptest.NewRequest("POST", "/auth/refresh", bytes.NewReader([]byte(`{"refresh_token":"unknown"}`))))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("unknown refresh token: expected 401, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), hashToken("mine")).Return(&models.RefreshToken{ID: 2, FamilyID: "fam"}, nil)
+	mockRepo.EXPECT().RevokeRefreshFamily(gomock.Any(), "fam", now).Return(nil)
+	w = httptest.NewRecorder()
+	handler.Logout(w, httptest.NewRequest("POST", "/auth/logout", bytes.NewReader([]byte(`{"refresh_token":"mine"}`))))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("logout: expected 204, got %d", w.Code)
+	}
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"tsukamoto/config"
+)
+
+// ThrottleOptions bounds failed login attempts per account and per IP
+type ThrottleOptions struct {
+	MaxAccountAttempts int
+	MaxIPAttempts      int
+	Window             time.Duration
+	Lockout            time.Duration
+}
+
+// DefaultThrottleOptions locks an account after 5 failures or an IP after
+// 20 failures within 15 minutes, for 15 minutes
+func DefaultThrottleOptions() ThrottleOptions {
+	return ThrottleOptions{MaxAccountAttempts: 5, MaxIPAttempts: 20, Window: 15 * time.Minute, Lockout: 15 * time.Minute}
+}
+
+// ThrottleOptionsFromConfig overrides the defaults with LOGIN_* settings
+func ThrottleOptionsFromConfig(cfg *config.Config) ThrottleOptions {
+	options := DefaultThrottleOptions()
+	if value, err := strconv.Atoi(cfg.LoginMaxAttempts); err == nil && value > 0 {
+		options.MaxAccountAttempts = value
+	}
+	if value, err := strconv.Atoi(cfg.LoginIPMaxAttempts); err == nil && value > 0 {
+		options.MaxIPAttempts = value
+	}
+	if value, err := time.ParseDuration(cfg.LoginAttemptWindow); err == nil && value > 0 {
+		options.Window = value
+	}
+	if value, err := time.ParseDuration(cfg.LoginLockout); err == nil && value > 0 {
+		options.Lockout = value
+	}
+	return options
+}
+
+// pruneThreshold is the number of tracked keys above which stale entries are dropped
+const pruneThreshold = 10000
+
+type attempts struct {
+	failures    []time.Time
+	lockedUntil time.Time
+}
+
+// LoginThrottle counts failed logins in memory and locks accounts and IPs
+// that fail too often. State is per process.
+type LoginThrottle struct {
+	mu      sync.Mutex
+	options ThrottleOptions
+	entries map[string]*attempts
+	now     func() time.Time
+}
+
+func NewLoginThrottle(options ThrottleOptions) *LoginThrottle {
+	return &LoginThrottle{options: options, entries: make(map[string]*attempts), now: time.Now}
+}
+
+func accountKey(username string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Locked returns how long the account or IP stays locked, zero when neither is
+func (t *LoginThrottle) Locked(username, ip string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var remaining time.Duration
+	for _, key := range []string{accountKey(username), ipKey(ip)} {
+		if entry, ok := t.entries[key]; ok && entry.lockedUntil.After(now) {
+			if wait := entry.lockedUntil.Sub(now); wait > remaining {
+				remaining = wait
+			}
+		}
+	}
+	return remaining
+}
+
+// Fail records a failed attempt for the account and the IP
+func (t *LoginThrottle) Fail(username, ip string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if len(t.entries) > pruneThreshold {
+		t.prune(now)
+	}
+	t.fail(accountKey(username), t.options.MaxAccountAttempts, now)
+	t.fail(ipKey(ip), t.options.MaxIPAttempts, now)
+}
+
+func (t *LoginThrottle) fail(key string, max int, now time.Time) {
+	entry, ok := t.entries[key]
+	if !ok {
+		entry = &attempts{}
+		t.entries[key] = entry
+	}
+
+	// Hanya kegagalan dalam window yang dihitung
+	recent := entry.failures[:0]
+	for _, at := range entry.failures {
+		if now.Sub(at) < t.options.Window {
+			recent = append(recent, at)
+		}
+	}
+	entry.failures = append(recent, now)
+
+	if len(entry.failures) >= max {
+		entry.lockedUntil = now.Add(t.options.Lockout)
+		entry.failures = nil
+	}
+}
+
+// Succeed clears the failures of the account after a successful login
+func (t *LoginThrottle) Succeed(username string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, accountKey(username))
+}
+
+func (t *LoginThrottle) prune(now time.Time) {
+	for key, entry := range t.entries {
+		stale := len(entry.failures) == 0 || now.Sub(entry.failures[len(entry.failures)-1]) >= t.options.Window
+		if stale && !entry.lockedUntil.After(now) {
+			delete(t.entries, key)
+		}
+	}
+}
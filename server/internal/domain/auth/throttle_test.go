@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestThrottle(now *time.Time) *LoginThrottle {
+	throttle := NewLoginThrottle(ThrottleOptions{MaxAccountAttempts: 3, MaxIPAttempts: 5, Window: time.Minute, Lockout: 10 * time.Minute})
+	throttle.now = func() time.Time { return *now }
+	return throttle
+}
+
+func TestLoginThrottle_LocksAccount(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	throttle := newTestThrottle(&now)
+
+	for i := 0; i < 2; i++ {
+		throttle.Fail("budi", "10.0.0.1")
+	}
+	if wait := throttle.Locked("budi", "10.0.0.1"); wait != 0 {
+		t.Fatalf("account should not be locked yet, got %v", wait)
+	}
+
+	throttle.Fail("Budi", "10.0.0.2")
+	if wait := throttle.Locked("budi", "10.0.0.3"); wait != 10*time.Minute {
+		t.Errorf("account should be locked for the lockout period from any IP, got %v", wait)
+	}
+	if wait := throttle.Locked("siti", "10.0.0.1"); wait != 0 {
+		t.Errorf("other accounts should not be locked, got %v", wait)
+	}
+
+	now = now.Add(10 * time.Minute)
+	if wait := throttle.Locked("budi", "10.0.0.1"); wait != 0 {
+		t.Errorf("lock should expire, got %v", wait)
+	}
+}
+
+func TestLoginThrottle_WindowAndSuccess(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	throttle := newTestThrottle(&now)
+
+	// Kegagalan di luar window tidak dihitung
+	throttle.Fail("budi", "10.0.0.1")
+	throttle.Fail("budi", "10.0.0.1")
+	now = now.Add(2 * time.Minute)
+	throttle.Fail("budi", "10.0.0.1")
+	if wait := throttle.Locked("budi", "10.0.0.1"); wait != 0 {
+		t.Errorf("failures outside the window should not lock, got %v", wait)
+	}
+
+	throttle.Fail("budi", "10.0.0.1")
+	throttle.Succeed("budi")
+	throttle.Fail("budi", "10.0.0.1")
+	if wait := throttle.Locked("budi", "10.0.0.1"); wait != 0 {
+		t.Errorf("a successful login should reset the account, got %v", wait)
+	}
+}
+
+func TestLoginThrottle_LocksIP(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	throttle := newTestThrottle(&now)
+
+	for _, username := range []string{"a", "b", "c", "d", "e"} {
+		throttle.Fail(username, "10.0.0.1")
+	}
+	if wait := throttle.Locked("f", "10.0.0.1"); wait == 0 {
+		t.Error("an IP guessing many accounts should be locked")
+	}
+	if wait := throttle.Locked("f", "10.0.0.2"); wait != 0 {
+		t.Errorf("other IPs should not be locked, got %v", wait)
+	}
+}
@@ -0,0 +1,217 @@
+package courses
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/agregasi"
+	"tsukamoto/internal/tenant"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AttendanceWeightsFromConfig reads the ATTENDANCE_WEIGHT_* settings; missing
+// or invalid values keep their default
+func AttendanceWeightsFromConfig(cfg *config.Config) agregasi.AttendanceWeights {
+	weights := agregasi.DefaultAttendanceWeights()
+	for status, value := range map[string]string{
+		models.AttendancePresent: cfg.AttendanceWeightPresent,
+		models.AttendanceLate:    cfg.AttendanceWeightLate,
+		models.AttendanceExcused: cfg.AttendanceWeightExcused,
+		models.AttendanceAbsent:  cfg.AttendanceWeightAbsent,
+	} {
+		if weight, err := strconv.ParseFloat(value, 64); err == nil && weight >= 0 && weight <= 1 {
+			weights[status] = weight
+		}
+	}
+	return weights
+}
+
+// Aggregator derives the GPA, core course average and exam scores of an
+// academic record from the student's course grades, and the attendance rate
+// from the attendance records. Fields with a manual value are left alone
+// until their override is cleared.
+type Aggregator struct {
+	repo       CourseRepository
+	bus        *events.Bus
+	weights    agregasi.Weights
+	attendance agregasi.AttendanceWeights
+	wg         sync.WaitGroup
+}
+
+func NewAggregator(repo CourseRepository, bus *events.Bus, attendance agregasi.AttendanceWeights) *Aggregator {
+	return &Aggregator{repo: repo, bus: bus, weights: agregasi.DefaultWeights(), attendance: attendance}
+}
+
+// Breakdown returns the attendance of a student in a term per course
+func (a *Aggregator) Breakdown(ctx context.Context, userID uint, termID *uint) (AttendanceBreakdown, error) {
+	breakdown := AttendanceBreakdown{
+		StudentID: userID,
+		TermID:    termID,
+		Weights:   a.attendance,
+		Courses:   []CourseAttendance{},
+		Counts:    map[string]int{},
+	}
+	counts, err := a.repo.AttendanceCounts(ctx, userID, termID)
+	if err != nil {
+		return breakdown, err
+	}
+
+	index := map[int]int{}
+	for _, count := range counts {
+		i, ok := index[count.CourseID]
+		if !ok {
+			i = len(breakdown.Courses)
+			index[count.CourseID] = i
+			breakdown.Courses = append(breakdown.Courses, CourseAttendance{
+				CourseID: count.CourseID,
+				Code:     count.Code,
+				Name:     count.Name,
+				Counts:   map[string]int{},
+			})
+		}
+		breakdown.Courses[i].Counts[count.Status] += count.Count
+		breakdown.Courses[i].Sessions += count.Count
+		breakdown.Counts[count.Status] += count.Count
+		breakdown.Sessions += count.Count
+	}
+	for i := range breakdown.Courses {
+		if rate, ok := a.attendance.Rate(breakdown.Courses[i].Counts); ok {
+			breakdown.Courses[i].Rate = &rate
+		}
+	}
+	if rate, ok := a.attendance.Rate(breakdown.Counts); ok {
+		breakdown.Rate = &rate
+	}
+	return breakdown, nil
+}
+
+// Recompute updates the student's academic record of a term and creates it
+// once grades and attendance determine every input. The record is nil when
+// the student has no record yet and some input is still unknown; values are
+// never filled in with zero, which would read as a failing student.
+func (a *Aggregator) Recompute(ctx context.Context, userID uint, termID *uint, clearOverrides []string) (*models.Academic, agregasi.Result, error) {
+	academic, result, err := a.recompute(ctx, userID, termID, clearOverrides)
+	if err != nil || academic == nil {
+		return academic, result, err
+	}
+	a.bus.Publish(ctx, events.AcademicChanged, events.AcademicChangedPayload{UserIDs: []uint{userID}})
+	return academic, result, nil
+}
+
+// RecomputeMany recomputes the records of several students in a term and
+// announces the updated ones in a single AcademicChanged event. Failures are
+// logged; it returns how many records were updated.
+func (a *Aggregator) RecomputeMany(ctx context.Context, userIDs []uint, termID *uint) int {
+	updated := make([]uint, 0, len(userIDs))
+	for _, userID := range userIDs {
+		academic, _, err := a.recompute(ctx, userID, termID, nil)
+		if err != nil {
+			logrus.Warnf("recomputing academic record of user %d failed: %v", userID, err)
+			continue
+		}
+		if academic != nil {
+			updated = append(updated, userID)
+		}
+	}
+	if len(updated) > 0 {
+		a.bus.Publish(ctx, events.AcademicChanged, events.AcademicChangedPayload{UserIDs: updated})
+	}
+	return len(updated)
+}
+
+// RecomputeInBackground runs RecomputeMany outside the caller's request, so
+// that a large upload does not wait for every record
+func (a *Aggregator) RecomputeInBackground(userIDs []uint, termID *uint) {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		// Mahasiswa sudah diperiksa terhadap cakupan pemanggil saat request
+		a.RecomputeMany(tenant.WithSystem(context.Background()), userIDs, termID)
+	}()
+}
+
+// Wait blocks until every background recompute has finished
+func (a *Aggregator) Wait() {
+	a.wg.Wait()
+}
+
+// recompute updates the record like Recompute without publishing the change
+func (a *Aggregator) recompute(ctx context.Context, userID uint, termID *uint, clearOverrides []string) (*models.Academic, agregasi.Result, error) {
+	enrollments, err := a.repo.ListStudentEnrollments(ctx, userID, termID)
+	if err != nil {
+		return nil, agregasi.Result{}, err
+	}
+	courses := make([]agregasi.Course, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		course := agregasi.Course{Scores: make(map[string]float64, len(enrollment.Grades))}
+		if enrollment.Course != nil {
+			course.Credits = enrollment.Course.Credits
+			course.Core = enrollment.Course.Core
+		}
+		for _, grade := range enrollment.Grades {
+			course.Scores[grade.Component] = grade.Score
+		}
+		courses = append(courses, course)
+	}
+	result := agregasi.Compute(courses, a.weights)
+
+	attendance, err := a.Breakdown(ctx, userID, termID)
+	if err != nil {
+		return nil, result, err
+	}
+	result.AttendanceRate = attendance.Rate
+
+	academic, err := a.repo.GetAcademic(ctx, userID, termID)
+	if err != nil {
+		return nil, result, err
+	}
+	if academic == nil {
+		if !result.Complete() || len(enrollments) == 0 || enrollments[0].Course == nil {
+			return nil, result, nil
+		}
+		// Record baru sepenuhnya berasal dari nilai mata kuliah
+		academic = &models.Academic{
+			UserID:       userID,
+			UniversityID: enrollments[0].Course.UniversityID,
+			TermID:       termID,
+			Provenance:   models.StringMap{},
+		}
+		for field := range result.Fields() {
+			academic.Provenance[field] = models.ProvenanceComputed
+		}
+	}
+	if academic.Provenance == nil {
+		academic.Provenance = models.StringMap{}
+	}
+	for _, field := range clearOverrides {
+		academic.Provenance[field] = models.ProvenanceComputed
+	}
+
+	for field, value := range result.Fields() {
+		if value == nil || academic.Source(field) != models.ProvenanceComputed {
+			continue
+		}
+		v := float32(*value)
+		switch field {
+		case "gpa":
+			academic.GPA = v
+		case "core_course_average":
+			academic.CoreCourseAverage = v
+		case "midterm_exam_score":
+			academic.MidtermExamScore = v
+		case "final_exam_score":
+			academic.FinalExamScore = v
+		case "attendance_rate":
+			academic.AttendanceRate = v
+		}
+	}
+
+	if err := a.repo.SaveAcademic(ctx, academic); err != nil {
+		return nil, result, err
+	}
+	return academic, result, nil
+}
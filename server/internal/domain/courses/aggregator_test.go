@@ -0,0 +1,191 @@
+package courses
+
+import (
+	"context"
+	"testing"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/agregasi"
+
+	"github.com/golang/mock/gomock"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func gradedEnrollments() []models.Enrollment {
+	core := &models.Course{ID: 1, UniversityID: 4, Credits: 3, Core: true}
+	elective := &models.Course{ID: 2, UniversityID: 4, Credits: 2}
+	return []models.Enrollment{
+		{ID: 10, CourseID: 1, Course: core, UserID: 7, Grades: []models.Grade{
+			{Component: models.GradeMidterm, Score: 80}, {Component: models.GradeFinalExam, Score: 90}, {Component: models.GradeCoursework, Score: 85},
+		}},
+		{ID: 11, CourseID: 2, Course: elective, UserID: 7, Grades: []models.Grade{
+			{Component: models.GradeMidterm, Score: 60}, {Component: models.GradeFinalExam, Score: 70}, {Component: models.GradeCoursework, Score: 50},
+		}},
+	}
+}
+
+func TestAggregator_CreatesComputedRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	bus := events.NewBus()
+	var published []uint
+	bus.Subscribe(events.AcademicChanged, func(_ context.Context, event events.Event) {
+		published = append(published, event.Payload.(events.AcademicChangedPayload).UserIDs...)
+	})
+	aggregator := NewAggregator(mockRepo, bus, agregasi.DefaultAttendanceWeights())
+
+	termID := uintPtr(3)
+	mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), termID).Return(gradedEnrollments(), nil)
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), termID).Return([]AttendanceCount{{CourseID: 1, Status: models.AttendancePresent, Count: 4}}, nil)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), termID).Return(nil, nil)
+	mockRepo.EXPECT().SaveAcademic(gomock.Any(), gomock.Any()).Return(nil)
+
+	academic, result, err := aggregator.Recompute(context.Background(), 7, termID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if academic == nil || academic.UniversityID != 4 || academic.TermID != termID {
+		t.Fatalf("unexpected academic record %+v", academic)
+	}
+	if result.GradedCourses != 2 {
+		t.Errorf("expected 2 graded courses, got %d", result.GradedCourses)
+	}
+	// Skor 85.5 (4.0, 3 SKS) dan 61 (2.3, 2 SKS)
+	if academic.GPA != float32((3*4.0+2*2.3)/5) || academic.CoreCourseAverage != 85.5 || academic.MidtermExamScore != 72 || academic.FinalExamScore != 82 || academic.AttendanceRate != 1 {
+		t.Errorf("unexpected computed values %+v", academic)
+	}
+	for _, field := range []string{"gpa", "core_course_average", "midterm_exam_score", "final_exam_score", "attendance_rate"} {
+		if academic.Source(field) != models.ProvenanceComputed {
+			t.Errorf("%s should be computed, got %s", field, academic.Source(field))
+		}
+	}
+	if len(published) != 1 || published[0] != 7 {
+		t.Errorf("expected academic change for user 7, got %v", published)
+	}
+}
+
+func TestAggregator_KeepsManualOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	aggregator := NewAggregator(mockRepo, nil, agregasi.DefaultAttendanceWeights())
+
+	existing := &models.Academic{
+		ID: 5, UserID: 7, UniversityID: 4, GPA: 3.9, CoreCourseAverage: 60, AttendanceRate: 0.9,
+		Provenance: models.StringMap{"gpa": models.ProvenanceManual, "core_course_average": models.ProvenanceManual, "midterm_exam_score": models.ProvenanceComputed},
+	}
+	mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return(gradedEnrollments(), nil)
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), nil).Return(nil, nil)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(existing, nil)
+	mockRepo.EXPECT().SaveAcademic(gomock.Any(), existing).Return(nil)
+
+	academic, _, err := aggregator.Recompute(context.Background(), 7, nil, []string{"core_course_average"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if academic.GPA != 3.9 {
+		t.Errorf("manual GPA should be kept, got %v", academic.GPA)
+	}
+	if academic.CoreCourseAverage != 85.5 || academic.Source("core_course_average") != models.ProvenanceComputed {
+		t.Errorf("cleared override should be computed, got %v (%s)", academic.CoreCourseAverage, academic.Source("core_course_average"))
+	}
+	if academic.MidtermExamScore != 72 {
+		t.Errorf("computed midterm should be refreshed, got %v", academic.MidtermExamScore)
+	}
+	// Tanpa provenance tercatat, nilai dianggap diketik manual
+	if academic.FinalExamScore != 0 {
+		t.Errorf("legacy final exam score should be left alone, got %v", academic.FinalExamScore)
+	}
+	if academic.AttendanceRate != 0.9 {
+		t.Errorf("attendance is not derived from grades, got %v", academic.AttendanceRate)
+	}
+}
+
+func TestAggregator_NothingToCompute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	aggregator := NewAggregator(mockRepo, nil, agregasi.DefaultAttendanceWeights())
+
+	mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return(nil, nil)
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), nil).Return(nil, nil)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(nil, nil)
+
+	academic, _, err := aggregator.Recompute(context.Background(), 7, nil, nil)
+	if err != nil || academic != nil {
+		t.Errorf("expected no record, got %+v, %v", academic, err)
+	}
+}
+
+func TestAggregator_WaitsForEveryInput(t *testing.T) {
+	tests := []struct {
+		name       string
+		enrollment models.Enrollment
+		counts     []AttendanceCount
+	}{
+		{
+			name:       "grades without attendance",
+			enrollment: gradedEnrollments()[0],
+		},
+		{
+			name:       "attendance without grades",
+			enrollment: models.Enrollment{ID: 10, Course: &models.Course{ID: 1, UniversityID: 4, Credits: 3}, UserID: 7},
+			counts:     []AttendanceCount{{CourseID: 1, Status: models.AttendancePresent, Count: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := NewMockCourseRepository(ctrl)
+			bus := events.NewBus()
+			bus.Subscribe(events.AcademicChanged, func(context.Context, events.Event) {
+				t.Error("an incomplete record must not be published")
+			})
+			aggregator := NewAggregator(mockRepo, bus, agregasi.DefaultAttendanceWeights())
+
+			mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return([]models.Enrollment{tt.enrollment}, nil)
+			mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), nil).Return(tt.counts, nil)
+			mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(nil, nil)
+
+			// Record tidak dibuat dengan nilai nol untuk masukan yang belum diketahui
+			academic, _, err := aggregator.Recompute(context.Background(), 7, nil, nil)
+			if err != nil || academic != nil {
+				t.Errorf("expected no record, got %+v, %v", academic, err)
+			}
+		})
+	}
+}
+
+func TestAggregator_ComputesAttendanceRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	aggregator := NewAggregator(mockRepo, nil, agregasi.AttendanceWeights{models.AttendancePresent: 1, models.AttendanceLate: 0.5})
+
+	existing := &models.Academic{ID: 5, UserID: 7, UniversityID: 4, GPA: 3.1, Provenance: models.StringMap{"attendance_rate": models.ProvenanceComputed}}
+	enrollments := []models.Enrollment{{ID: 10, Course: &models.Course{ID: 1, UniversityID: 4, Credits: 3}, UserID: 7}}
+	mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return(enrollments, nil)
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), nil).Return([]AttendanceCount{
+		{CourseID: 1, Status: models.AttendancePresent, Count: 3},
+		{CourseID: 1, Status: models.AttendanceLate, Count: 2},
+	}, nil)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(existing, nil)
+	mockRepo.EXPECT().SaveAcademic(gomock.Any(), existing).Return(nil)
+
+	academic, result, err := aggregator.Recompute(context.Background(), 7, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Record yang sudah ada diperbarui meski nilai mata kuliah belum ada
+	if academic == nil || academic.AttendanceRate != 0.8 || academic.GPA != 3.1 {
+		t.Fatalf("unexpected academic record %+v", academic)
+	}
+	if result.GPA != nil {
+		t.Errorf("GPA should stay undetermined without grades")
+	}
+}
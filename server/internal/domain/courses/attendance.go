@@ -0,0 +1,243 @@
+package courses
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/gocarina/gocsv"
+	"github.com/gorilla/mux"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// maxAttendanceUpload bounds the size of an attendance CSV upload
+	maxAttendanceUpload = 1 << 20
+)
+
+func validStatus(status string) bool {
+	for _, s := range models.AttendanceStatuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSession reads the attendance session named by the {id} path variable
+func (h *courseHandler) loadSession(w http.ResponseWriter, r *http.Request) (*models.AttendanceSession, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "id", Message: "ID pertemuan tidak valid"}}, nil)
+		return nil, false
+	}
+	session, err := h.repo.GetSessionByID(r.Context(), id)
+	if errors.Is(err, ErrSessionNotFound) {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Pertemuan tidak ditemukan"}}, nil)
+		return nil, false
+	}
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data pertemuan"}}, nil)
+		return nil, false
+	}
+	return session, true
+}
+
+// CreateSession handles POST /courses/:id/sessions
+func (h *courseHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	course, ok := h.loadCourse(w, r)
+	if !ok {
+		return
+	}
+
+	var req SessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	var errs []utils.ErrorDetail
+	session := models.AttendanceSession{CourseID: course.ID, SessionNumber: req.SessionNumber}
+	if req.SessionNumber < 1 {
+		errs = append(errs, utils.ErrorDetail{Field: "session_number", Message: "Nomor pertemuan harus minimal 1"})
+	}
+	var err error
+	if session.Date, err = time.Parse(dateLayout, req.Date); err != nil {
+		errs = append(errs, utils.ErrorDetail{Field: "date", Message: "Tanggal harus berformat YYYY-MM-DD"})
+	}
+	if req.TermID != 0 {
+		if _, err := h.repo.GetTermByID(r.Context(), req.TermID); err != nil {
+			errs = append(errs, utils.ErrorDetail{Field: "term_id", Message: "Term tidak ditemukan"})
+		}
+		session.TermID = &req.TermID
+	}
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	existing, err := h.repo.FindSession(r.Context(), course.ID, session.TermID, session.SessionNumber)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa data pertemuan"}}, nil)
+		return
+	}
+	if existing != nil {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Field: "session_number", Message: "Nomor pertemuan sudah dipakai pada term ini"}}, nil)
+		return
+	}
+
+	if err := h.repo.CreateSession(r.Context(), &session); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan pertemuan"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, nil, session)
+}
+
+// ListSessions handles GET /courses/:id/sessions?term_id=
+func (h *courseHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	course, ok := h.loadCourse(w, r)
+	if !ok {
+		return
+	}
+	termID, ok := parseUintQuery(r, "term_id")
+	if !ok {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "term_id", Message: "ID term tidak valid"}}, nil)
+		return
+	}
+
+	sessions, err := h.repo.ListSessions(r.Context(), course.ID, termID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data pertemuan"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, sessions)
+}
+
+// UploadAttendance handles POST /sessions/:id/attendance with a text/csv body
+// of student_id,status rows. Every row is validated before anything is
+// stored; students already recorded in the session get their status replaced.
+// Their academic records are recomputed in the background.
+func (h *courseHandler) UploadAttendance(w http.ResponseWriter, r *http.Request) {
+	session, ok := h.loadSession(w, r)
+	if !ok {
+		return
+	}
+
+	var rows []AttendanceRow
+	reader := csv.NewReader(http.MaxBytesReader(w, r.Body, maxAttendanceUpload))
+	reader.TrimLeadingSpace = true
+	if err := gocsv.UnmarshalCSV(reader, &rows); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Gagal parsing file CSV: " + err.Error()}}, nil)
+		return
+	}
+	if len(rows) == 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "File CSV tidak berisi data presensi"}}, nil)
+		return
+	}
+
+	studentIDs, err := h.repo.EnrolledStudentIDs(r.Context(), session.CourseID, session.TermID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data enrollment"}}, nil)
+		return
+	}
+	enrolled := make(map[uint]bool, len(studentIDs))
+	for _, id := range studentIDs {
+		enrolled[id] = true
+	}
+
+	var validationErrors []utils.ErrorDetail
+	seen := make(map[uint]int, len(rows))
+	records := make([]models.AttendanceRecord, 0, len(rows))
+	for i, row := range rows {
+		// Baris 1 adalah header CSV
+		line := i + 2
+		status := strings.ToLower(strings.TrimSpace(row.Status))
+		switch {
+		case !enrolled[row.StudentID]:
+			validationErrors = append(validationErrors, utils.ErrorDetail{
+				Field:   "student_id",
+				Message: fmt.Sprintf("Baris %d: mahasiswa %d tidak terdaftar di mata kuliah ini", line, row.StudentID),
+			})
+		case seen[row.StudentID] != 0:
+			validationErrors = append(validationErrors, utils.ErrorDetail{
+				Field:   "student_id",
+				Message: fmt.Sprintf("Baris %d: mahasiswa %d sudah tercantum di baris %d", line, row.StudentID, seen[row.StudentID]),
+			})
+		case !validStatus(status):
+			validationErrors = append(validationErrors, utils.ErrorDetail{
+				Field:   "status",
+				Message: fmt.Sprintf("Baris %d: status harus present, late, excused atau absent", line),
+			})
+		default:
+			seen[row.StudentID] = line
+			records = append(records, models.AttendanceRecord{SessionID: session.ID, UserID: row.StudentID, Status: status})
+		}
+	}
+	if len(validationErrors) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, validationErrors, nil)
+		return
+	}
+
+	if err := h.repo.SaveAttendance(r.Context(), records); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan presensi"}}, nil)
+		return
+	}
+
+	// Presensi sudah tersimpan; data akademik seluruh kelas dihitung ulang di
+	// luar request dan perubahannya diumumkan sekali
+	studentIDs = make([]uint, 0, len(records))
+	for _, record := range records {
+		studentIDs = append(studentIDs, record.UserID)
+	}
+	h.aggregator.RecomputeInBackground(studentIDs, session.TermID)
+
+	utils.WriteResponse(w, http.StatusOK, nil, AttendanceUploadResponse{SessionID: session.ID, Count: len(records), Recomputing: len(studentIDs)})
+}
+
+// ListAttendance handles GET /sessions/:id/attendance
+func (h *courseHandler) ListAttendance(w http.ResponseWriter, r *http.Request) {
+	session, ok := h.loadSession(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := h.repo.ListAttendance(r.Context(), session.ID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data presensi"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, records)
+}
+
+// AttendanceBreakdown handles GET /academic/student/:student_id/attendance?term_id=.
+// Without term_id it covers sessions recorded outside any term.
+func (h *courseHandler) AttendanceBreakdown(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.ParseUint(mux.Vars(r)["student_id"], 10, 32)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "student_id", Message: "ID mahasiswa tidak valid"}}, nil)
+		return
+	}
+	value, ok := parseUintQuery(r, "term_id")
+	if !ok {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "term_id", Message: "ID term tidak valid"}}, nil)
+		return
+	}
+	var termID *uint
+	if value != 0 {
+		termID = &value
+	}
+
+	breakdown, err := h.aggregator.Breakdown(r.Context(), uint(studentID), termID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil rekap presensi"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, breakdown)
+}
@@ -0,0 +1,196 @@
+package courses
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/agregasi"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAttendanceWeightsFromConfig(t *testing.T) {
+	weights := AttendanceWeightsFromConfig(&config.Config{AttendanceWeightLate: "0.5", AttendanceWeightExcused: "2", AttendanceWeightAbsent: "x"})
+	if weights[models.AttendanceLate] != 0.5 {
+		t.Errorf("late weight = %v, want 0.5", weights[models.AttendanceLate])
+	}
+	defaults := agregasi.DefaultAttendanceWeights()
+	if weights[models.AttendanceExcused] != defaults[models.AttendanceExcused] || weights[models.AttendanceAbsent] != defaults[models.AttendanceAbsent] {
+		t.Errorf("invalid weights should keep their default, got %v", weights)
+	}
+}
+
+func TestCourseHandler_CreateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().GetCourseByID(gomock.Any(), 1).Return(&models.Course{ID: 1}, nil)
+	mockRepo.EXPECT().GetTermByID(gomock.Any(), uint(3)).Return(&models.Term{ID: 3}, nil)
+	mockRepo.EXPECT().FindSession(gomock.Any(), 1, uintPtr(3), 2).Return(nil, nil)
+	mockRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Return(nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/courses/1/sessions", jsonBody(SessionRequest{TermID: 3, SessionNumber: 2, Date: "2025-09-01"}))
+	handler.CreateSession(w, withVars(req, map[string]string{"id": "1"}))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCourseHandler_CreateSession_Errors(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockCourseRepository(ctrl)
+		handler := newHandler(mockRepo)
+
+		mockRepo.EXPECT().GetCourseByID(gomock.Any(), 1).Return(&models.Course{ID: 1}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/courses/1/sessions", jsonBody(SessionRequest{SessionNumber: 0, Date: "01-09-2025"}))
+		handler.CreateSession(w, withVars(req, map[string]string{"id": "1"}))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("duplicate number", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockCourseRepository(ctrl)
+		handler := newHandler(mockRepo)
+
+		mockRepo.EXPECT().GetCourseByID(gomock.Any(), 1).Return(&models.Course{ID: 1}, nil)
+		mockRepo.EXPECT().FindSession(gomock.Any(), 1, nil, 2).Return(&models.AttendanceSession{ID: 4}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/courses/1/sessions", jsonBody(SessionRequest{SessionNumber: 2, Date: "2025-09-01"}))
+		handler.CreateSession(w, withVars(req, map[string]string{"id": "1"}))
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d", w.Code)
+		}
+	})
+}
+
+func TestCourseHandler_UploadAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	bus := events.NewBus()
+	var published [][]uint
+	bus.Subscribe(events.AcademicChanged, func(_ context.Context, event events.Event) {
+		published = append(published, event.Payload.(events.AcademicChangedPayload).UserIDs)
+	})
+	aggregator := NewAggregator(mockRepo, bus, agregasi.DefaultAttendanceWeights())
+	handler := NewCourseHandler(mockRepo, aggregator, nil)
+
+	session := &models.AttendanceSession{ID: 4, CourseID: 1}
+	mockRepo.EXPECT().GetSessionByID(gomock.Any(), 4).Return(session, nil)
+	mockRepo.EXPECT().EnrolledStudentIDs(gomock.Any(), 1, nil).Return([]uint{7, 8}, nil)
+	mockRepo.EXPECT().SaveAttendance(gomock.Any(), []models.AttendanceRecord{
+		{SessionID: 4, UserID: 7, Status: models.AttendancePresent},
+		{SessionID: 4, UserID: 8, Status: models.AttendanceLate},
+	}).Return(nil)
+	enrollments := []models.Enrollment{{ID: 10, Course: &models.Course{ID: 1, UniversityID: 4, Credits: 3}}}
+	for _, id := range []uint{7, 8} {
+		mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), id, nil).Return(enrollments, nil)
+		mockRepo.EXPECT().AttendanceCounts(gomock.Any(), id, nil).Return([]AttendanceCount{{CourseID: 1, Status: models.AttendancePresent, Count: 1}}, nil)
+	}
+	// Mahasiswa 8 belum punya record dan nilainya belum ada: tidak dibuat
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(&models.Academic{UserID: 7}, nil)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(8), nil).Return(nil, nil)
+	mockRepo.EXPECT().SaveAcademic(gomock.Any(), gomock.Any()).Return(nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/sessions/4/attendance", strings.NewReader("student_id,status\n7,present\n8, Late\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	handler.UploadAttendance(w, withVars(req, map[string]string{"id": "4"}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body struct {
+		Data AttendanceUploadResponse `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Data.Count != 2 || body.Data.Recomputing != 2 {
+		t.Errorf("unexpected upload summary %+v", body.Data)
+	}
+
+	aggregator.Wait()
+	if len(published) != 1 || len(published[0]) != 1 || published[0][0] != 7 {
+		t.Errorf("expected one academic change for user 7, got %v", published)
+	}
+}
+
+func TestCourseHandler_UploadAttendance_RowErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().GetSessionByID(gomock.Any(), 4).Return(&models.AttendanceSession{ID: 4, CourseID: 1}, nil)
+	mockRepo.EXPECT().EnrolledStudentIDs(gomock.Any(), 1, nil).Return([]uint{7, 8}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/sessions/4/attendance", strings.NewReader("student_id,status\n7,present\n9,present\n7,absent\n8,sick\n"))
+	handler.UploadAttendance(w, withVars(req, map[string]string{"id": "4"}))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var body struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if len(body.Errors) != 3 || !strings.HasPrefix(body.Errors[0].Message, "Baris 3") {
+		t.Errorf("expected one error per invalid row, got %+v", body.Errors)
+	}
+}
+
+func TestCourseHandler_AttendanceBreakdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), uintPtr(3)).Return([]AttendanceCount{
+		{CourseID: 1, Code: "IF101", Status: models.AttendancePresent, Count: 6},
+		{CourseID: 1, Code: "IF101", Status: models.AttendanceLate, Count: 2},
+		{CourseID: 2, Code: "IF102", Status: models.AttendanceAbsent, Count: 2},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/academic/student/7/attendance?term_id=3", nil)
+	handler.AttendanceBreakdown(w, withVars(req, map[string]string{"student_id": "7"}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body struct {
+		Data AttendanceBreakdown `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	breakdown := body.Data
+	if len(breakdown.Courses) != 2 || breakdown.Sessions != 10 {
+		t.Fatalf("unexpected breakdown %+v", breakdown)
+	}
+	// (6 + 2*0.75) / 8
+	if rate := breakdown.Courses[0].Rate; rate == nil || math.Abs(*rate-0.9375) > 1e-9 {
+		t.Errorf("unexpected IF101 rate %v", rate)
+	}
+	if rate := breakdown.Rate; rate == nil || math.Abs(*rate-0.75) > 1e-9 {
+		t.Errorf("unexpected overall rate %v", rate)
+	}
+}
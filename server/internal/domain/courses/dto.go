@@ -0,0 +1,105 @@
+package courses
+
+import (
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/agregasi"
+)
+
+// CourseFilter membatasi daftar mata kuliah; UniversityID 0 berarti semua
+type CourseFilter struct {
+	UniversityID uint
+}
+
+// CourseRequest creates or replaces a course
+type CourseRequest struct {
+	UniversityID uint   `json:"university_id"`
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Credits      int    `json:"credits"`
+	Core         bool   `json:"core"`
+	LecturerID   *uint  `json:"lecturer_id"`
+}
+
+// EnrollRequest enrolls a student in a course
+type EnrollRequest struct {
+	StudentID uint `json:"student_id"`
+	TermID    uint `json:"term_id,omitempty"`
+}
+
+// GradeRequest records the 0-100 score of a grade component
+type GradeRequest struct {
+	Score *float64 `json:"score"`
+}
+
+// RecomputeRequest recomputes a student's academic record of a term.
+// ClearOverrides lists the fields whose manual value is replaced by the
+// computed one.
+type RecomputeRequest struct {
+	TermID         uint     `json:"term_id,omitempty"`
+	ClearOverrides []string `json:"clear_overrides"`
+}
+
+// AggregateResponse is the academic record after a recomputation together
+// with the values derived from the course grades
+type AggregateResponse struct {
+	Academic *models.Academic `json:"academic"`
+	Computed agregasi.Result  `json:"computed"`
+}
+
+// GradeResponse is a recorded grade and the academic record it updated
+type GradeResponse struct {
+	Grade    *models.Grade    `json:"grade,omitempty"`
+	Academic *models.Academic `json:"academic"`
+}
+
+// SessionRequest creates an attendance session. The date uses the YYYY-MM-DD format.
+type SessionRequest struct {
+	TermID        uint   `json:"term_id,omitempty"`
+	SessionNumber int    `json:"session_number"`
+	Date          string `json:"date"`
+}
+
+// AttendanceRow is one line of an attendance CSV upload
+type AttendanceRow struct {
+	StudentID uint   `csv:"student_id"`
+	Status    string `csv:"status"`
+}
+
+// AttendanceUploadResponse summarizes an attendance CSV upload. Recomputing
+// is the number of students whose academic record is refreshed in the background.
+type AttendanceUploadResponse struct {
+	SessionID   int `json:"session_id"`
+	Count       int `json:"count"`
+	Recomputing int `json:"recomputing"`
+}
+
+// AttendanceCount is the number of records of one status in one course
+type AttendanceCount struct {
+	CourseID int
+	Code     string
+	Name     string
+	Status   string
+	Count    int
+}
+
+// CourseAttendance is the attendance of a student in one course
+type CourseAttendance struct {
+	CourseID int            `json:"course_id"`
+	Code     string         `json:"code"`
+	Name     string         `json:"name"`
+	Counts   map[string]int `json:"counts"`
+	Sessions int            `json:"sessions"`
+	Rate     *float64       `json:"rate"`
+}
+
+// AttendanceBreakdown is the attendance of a student in a term per course
+// and overall, with the weights used for the rates
+type AttendanceBreakdown struct {
+	StudentID uint                       `json:"student_id"`
+	TermID    *uint                      `json:"term_id"`
+	Weights   agregasi.AttendanceWeights `json:"weights"`
+	Courses   []CourseAttendance         `json:"courses"`
+	Counts    map[string]int             `json:"counts"`
+	Sessions  int                        `json:"sessions"`
+	Rate      *float64                   `json:"rate"`
+}
@@ -0,0 +1,416 @@
+package courses
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/agregasi"
+	"tsukamoto/internal/tenant"
+	"tsukamoto/internal/utils"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// maxCredits is the largest number of credits a single course can carry
+const maxCredits = 24
+
+type courseHandler struct {
+	repo       CourseRepository
+	aggregator *Aggregator
+	audit      *audit.Log
+}
+
+func NewCourseHandler(repo CourseRepository, aggregator *Aggregator, log *audit.Log) CourseHandler {
+	return &courseHandler{repo: repo, aggregator: aggregator, audit: log}
+}
+
+// currentGrade returns the enrollment's grade for the component, nil when it has none
+func currentGrade(enrollment *models.Enrollment, component string) *models.Grade {
+	for i := range enrollment.Grades {
+		if enrollment.Grades[i].Component == component {
+			return &enrollment.Grades[i]
+		}
+	}
+	return nil
+}
+
+func validComponent(component string) bool {
+	for _, c := range models.GradeComponents() {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// parseUintQuery reads an optional numeric query parameter
+func parseUintQuery(r *http.Request, name string) (uint, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseCourse validates the request and converts it into a course
+func (h *courseHandler) parseCourse(r *http.Request, req CourseRequest) (models.Course, []utils.ErrorDetail) {
+	var errs []utils.ErrorDetail
+	course := models.Course{
+		UniversityID: req.UniversityID,
+		Code:         strings.TrimSpace(req.Code),
+		Name:         strings.TrimSpace(req.Name),
+		Credits:      req.Credits,
+		Core:         req.Core,
+		LecturerID:   req.LecturerID,
+	}
+
+	if req.UniversityID == 0 {
+		errs = append(errs, utils.ErrorDetail{Field: "university_id", Message: "University ID diperlukan"})
+	} else if _, err := h.repo.GetUniversityByID(r.Context(), req.UniversityID); err != nil {
+		errs = append(errs, utils.ErrorDetail{Field: "university_id", Message: "Universitas tidak ditemukan"})
+	}
+	if course.Code == "" || len(course.Code) > 20 {
+		errs = append(errs, utils.ErrorDetail{Field: "code", Message: "Kode mata kuliah diperlukan, maksimal 20 karakter"})
+	}
+	if course.Name == "" || len(course.Name) > 100 {
+		errs = append(errs, utils.ErrorDetail{Field: "name", Message: "Nama mata kuliah diperlukan, maksimal 100 karakter"})
+	}
+	if course.Credits < 1 || course.Credits > maxCredits {
+		errs = append(errs, utils.ErrorDetail{Field: "credits", Message: "SKS harus antara 1 dan " + strconv.Itoa(maxCredits)})
+	}
+	if req.LecturerID != nil {
+		lecturer, err := h.repo.GetUserByID(r.Context(), *req.LecturerID)
+		if err != nil || lecturer.Role == "student" {
+			errs = append(errs, utils.ErrorDetail{Field: "lecturer_id", Message: "Dosen tidak ditemukan"})
+		}
+	}
+	return course, errs
+}
+
+// checkCode rejects a code already used by another course of the same university
+func (h *courseHandler) checkCode(w http.ResponseWriter, r *http.Request, course models.Course) bool {
+	existing, err := h.repo.GetCourseByCode(r.Context(), course.UniversityID, course.Code)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa data mata kuliah"}}, nil)
+		return false
+	}
+	if existing != nil && existing.ID != course.ID {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Field: "code", Message: "Kode mata kuliah sudah dipakai di universitas ini"}}, nil)
+		return false
+	}
+	return true
+}
+
+// loadCourse reads the course named by the {id} path variable
+func (h *courseHandler) loadCourse(w http.ResponseWriter, r *http.Request) (*models.Course, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "id", Message: "ID mata kuliah tidak valid"}}, nil)
+		return nil, false
+	}
+	course, err := h.repo.GetCourseByID(r.Context(), id)
+	if errors.Is(err, ErrCourseNotFound) {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Mata kuliah tidak ditemukan"}}, nil)
+		return nil, false
+	}
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data mata kuliah"}}, nil)
+		return nil, false
+	}
+	return course, true
+}
+
+// loadEnrollment reads the enrollment named by the {id} path variable
+func (h *courseHandler) loadEnrollment(w http.ResponseWriter, r *http.Request) (*models.Enrollment, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "id", Message: "ID enrollment tidak valid"}}, nil)
+		return nil, false
+	}
+	enrollment, err := h.repo.GetEnrollmentByID(r.Context(), id)
+	if errors.Is(err, ErrEnrollmentNotFound) {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Enrollment tidak ditemukan"}}, nil)
+		return nil, false
+	}
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data enrollment"}}, nil)
+		return nil, false
+	}
+	return enrollment, true
+}
+
+// CreateCourse handles POST /courses
+func (h *courseHandler) CreateCourse(w http.ResponseWriter, r *http.Request) {
+	var req CourseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	course, errs := h.parseCourse(r, req)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+	if !h.checkCode(w, r, course) {
+		return
+	}
+
+	if err := h.repo.CreateCourse(r.Context(), &course); err != nil {
+		if errors.Is(err, tenant.ErrOutsideScope) {
+			utils.WriteResponse(w, http.StatusForbidden, []utils.ErrorDetail{{Field: "university_id", Message: "Universitas di luar jangkauan Anda"}}, nil)
+			return
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan mata kuliah"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, nil, course)
+}
+
+// ListCourses handles GET /courses?university_id=
+func (h *courseHandler) ListCourses(w http.ResponseWriter, r *http.Request) {
+	var filter CourseFilter
+	var ok bool
+	if filter.UniversityID, ok = parseUintQuery(r, "university_id"); !ok {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "university_id", Message: "ID universitas tidak valid"}}, nil)
+		return
+	}
+
+	courses, err := h.repo.ListCourses(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data mata kuliah"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, courses)
+}
+
+// GetCourse handles GET /courses/:id
+func (h *courseHandler) GetCourse(w http.ResponseWriter, r *http.Request) {
+	course, ok := h.loadCourse(w, r)
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, course)
+}
+
+// UpdateCourse handles PUT /courses/:id. Changing the credits or the core
+// flag does not recompute existing academic records until their next grade.
+func (h *courseHandler) UpdateCourse(w http.ResponseWriter, r *http.Request) {
+	existing, ok := h.loadCourse(w, r)
+	if !ok {
+		return
+	}
+
+	var req CourseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	course, errs := h.parseCourse(r, req)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+	course.ID = existing.ID
+	course.CreatedAt = existing.CreatedAt
+	if !h.checkCode(w, r, course) {
+		return
+	}
+
+	if err := h.repo.UpdateCourse(r.Context(), &course); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memperbarui mata kuliah"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, course)
+}
+
+// Enroll handles POST /courses/:id/enrollments
+func (h *courseHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	course, ok := h.loadCourse(w, r)
+	if !ok {
+		return
+	}
+
+	var req EnrollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	var errs []utils.ErrorDetail
+	if student, err := h.repo.GetUserByID(r.Context(), req.StudentID); err != nil || student.Role != "student" {
+		errs = append(errs, utils.ErrorDetail{Field: "student_id", Message: "Mahasiswa tidak ditemukan"})
+	}
+	var termID *uint
+	if req.TermID != 0 {
+		if _, err := h.repo.GetTermByID(r.Context(), req.TermID); err != nil {
+			errs = append(errs, utils.ErrorDetail{Field: "term_id", Message: "Term tidak ditemukan"})
+		}
+		termID = &req.TermID
+	}
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	existing, err := h.repo.FindEnrollment(r.Context(), course.ID, req.StudentID, termID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa enrollment"}}, nil)
+		return
+	}
+	if existing != nil {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Field: "student_id", Message: "Mahasiswa sudah terdaftar di mata kuliah ini pada term tersebut"}}, nil)
+		return
+	}
+
+	enrollment := models.Enrollment{CourseID: course.ID, UserID: req.StudentID, TermID: termID}
+	if err := h.repo.CreateEnrollment(r.Context(), &enrollment); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan enrollment"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, nil, enrollment)
+}
+
+// ListEnrollments handles GET /courses/:id/enrollments?term_id=
+func (h *courseHandler) ListEnrollments(w http.ResponseWriter, r *http.Request) {
+	course, ok := h.loadCourse(w, r)
+	if !ok {
+		return
+	}
+	termID, ok := parseUintQuery(r, "term_id")
+	if !ok {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "term_id", Message: "ID term tidak valid"}}, nil)
+		return
+	}
+
+	enrollments, err := h.repo.ListEnrollments(r.Context(), course.ID, termID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data enrollment"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, enrollments)
+}
+
+// recompute refreshes the academic record after a grade change. The grade is
+// already stored, so a failure is logged rather than reported.
+func (h *courseHandler) recompute(r *http.Request, enrollment *models.Enrollment) *models.Academic {
+	academic, _, err := h.aggregator.Recompute(r.Context(), enrollment.UserID, enrollment.TermID, nil)
+	if err != nil {
+		logrus.Warnf("recomputing academic record of user %d failed: %v", enrollment.UserID, err)
+		return nil
+	}
+	return academic
+}
+
+// RecordGrade handles PUT /enrollments/:id/grades/:component
+func (h *courseHandler) RecordGrade(w http.ResponseWriter, r *http.Request) {
+	enrollment, ok := h.loadEnrollment(w, r)
+	if !ok {
+		return
+	}
+	component := mux.Vars(r)["component"]
+	if !validComponent(component) {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "component", Message: "Komponen nilai harus midterm, final_exam atau coursework"}}, nil)
+		return
+	}
+
+	var req GradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+	if req.Score == nil || *req.Score < 0 || *req.Score > 100 {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "score", Message: "Nilai harus antara 0 dan 100"}}, nil)
+		return
+	}
+
+	previous := currentGrade(enrollment, component)
+	grade := models.Grade{EnrollmentID: enrollment.ID, Component: component, Score: *req.Score}
+	if err := h.repo.SaveGrade(r.Context(), &grade); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan nilai"}}, nil)
+		return
+	}
+	action := models.AuditCreate
+	if previous != nil {
+		action = models.AuditUpdate
+	}
+	h.audit.Record(r, audit.Entry{Action: action, EntityType: models.AuditEntityGrade, EntityID: grade.ID, Before: previous, After: grade})
+	utils.WriteResponse(w, http.StatusOK, nil, GradeResponse{Grade: &grade, Academic: h.recompute(r, enrollment)})
+}
+
+// DeleteGrade handles DELETE /enrollments/:id/grades/:component. Derived
+// values without any remaining grade keep their last computed value.
+func (h *courseHandler) DeleteGrade(w http.ResponseWriter, r *http.Request) {
+	enrollment, ok := h.loadEnrollment(w, r)
+	if !ok {
+		return
+	}
+	component := mux.Vars(r)["component"]
+
+	err := h.repo.DeleteGrade(r.Context(), enrollment.ID, component)
+	if errors.Is(err, ErrGradeNotFound) {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Nilai tidak ditemukan"}}, nil)
+		return
+	}
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghapus nilai"}}, nil)
+		return
+	}
+	if previous := currentGrade(enrollment, component); previous != nil {
+		h.audit.Record(r, audit.Entry{Action: models.AuditDelete, EntityType: models.AuditEntityGrade, EntityID: previous.ID, Before: previous})
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, GradeResponse{Academic: h.recompute(r, enrollment)})
+}
+
+// Recompute handles POST /academic/student/:student_id/recompute
+func (h *courseHandler) Recompute(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.ParseUint(mux.Vars(r)["student_id"], 10, 32)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "student_id", Message: "ID mahasiswa tidak valid"}}, nil)
+		return
+	}
+
+	var req RecomputeRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+			return
+		}
+	}
+	derived := agregasi.Result{}.Fields()
+	for _, field := range req.ClearOverrides {
+		if _, ok := derived[field]; !ok {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "clear_overrides", Message: "Field " + field + " tidak dihitung dari nilai mata kuliah"}}, nil)
+			return
+		}
+	}
+	var termID *uint
+	if req.TermID != 0 {
+		if _, err := h.repo.GetTermByID(r.Context(), req.TermID); err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "term_id", Message: "Term tidak ditemukan"}}, nil)
+			return
+		}
+		termID = &req.TermID
+	}
+
+	academic, result, err := h.aggregator.Recompute(r.Context(), uint(studentID), termID, req.ClearOverrides)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghitung ulang data akademik"}}, nil)
+		return
+	}
+	if academic == nil {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Belum ada data akademik pada term ini; nilai mata kuliah dan presensi belum lengkap"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, AggregateResponse{Academic: academic, Computed: result})
+}
@@ -0,0 +1,353 @@
+package courses
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/agregasi"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func jsonBody(v interface{}) *bytes.Reader {
+	body, _ := json.Marshal(v)
+	return bytes.NewReader(body)
+}
+
+func withVars(req *http.Request, vars map[string]string) *http.Request {
+	return mux.SetURLVars(req, vars)
+}
+
+func newHandler(repo CourseRepository) CourseHandler {
+	return NewCourseHandler(repo, NewAggregator(repo, nil, agregasi.DefaultAttendanceWeights()), nil)
+}
+
+func validCourse() CourseRequest {
+	return CourseRequest{UniversityID: 4, Code: "IF101", Name: "Algoritma", Credits: 3, Core: true}
+}
+
+func TestCourseHandler_CreateCourse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(4)).Return(&models.University{ID: 4}, nil)
+	mockRepo.EXPECT().GetCourseByCode(gomock.Any(), uint(4), "IF101").Return(nil, nil)
+	mockRepo.EXPECT().CreateCourse(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, course *models.Course) error {
+		course.ID = 1
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.CreateCourse(w, httptest.NewRequest("POST", "/courses", jsonBody(validCourse())))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCourseHandler_CreateCourse_Errors(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockCourseRepository(ctrl)
+		handler := newHandler(mockRepo)
+
+		mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(4)).Return(&models.University{ID: 4}, nil)
+		req := validCourse()
+		req.Code = " "
+		req.Credits = 0
+
+		w := httptest.NewRecorder()
+		handler.CreateCourse(w, httptest.NewRequest("POST", "/courses", jsonBody(req)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockCourseRepository(ctrl)
+		handler := newHandler(mockRepo)
+
+		mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(4)).Return(&models.University{ID: 4}, nil)
+		mockRepo.EXPECT().GetCourseByCode(gomock.Any(), uint(4), "IF101").Return(&models.Course{ID: 9}, nil)
+
+		w := httptest.NewRecorder()
+		handler.CreateCourse(w, httptest.NewRequest("POST", "/courses", jsonBody(validCourse())))
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("student as lecturer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockCourseRepository(ctrl)
+		handler := newHandler(mockRepo)
+
+		mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(4)).Return(&models.University{ID: 4}, nil)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(8)).Return(&models.User{ID: 8, Role: "student"}, nil)
+		req := validCourse()
+		req.LecturerID = uintPtr(8)
+
+		w := httptest.NewRecorder()
+		handler.CreateCourse(w, httptest.NewRequest("POST", "/courses", jsonBody(req)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestCourseHandler_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().GetCourseByID(gomock.Any(), 1).Return(&models.Course{ID: 1}, nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(7)).Return(&models.User{ID: 7, Role: "student"}, nil)
+	mockRepo.EXPECT().GetTermByID(gomock.Any(), uint(3)).Return(&models.Term{ID: 3}, nil)
+	mockRepo.EXPECT().FindEnrollment(gomock.Any(), 1, uint(7), uintPtr(3)).Return(nil, nil)
+	mockRepo.EXPECT().CreateEnrollment(gomock.Any(), gomock.Any()).Return(nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/courses/1/enrollments", jsonBody(EnrollRequest{StudentID: 7, TermID: 3}))
+	handler.Enroll(w, withVars(req, map[string]string{"id": "1"}))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCourseHandler_Enroll_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().GetCourseByID(gomock.Any(), 1).Return(&models.Course{ID: 1}, nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(7)).Return(&models.User{ID: 7, Role: "student"}, nil)
+	mockRepo.EXPECT().FindEnrollment(gomock.Any(), 1, uint(7), nil).Return(&models.Enrollment{ID: 2}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/courses/1/enrollments", jsonBody(EnrollRequest{StudentID: 7}))
+	handler.Enroll(w, withVars(req, map[string]string{"id": "1"}))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestCourseHandler_Enroll_CourseNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().GetCourseByID(gomock.Any(), 1).Return(nil, ErrCourseNotFound)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/courses/1/enrollments", jsonBody(EnrollRequest{StudentID: 7}))
+	handler.Enroll(w, withVars(req, map[string]string{"id": "1"}))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestCourseHandler_RecordGrade(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	enrollment := &models.Enrollment{ID: 10, CourseID: 1, UserID: 7}
+	mockRepo.EXPECT().GetEnrollmentByID(gomock.Any(), 10).Return(enrollment, nil)
+	mockRepo.EXPECT().SaveGrade(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, grade *models.Grade) error {
+		if grade.EnrollmentID != 10 || grade.Component != models.GradeFinalExam || grade.Score != 88 {
+			t.Errorf("unexpected grade %+v", grade)
+		}
+		return nil
+	})
+	mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return(gradedEnrollments(), nil)
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), nil).Return([]AttendanceCount{{CourseID: 1, Status: models.AttendancePresent, Count: 2}}, nil)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(nil, nil)
+	mockRepo.EXPECT().SaveAcademic(gomock.Any(), gomock.Any()).Return(nil)
+
+	score := 88.0
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/enrollments/10/grades/final_exam", jsonBody(GradeRequest{Score: &score}))
+	handler.RecordGrade(w, withVars(req, map[string]string{"id": "10", "component": models.GradeFinalExam}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body struct {
+		Data GradeResponse `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Data.Academic == nil || body.Data.Academic.CoreCourseAverage != 85.5 {
+		t.Errorf("expected recomputed academic record, got %+v", body.Data.Academic)
+	}
+}
+
+func TestCourseHandler_RecordGrade_Audited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	store := &audit.MemoryStore{}
+	handler := NewCourseHandler(mockRepo, NewAggregator(mockRepo, nil, agregasi.DefaultAttendanceWeights()), audit.NewLog(store))
+
+	enrollment := &models.Enrollment{ID: 10, CourseID: 1, UserID: 7, Grades: []models.Grade{{ID: 3, EnrollmentID: 10, Component: models.GradeMidterm, Score: 60}}}
+	mockRepo.EXPECT().GetEnrollmentByID(gomock.Any(), 10).Return(enrollment, nil).Times(2)
+	mockRepo.EXPECT().SaveGrade(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, grade *models.Grade) error {
+		grade.ID = 3
+		return nil
+	})
+	mockRepo.EXPECT().DeleteGrade(gomock.Any(), 10, models.GradeMidterm).Return(nil)
+	mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), nil).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(nil, nil).AnyTimes()
+	mockRepo.EXPECT().SaveAcademic(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	score := 75.0
+	req := httptest.NewRequest("PUT", "/enrollments/10/grades/midterm", jsonBody(GradeRequest{Score: &score}))
+	handler.RecordGrade(httptest.NewRecorder(), withVars(req, map[string]string{"id": "10", "component": models.GradeMidterm}))
+	req = httptest.NewRequest("DELETE", "/enrollments/10/grades/midterm", nil)
+	handler.DeleteGrade(httptest.NewRecorder(), withVars(req, map[string]string{"id": "10", "component": models.GradeMidterm}))
+
+	if len(store.Entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(store.Entries))
+	}
+	var changes map[string]audit.Change
+	store.Entries[0].Changes.Decode(&changes)
+	if store.Entries[0].Action != models.AuditUpdate || changes["score"].From != 60.0 || changes["score"].To != 75.0 {
+		t.Errorf("expected the score change, got %s %+v", store.Entries[0].Action, changes)
+	}
+	if store.Entries[1].Action != models.AuditDelete || store.Entries[1].EntityID != "3" {
+		t.Errorf("expected the deletion of grade 3, got %+v", store.Entries[1])
+	}
+}
+
+func TestCourseHandler_RecordGrade_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		component string
+		score     *float64
+	}{
+		{"unknown component", "quiz", nil},
+		{"missing score", models.GradeMidterm, nil},
+		{"score above 100", models.GradeMidterm, func() *float64 { v := 101.0; return &v }()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := NewMockCourseRepository(ctrl)
+			handler := newHandler(mockRepo)
+
+			mockRepo.EXPECT().GetEnrollmentByID(gomock.Any(), 10).Return(&models.Enrollment{ID: 10}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/enrollments/10/grades/"+tt.component, jsonBody(GradeRequest{Score: tt.score}))
+			handler.RecordGrade(w, withVars(req, map[string]string{"id": "10", "component": tt.component}))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestCourseHandler_DeleteGrade_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	mockRepo.EXPECT().GetEnrollmentByID(gomock.Any(), 10).Return(&models.Enrollment{ID: 10}, nil)
+	mockRepo.EXPECT().DeleteGrade(gomock.Any(), 10, models.GradeMidterm).Return(ErrGradeNotFound)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/enrollments/10/grades/midterm", nil)
+	handler.DeleteGrade(w, withVars(req, map[string]string{"id": "10", "component": models.GradeMidterm}))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestCourseHandler_Recompute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockCourseRepository(ctrl)
+	handler := newHandler(mockRepo)
+
+	existing := &models.Academic{ID: 5, UserID: 7, GPA: 3.9}
+	mockRepo.EXPECT().GetTermByID(gomock.Any(), uint(3)).Return(&models.Term{ID: 3}, nil)
+	mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), uintPtr(3)).Return(gradedEnrollments(), nil)
+	mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), uintPtr(3)).Return(nil, nil)
+	mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), uintPtr(3)).Return(existing, nil)
+	mockRepo.EXPECT().SaveAcademic(gomock.Any(), existing).Return(nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/academic/student/7/recompute", jsonBody(RecomputeRequest{TermID: 3, ClearOverrides: []string{"gpa"}}))
+	handler.Recompute(w, withVars(req, map[string]string{"student_id": "7"}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if existing.Source("gpa") != models.ProvenanceComputed || existing.GPA == 3.9 {
+		t.Errorf("cleared GPA override should be recomputed, got %v (%s)", existing.GPA, existing.Source("gpa"))
+	}
+}
+
+func TestCourseHandler_Recompute_Errors(t *testing.T) {
+	t.Run("unknown field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		handler := newHandler(NewMockCourseRepository(ctrl))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/academic/student/7/recompute", jsonBody(RecomputeRequest{ClearOverrides: []string{"cca"}}))
+		handler.Recompute(w, withVars(req, map[string]string{"student_id": "7"}))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("nothing to compute", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockCourseRepository(ctrl)
+		handler := newHandler(mockRepo)
+
+		mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return(nil, nil)
+		mockRepo.EXPECT().AttendanceCounts(gomock.Any(), uint(7), nil).Return(nil, nil)
+		mockRepo.EXPECT().GetAcademic(gomock.Any(), uint(7), nil).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		handler.Recompute(w, withVars(httptest.NewRequest("POST", "/academic/student/7/recompute", nil), map[string]string{"student_id": "7"}))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := NewMockCourseRepository(ctrl)
+		handler := newHandler(mockRepo)
+
+		mockRepo.EXPECT().ListStudentEnrollments(gomock.Any(), uint(7), nil).Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		handler.Recompute(w, withVars(httptest.NewRequest("POST", "/academic/student/7/recompute", nil), map[string]string{"student_id": "7"}))
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", w.Code)
+		}
+	})
+}
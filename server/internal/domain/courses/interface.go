@@ -0,0 +1,84 @@
+package courses
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tsukamoto/internal/models"
+)
+
+var (
+	// ErrCourseNotFound is returned when the course does not exist
+	ErrCourseNotFound = errors.New("course not found")
+	// ErrEnrollmentNotFound is returned when the enrollment does not exist
+	ErrEnrollmentNotFound = errors.New("enrollment not found")
+	// ErrGradeNotFound is returned by DeleteGrade when the component has no grade
+	ErrGradeNotFound = errors.New("grade not found")
+	// ErrSessionNotFound is returned when the attendance session does not exist
+	ErrSessionNotFound = errors.New("attendance session not found")
+	// ErrUserNotFound is returned by GetUserByID when the user does not exist
+	ErrUserNotFound = errors.New("user not found")
+)
+
+type CourseRepository interface {
+	CreateCourse(ctx context.Context, course *models.Course) error
+	ListCourses(ctx context.Context, filter CourseFilter) ([]models.Course, error)
+	GetCourseByID(ctx context.Context, id int) (*models.Course, error)
+	// GetCourseByCode returns the course with the code at a university, or nil when there is none
+	GetCourseByCode(ctx context.Context, universityID uint, code string) (*models.Course, error)
+	UpdateCourse(ctx context.Context, course *models.Course) error
+	GetUniversityByID(ctx context.Context, id uint) (*models.University, error)
+	GetTermByID(ctx context.Context, id uint) (*models.Term, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+
+	CreateEnrollment(ctx context.Context, enrollment *models.Enrollment) error
+	// FindEnrollment returns the enrollment of a student in a course and term, or nil when there is none
+	FindEnrollment(ctx context.Context, courseID int, userID uint, termID *uint) (*models.Enrollment, error)
+	// GetEnrollmentByID returns the enrollment with its course and grades
+	GetEnrollmentByID(ctx context.Context, id int) (*models.Enrollment, error)
+	// ListEnrollments returns the enrollments of a course with their grades
+	ListEnrollments(ctx context.Context, courseID int, termID uint) ([]models.Enrollment, error)
+	// ListStudentEnrollments returns the enrollments of a student in a term
+	// (nil for records without a term) with their course and grades
+	ListStudentEnrollments(ctx context.Context, userID uint, termID *uint) ([]models.Enrollment, error)
+
+	// SaveGrade inserts the grade or replaces the score of the same component
+	SaveGrade(ctx context.Context, grade *models.Grade) error
+	DeleteGrade(ctx context.Context, enrollmentID int, component string) error
+
+	CreateSession(ctx context.Context, session *models.AttendanceSession) error
+	// FindSession returns the session with the number in a course and term, or nil when there is none
+	FindSession(ctx context.Context, courseID int, termID *uint, number int) (*models.AttendanceSession, error)
+	GetSessionByID(ctx context.Context, id int) (*models.AttendanceSession, error)
+	// ListSessions returns the sessions of a course in session order; termID 0 means every term
+	ListSessions(ctx context.Context, courseID int, termID uint) ([]models.AttendanceSession, error)
+	// EnrolledStudentIDs returns the students enrolled in a course in a term
+	EnrolledStudentIDs(ctx context.Context, courseID int, termID *uint) ([]uint, error)
+	// SaveAttendance inserts the records and replaces the status of students
+	// already recorded in the same session
+	SaveAttendance(ctx context.Context, records []models.AttendanceRecord) error
+	ListAttendance(ctx context.Context, sessionID int) ([]models.AttendanceRecord, error)
+	// AttendanceCounts counts the attendance records of a student per course and status in a term
+	AttendanceCounts(ctx context.Context, userID uint, termID *uint) ([]AttendanceCount, error)
+
+	// GetAcademic returns the academic record of a student in a term, or nil when there is none
+	GetAcademic(ctx context.Context, userID uint, termID *uint) (*models.Academic, error)
+	SaveAcademic(ctx context.Context, academic *models.Academic) error
+}
+
+type CourseHandler interface {
+	CreateCourse(w http.ResponseWriter, r *http.Request)
+	ListCourses(w http.ResponseWriter, r *http.Request)
+	GetCourse(w http.ResponseWriter, r *http.Request)
+	UpdateCourse(w http.ResponseWriter, r *http.Request)
+	Enroll(w http.ResponseWriter, r *http.Request)
+	ListEnrollments(w http.ResponseWriter, r *http.Request)
+	RecordGrade(w http.ResponseWriter, r *http.Request)
+	DeleteGrade(w http.ResponseWriter, r *http.Request)
+	Recompute(w http.ResponseWriter, r *http.Request)
+	CreateSession(w http.ResponseWriter, r *http.Request)
+	ListSessions(w http.ResponseWriter, r *http.Request)
+	UploadAttendance(w http.ResponseWriter, r *http.Request)
+	ListAttendance(w http.ResponseWriter, r *http.Request)
+	AttendanceBreakdown(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,257 @@
+package courses
+
+import (
+	"context"
+	"errors"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/tenant"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type courseRepository struct {
+	db *gorm.DB
+}
+
+func NewCourseRepository(db *gorm.DB) CourseRepository {
+	return &courseRepository{db: db}
+}
+
+// termScope matches a nullable term_id column
+func termScope(termID *uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if termID == nil {
+			return db.Where("term_id IS NULL")
+		}
+		return db.Where("term_id = ?", *termID)
+	}
+}
+
+func (r *courseRepository) CreateCourse(ctx context.Context, course *models.Course) error {
+	if !tenant.FromContext(ctx).Allows(course.UniversityID) {
+		return tenant.ErrOutsideScope
+	}
+	return r.db.WithContext(ctx).Create(course).Error
+}
+
+func (r *courseRepository) ListCourses(ctx context.Context, filter CourseFilter) ([]models.Course, error) {
+	query := r.db.WithContext(ctx).Scopes(tenant.Scoped(ctx, "university_id")).Order("code, id")
+	if filter.UniversityID != 0 {
+		query = query.Where("university_id = ?", filter.UniversityID)
+	}
+	var courses []models.Course
+	err := query.Find(&courses).Error
+	return courses, err
+}
+
+func (r *courseRepository) GetCourseByID(ctx context.Context, id int) (*models.Course, error) {
+	var course models.Course
+	if err := r.db.WithContext(ctx).First(&course, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound
+		}
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *courseRepository) GetCourseByCode(ctx context.Context, universityID uint, code string) (*models.Course, error) {
+	var course models.Course
+	err := r.db.WithContext(ctx).Where("university_id = ? AND code = ?", universityID, code).First(&course).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *courseRepository) UpdateCourse(ctx context.Context, course *models.Course) error {
+	return r.db.WithContext(ctx).Save(course).Error
+}
+
+func (r *courseRepository) GetUniversityByID(ctx context.Context, id uint) (*models.University, error) {
+	var university models.University
+	if err := r.db.WithContext(ctx).First(&university, id).Error; err != nil {
+		return nil, err
+	}
+	return &university, nil
+}
+
+func (r *courseRepository) GetTermByID(ctx context.Context, id uint) (*models.Term, error) {
+	var term models.Term
+	if err := r.db.WithContext(ctx).First(&term, id).Error; err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *courseRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *courseRepository) CreateEnrollment(ctx context.Context, enrollment *models.Enrollment) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(enrollment).Error
+}
+
+func (r *courseRepository) FindEnrollment(ctx context.Context, courseID int, userID uint, termID *uint) (*models.Enrollment, error) {
+	var enrollment models.Enrollment
+	err := r.db.WithContext(ctx).Where("course_id = ? AND user_id = ?", courseID, userID).Scopes(termScope(termID)).First(&enrollment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *courseRepository) GetEnrollmentByID(ctx context.Context, id int) (*models.Enrollment, error) {
+	var enrollment models.Enrollment
+	if err := r.db.WithContext(ctx).Preload("Course").Preload("Grades").First(&enrollment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEnrollmentNotFound
+		}
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *courseRepository) ListEnrollments(ctx context.Context, courseID int, termID uint) ([]models.Enrollment, error) {
+	query := r.db.WithContext(ctx).Preload("Grades").Where("course_id = ?", courseID).Order("user_id, id")
+	if termID != 0 {
+		query = query.Where("term_id = ?", termID)
+	}
+	var enrollments []models.Enrollment
+	err := query.Find(&enrollments).Error
+	return enrollments, err
+}
+
+func (r *courseRepository) ListStudentEnrollments(ctx context.Context, userID uint, termID *uint) ([]models.Enrollment, error) {
+	var enrollments []models.Enrollment
+	err := r.db.WithContext(ctx).Preload("Course").Preload("Grades").
+		Where("user_id = ?", userID).Scopes(termScope(termID)).
+		Order("id").Find(&enrollments).Error
+	return enrollments, err
+}
+
+func (r *courseRepository) SaveGrade(ctx context.Context, grade *models.Grade) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "enrollment_id"}, {Name: "component"}},
+		DoUpdates: clause.AssignmentColumns([]string{"score", "updated_at"}),
+	}).Create(grade).Error
+}
+
+func (r *courseRepository) DeleteGrade(ctx context.Context, enrollmentID int, component string) error {
+	result := r.db.WithContext(ctx).Where("enrollment_id = ? AND component = ?", enrollmentID, component).Delete(&models.Grade{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrGradeNotFound
+	}
+	return nil
+}
+
+func (r *courseRepository) CreateSession(ctx context.Context, session *models.AttendanceSession) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(session).Error
+}
+
+func (r *courseRepository) FindSession(ctx context.Context, courseID int, termID *uint, number int) (*models.AttendanceSession, error) {
+	var session models.AttendanceSession
+	err := r.db.WithContext(ctx).Where("course_id = ? AND session_number = ?", courseID, number).Scopes(termScope(termID)).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *courseRepository) GetSessionByID(ctx context.Context, id int) (*models.AttendanceSession, error) {
+	var session models.AttendanceSession
+	if err := r.db.WithContext(ctx).First(&session, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *courseRepository) ListSessions(ctx context.Context, courseID int, termID uint) ([]models.AttendanceSession, error) {
+	query := r.db.WithContext(ctx).Where("course_id = ?", courseID).Order("term_id, session_number")
+	if termID != 0 {
+		query = query.Where("term_id = ?", termID)
+	}
+	var sessions []models.AttendanceSession
+	err := query.Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *courseRepository) EnrolledStudentIDs(ctx context.Context, courseID int, termID *uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.Enrollment{}).
+		Where("course_id = ?", courseID).Scopes(termScope(termID)).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+func (r *courseRepository) SaveAttendance(ctx context.Context, records []models.AttendanceRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "updated_at"}),
+	}).Create(&records).Error
+}
+
+func (r *courseRepository) ListAttendance(ctx context.Context, sessionID int) ([]models.AttendanceRecord, error) {
+	var records []models.AttendanceRecord
+	err := r.db.WithContext(ctx).Where("session_id = ?", sessionID).Order("user_id").Find(&records).Error
+	return records, err
+}
+
+func (r *courseRepository) AttendanceCounts(ctx context.Context, userID uint, termID *uint) ([]AttendanceCount, error) {
+	query := r.db.WithContext(ctx).Table("attendance_records r").
+		Select("s.course_id, c.code, c.name, r.status, COUNT(*) AS count").
+		Joins("JOIN attendance_sessions s ON s.id = r.session_id").
+		Joins("JOIN courses c ON c.id = s.course_id").
+		Where("r.user_id = ?", userID)
+	if termID == nil {
+		query = query.Where("s.term_id IS NULL")
+	} else {
+		query = query.Where("s.term_id = ?", *termID)
+	}
+
+	var counts []AttendanceCount
+	err := query.Group("s.course_id, c.code, c.name, r.status").Order("c.code, r.status").Scan(&counts).Error
+	return counts, err
+}
+
+func (r *courseRepository) GetAcademic(ctx context.Context, userID uint, termID *uint) (*models.Academic, error) {
+	var academic models.Academic
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Scopes(termScope(termID)).Order("id DESC").First(&academic).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &academic, nil
+}
+
+func (r *courseRepository) SaveAcademic(ctx context.Context, academic *models.Academic) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(academic).Error
+}
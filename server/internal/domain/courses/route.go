@@ -0,0 +1,30 @@
+package courses
+
+import (
+	"tsukamoto/config"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+func CourseRoute(r *mux.Router, db *gorm.DB, bus *events.Bus, cfg *config.Config, log *audit.Log) {
+	repo := NewCourseRepository(db)
+	handler := NewCourseHandler(repo, NewAggregator(repo, bus, AttendanceWeightsFromConfig(cfg)), log)
+
+	r.HandleFunc("/courses", handler.CreateCourse).Methods("POST")
+	r.HandleFunc("/courses", handler.ListCourses).Methods("GET")
+	r.HandleFunc("/courses/{id}", handler.GetCourse).Methods("GET")
+	r.HandleFunc("/courses/{id}", handler.UpdateCourse).Methods("PUT")
+	r.HandleFunc("/courses/{id}/enrollments", handler.Enroll).Methods("POST")
+	r.HandleFunc("/courses/{id}/enrollments", handler.ListEnrollments).Methods("GET")
+	r.HandleFunc("/enrollments/{id}/grades/{component}", handler.RecordGrade).Methods("PUT")
+	r.HandleFunc("/enrollments/{id}/grades/{component}", handler.DeleteGrade).Methods("DELETE")
+	r.HandleFunc("/academic/student/{student_id}/recompute", handler.Recompute).Methods("POST")
+	r.HandleFunc("/courses/{id}/sessions", handler.CreateSession).Methods("POST")
+	r.HandleFunc("/courses/{id}/sessions", handler.ListSessions).Methods("GET")
+	r.HandleFunc("/sessions/{id}/attendance", handler.UploadAttendance).Methods("POST")
+	r.HandleFunc("/sessions/{id}/attendance", handler.ListAttendance).Methods("GET")
+	r.HandleFunc("/academic/student/{student_id}/attendance", handler.AttendanceBreakdown).Methods("GET")
+}
@@ -2,6 +2,7 @@ package datasets
 
 import (
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/normalisasi"
 )
 
 type AcademicDTO struct {
@@ -27,3 +28,61 @@ func (dto *AcademicDTO) ToModel(userID uint) models.Academic {
 		MidtermExamScore:  dto.MidtermExamScore,
 	}
 }
+
+// Input returns the fuzzy inputs of the row as reported in the CSV
+func (dto *AcademicDTO) Input() normalisasi.Input {
+	return normalisasi.Input{
+		GPA:               float64(dto.GPA),
+		CoreCourseAverage: float64(dto.CoreCourseAverage),
+		AttendanceRate:    float64(dto.AttendanceRate),
+		MidtermExamScore:  float64(dto.MidtermExamScore),
+		FinalExamScore:    float64(dto.FinalExamScore),
+	}
+}
+
+// Kebijakan saat sebagian baris tidak valid
+const (
+	// OnErrorAbort membatalkan seluruh impor jika ada satu baris tidak valid
+	OnErrorAbort = "abort"
+	// OnErrorSkip mengimpor baris yang valid dan melewati sisanya
+	OnErrorSkip = "skip"
+)
+
+// RowError is one problem found in a CSV row. Column is the CSV header of the
+// offending value and is empty for problems with the whole row.
+type RowError struct {
+	Row    int    `json:"row"`
+	Column string `json:"column,omitempty"`
+	Field  string `json:"field,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Error  string `json:"error"`
+}
+
+// ImportResult counts what an import writes, or would write on a dry run
+type ImportResult struct {
+	NewStudents      int `json:"new_students"`
+	ExistingStudents int `json:"existing_students"`
+	Created          int `json:"created"`
+	Updated          int `json:"updated"`
+}
+
+// ImportSummary counts the rows of the file and what happened to them
+type ImportSummary struct {
+	Rows    int `json:"rows"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+	Skipped int `json:"skipped"`
+	ImportResult
+}
+
+// ImportReport is the response of an import. Committed is false for a dry run
+// and for an import aborted by invalid rows.
+type ImportReport struct {
+	DryRun    bool          `json:"dry_run"`
+	OnError   string        `json:"on_error"`
+	Committed bool          `json:"committed"`
+	Summary   ImportSummary `json:"summary"`
+	Errors    []RowError    `json:"errors"`
+	// ErrorsTruncated is set when more errors were found than the report lists
+	ErrorsTruncated bool `json:"errors_truncated,omitempty"`
+}
@@ -3,88 +3,240 @@ package datasets
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
-	"path/filepath"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
 	"tsukamoto/internal/models"
-
-	"github.com/gocarina/gocsv"
+	"tsukamoto/internal/tenant"
+	"tsukamoto/internal/utils"
 )
 
 // academicHandler implements AcademicHandler interface
 type academicHandler struct {
-	repo AcademicRepository
+	repo    AcademicRepository
+	bus     *events.Bus
+	audit   *audit.Log
+	options ImportOptions
 }
 
 // NewAcademicHandler creates a new instance of academicHandler
-func NewAcademicHandler(repo AcademicRepository) AcademicHandler {
-	return &academicHandler{repo: repo}
+func NewAcademicHandler(repo AcademicRepository, bus *events.Bus, log *audit.Log, options ImportOptions) AcademicHandler {
+	return &academicHandler{repo: repo, bus: bus, audit: log, options: options}
 }
 
-// ImportCSV handles CSV file import from a JSON request body with file path
+// maxReportErrors caps the row errors listed in an import report
+const maxReportErrors = 1000
+
+// ImportCSV handles POST /datasets/import. The CSV is uploaded as the file
+// field of a multipart form or as a text/csv body; the import parameters are
+// query parameters or form fields. A JSON body with file_path imports a file
+// from the server's import directory instead.
+//
+// Every row is validated before anything is written. With dry_run the response
+// only reports the row errors and what would be imported. Otherwise on_error
+// decides what invalid rows do: abort rejects the whole file and skip imports
+// the valid rows alone, in one transaction either way.
 func (h *academicHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
-	// Parse JSON request body
-	var requestBody struct {
-		FilePath string `json:"file_path"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		http.Error(w, "Gagal parsing JSON body: "+err.Error(), http.StatusBadRequest)
+	source, err := h.options.openSource(w, r)
+	if err != nil {
+		writeSourceError(w, err)
 		return
 	}
+	defer source.Close()
 
-	// Validate file path
-	if requestBody.FilePath == "" {
-		http.Error(w, "File path diperlukan", http.StatusBadRequest)
+	scope := tenant.FromContext(r.Context())
+	if source.UniversityID != 0 && !scope.Allows(source.UniversityID) {
+		utils.WriteResponse(w, http.StatusForbidden, []utils.ErrorDetail{
+			{Field: "university_id", Message: "University is outside your scope"},
+		}, nil)
 		return
 	}
-	if !filepath.IsAbs(requestBody.FilePath) || filepath.Ext(requestBody.FilePath) != ".csv" {
-		http.Error(w, "File path tidak valid: harus absolut dan berakhir dengan .csv", http.StatusBadRequest)
-		return
+
+	// Universitas menentukan skala penilaian setiap baris
+	checker := &rowChecker{
+		repo:         h.repo,
+		scope:        scope,
+		universityID: source.UniversityID,
+		universities: map[uint]*models.University{},
+		seen:         map[uint]int{},
+	}
+	if source.UniversityID != 0 {
+		university, err := h.repo.GetUniversityByID(r.Context(), source.UniversityID)
+		if err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+				{Field: "university_id", Message: "University not found"},
+			}, nil)
+			return
+		}
+		checker.universities[source.UniversityID] = university
 	}
 
-	// Open CSV file
-	file, err := os.Open(requestBody.FilePath)
-	if err != nil {
-		http.Error(w, "Gagal membuka file CSV: "+err.Error(), http.StatusBadRequest)
-		return
+	if source.TermID != 0 {
+		if _, err := h.repo.GetTermByID(r.Context(), source.TermID); err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{
+				{Field: "term_id", Message: "Term not found"},
+			}, nil)
+			return
+		}
+		checker.termID = &source.TermID
 	}
-	defer file.Close()
 
-	// Read CSV file
-	reader := csv.NewReader(file)
-	reader.FieldsPerRecord = -1 // Allow variable number of fields
-	var dtos []AcademicDTO
-	if err := gocsv.UnmarshalCSV(reader, &dtos); err != nil {
-		http.Error(w, "Gagal parsing file CSV: "+err.Error(), http.StatusBadRequest)
+	// Baris dibaca satu per satu dari stream, file tidak pernah dimuat utuh
+	parser, err := newRowParser(source.Reader)
+	if err != nil {
+		writeSourceError(w, err)
 		return
 	}
 
-	// Validate and convert DTOs to models
+	report := &ImportReport{DryRun: source.DryRun, OnError: source.OnError, Errors: []RowError{}}
 	var academics []models.Academic
-	for _, dto := range dtos {
-		// Validate DTO fields
-		if dto.StudentID == 0 || dto.GPA <= 0 || dto.AttendanceRate < 0 || dto.AttendanceRate > 1 {
-			http.Error(w, fmt.Sprintf("Data tidak valid untuk StudentID %d", dto.StudentID), http.StatusBadRequest)
+	for {
+		row, err := parser.Next()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// Baris setelah CSV yang rusak tidak dapat dibaca dengan benar, jadi tidak ada yang diimpor
+			report.Summary.Rows++
+			report.Summary.Invalid++
+			report.addErrors(RowError{Row: parseErr.StartLine, Error: "CSV tidak valid: " + parseErr.Err.Error()})
+			writeReport(w, http.StatusBadRequest, report)
+			return
+		}
+		if err != nil {
+			writeSourceError(w, err)
 			return
 		}
 
-		academics = append(academics, dto.ToModel(dto.StudentID)) // Gunakan uint, bukan float32
+		report.Summary.Rows++
+		academic, rowErrors := checker.check(r.Context(), row)
+		if len(rowErrors) > 0 {
+			report.Summary.Invalid++
+			report.addErrors(rowErrors...)
+			continue
+		}
+		report.Summary.Valid++
+		academics = append(academics, academic)
+	}
+
+	if report.Summary.Invalid > 0 {
+		if source.OnError == OnErrorSkip {
+			report.Summary.Skipped = report.Summary.Invalid
+		} else if !source.DryRun {
+			writeReport(w, http.StatusBadRequest, report)
+			return
+		}
+	}
+
+	if source.DryRun {
+		result, err := h.repo.PlanImport(r.Context(), academics)
+		if err != nil {
+			writeImportError(w, err)
+			return
+		}
+		report.Summary.ImportResult = result
+		writeReport(w, http.StatusOK, report)
+		return
+	}
+	if len(academics) == 0 {
+		writeReport(w, http.StatusOK, report)
+		return
+	}
+
+	// ImportCSV mengisi UserID hasil pemetaan Student ID ke user
+	userIDsOf := func() []uint {
+		userIDs := make([]uint, 0, len(academics))
+		for _, academic := range academics {
+			userIDs = append(userIDs, academic.UserID)
+		}
+		return userIDs
+	}
+	record := func(result ImportResult) audit.Recorder {
+		return h.audit.Recorder(r, func() audit.Entry {
+			return audit.Entry{
+				Action:     models.AuditImport,
+				EntityType: models.AuditEntityDataset,
+				After: map[string]interface{}{
+					"source":        source.Mode,
+					"file_name":     source.Name,
+					"university_id": source.UniversityID,
+					"term_id":       source.TermID,
+					"on_error":      source.OnError,
+					"count":         len(academics),
+					"skipped":       report.Summary.Skipped,
+					"created":       result.Created,
+					"updated":       result.Updated,
+					"user_ids":      userIDsOf(),
+				},
+			}
+		})
 	}
 
 	// Import ke database
-	if err := h.repo.ImportCSV(r.Context(), academics); err != nil {
-		http.Error(w, "Gagal mengimpor data: "+err.Error(), http.StatusInternalServerError)
+	result, err := h.repo.ImportCSV(r.Context(), academics, record)
+	if err != nil {
+		writeImportError(w, err)
 		return
 	}
+	report.Committed = true
+	report.Summary.ImportResult = result
 
-	// Kirim respons JSON
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "CSV berhasil diimpor",
-		"count":   len(academics),
+	userIDs := userIDsOf()
+	h.bus.Publish(r.Context(), events.AcademicChanged, events.AcademicChangedPayload{UserIDs: userIDs})
+	h.bus.Publish(r.Context(), events.DatasetImported, events.DatasetImportedPayload{
+		Count:        len(academics),
+		UniversityID: source.UniversityID,
+		UserIDs:      userIDs,
 	})
+
+	writeReport(w, http.StatusOK, report)
+}
+
+// addErrors lists row errors in the report up to maxReportErrors
+func (report *ImportReport) addErrors(rowErrors ...RowError) {
+	for _, rowError := range rowErrors {
+		if len(report.Errors) >= maxReportErrors {
+			report.ErrorsTruncated = true
+			return
+		}
+		report.Errors = append(report.Errors, rowError)
+	}
+}
+
+// writeReport sends the report as data; a rejected import also lists the row
+// errors in the response's errors
+func writeReport(w http.ResponseWriter, status int, report *ImportReport) {
+	var details []utils.ErrorDetail
+	if status >= http.StatusBadRequest {
+		details = make([]utils.ErrorDetail, 0, len(report.Errors))
+		for _, rowError := range report.Errors {
+			details = append(details, rowError.Detail())
+		}
+	}
+	utils.WriteResponse(w, status, details, report)
+}
+
+func writeImportError(w http.ResponseWriter, err error) {
+	if errors.Is(err, tenant.ErrOutsideScope) {
+		utils.WriteResponse(w, http.StatusForbidden, []utils.ErrorDetail{
+			{Field: "university_id", Message: "University is outside your scope"},
+		}, nil)
+		return
+	}
+	var conflict *StudentConflictError
+	if errors.As(err, &conflict) {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{
+			{Field: "student_id", Message: fmt.Sprintf("Student ID %d terdaftar di universitas lain", conflict.StudentID)},
+		}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{
+		{Message: "Gagal mengimpor data: " + err.Error()},
+	}, nil)
 }
 
 // GetAll handles retrieving all academic records
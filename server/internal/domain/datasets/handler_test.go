@@ -2,11 +2,21 @@ package datasets
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/middleware"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
 
 	"github.com/golang/mock/gomock"
 )
@@ -21,7 +31,7 @@ func TestAcademicHandler_ImportCSV_BadRequest(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAcademicRepository(ctrl)
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
 
 	req := httptest.NewRequest("POST", importPath, bytes.NewReader([]byte("invalid")))
 	w := httptest.NewRecorder()
@@ -32,12 +42,210 @@ func TestAcademicHandler_ImportCSV_BadRequest(t *testing.T) {
 	}
 }
 
+const csvHeader = "Student ID,University ID,GPA,Core Course Average,Attendance Rate,Final Exam Scores,Midterm Exam Scores,Project/Assignment Scores\n"
+
+// writeCSV writes the rows below the standard header into a file in dir
+func writeCSV(t *testing.T, dir, rows string) string {
+	path := filepath.Join(dir, "academics.csv")
+	if err := os.WriteFile(path, []byte(csvHeader+rows), 0o600); err != nil {
+		t.Fatalf("failed to write csv: %v", err)
+	}
+	return path
+}
+
+// asSuperAdmin sends the request as a super admin, who reaches every university
+func asSuperAdmin(req *http.Request) *http.Request {
+	return req.WithContext(middleware.WithUser(req.Context(), middleware.AuthUser{ID: 1, Role: models.RoleSuperAdmin}))
+}
+
+// csvRequest sends the rows below the standard header as a text/csv body
+func csvRequest(rows, query string) *http.Request {
+	req := httptest.NewRequest("POST", importPath+query, strings.NewReader(csvHeader+rows))
+	req.Header.Set("Content-Type", "text/csv")
+	return asSuperAdmin(req)
+}
+
+// pathRequest asks for a server-side import as a super admin
+func pathRequest(body map[string]interface{}) *http.Request {
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", importPath, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	return asSuperAdmin(req)
+}
+
+func TestAcademicHandler_ImportCSV_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+
+	mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(1)).Return(&models.University{ID: 1}, nil)
+
+	w := httptest.NewRecorder()
+
+	handler.ImportCSV(w, csvRequest("1,1,3.2,75,0.9,80,78,85\n0,1,3.0,70,0.8,70,70,70\n3,1,4.6,70,1.4,70,70,70\n", ""))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var resp utils.Response
+	json.NewDecoder(w.Body).Decode(&resp)
+	fields := map[string]int{}
+	for _, e := range resp.Errors {
+		fields[e.Field]++
+	}
+	if fields["student_id"] != 1 || fields["gpa"] != 1 || fields["attendance_rate"] != 1 {
+		t.Errorf("expected student_id, gpa and attendance_rate errors, got %v", resp.Errors)
+	}
+}
+
+func TestAcademicHandler_ImportCSV_NormalizesPercentScale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+
+	mockRepo.EXPECT().
+		GetUniversityByID(gomock.Any(), uint(7)).
+		Return(&models.University{ID: 7, GPAScale: 4, AttendanceScale: 100, ScoreScale: 100}, nil)
+	mockRepo.EXPECT().
+		ImportCSV(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, academics []models.Academic, _ ImportRecorder) (ImportResult, error) {
+			if len(academics) != 1 || academics[0].AttendanceRate != 0.85 {
+				t.Errorf("expected attendance 85%% to be stored as 0.85, got %v", academics)
+			}
+			return ImportResult{Created: len(academics)}, nil
+		})
+
+	w := httptest.NewRecorder()
+
+	handler.ImportCSV(w, csvRequest("1,7,3.2,75,85,80,78,85\n", "?university_id=7"))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestAcademicHandler_ImportCSV_AssignsTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	store := &audit.MemoryStore{}
+	handler := NewAcademicHandler(mockRepo, nil, audit.NewLog(store), DefaultImportOptions())
+
+	mockRepo.EXPECT().GetTermByID(gomock.Any(), uint(2)).Return(&models.Term{ID: 2}, nil)
+	mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(7)).Return(&models.University{ID: 7}, nil)
+	mockRepo.EXPECT().
+		ImportCSV(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, academics []models.Academic, record ImportRecorder) (ImportResult, error) {
+			for _, academic := range academics {
+				if academic.TermID == nil || *academic.TermID != 2 {
+					t.Errorf("expected every record in term 2, got %v", academic.TermID)
+				}
+				if academic.UniversityID != 7 {
+					t.Errorf("expected every record in university 7, got %d", academic.UniversityID)
+				}
+			}
+			result := ImportResult{Created: len(academics)}
+			return result, record(result).Run(nil)
+		})
+
+	w := httptest.NewRecorder()
+
+	handler.ImportCSV(w, csvRequest("1,7,3.2,75,0.85,80,78,85\n2,7,2.8,70,0.9,70,72,75\n", "?term_id=2"))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if len(store.Entries) != 1 || store.Entries[0].Action != models.AuditImport {
+		t.Fatalf("expected the import to be audited, got %+v", store.Entries)
+	}
+	var changes map[string]audit.Change
+	store.Entries[0].Changes.Decode(&changes)
+	if changes["count"].To != 2.0 || changes["created"].To != 2.0 || changes["term_id"].To != 2.0 || changes["source"].To != SourceBody {
+		t.Errorf("unexpected audit changes %+v", changes)
+	}
+}
+
+func TestAcademicHandler_ImportCSV_UniversityErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+
+	mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(9)).Return(&models.University{}, errors.New("record not found"))
+
+	// Baris tanpa University ID dan baris dengan universitas yang tidak ada
+	w := httptest.NewRecorder()
+
+	handler.ImportCSV(w, csvRequest("1,0,3.2,75,0.85,80,78,85\n2,9,3.0,70,0.8,70,70,70\n3,9,3.1,70,0.8,70,70,70\n", ""))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var resp utils.Response
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Errors) != 3 {
+		t.Fatalf("expected 3 university errors, got %v", resp.Errors)
+	}
+	for _, e := range resp.Errors {
+		if e.Field != "university_id" {
+			t.Errorf("expected university_id errors, got %v", resp.Errors)
+		}
+	}
+}
+
+func TestAcademicHandler_ImportCSV_TermNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+
+	mockRepo.EXPECT().GetTermByID(gomock.Any(), uint(5)).Return(nil, errors.New("record not found"))
+
+	w := httptest.NewRecorder()
+
+	handler.ImportCSV(w, csvRequest("1,7,3.2,75,0.85,80,78,85\n", "?term_id=5"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAcademicHandler_ImportCSV_StudentOfOtherUniversity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+
+	mockRepo.EXPECT().
+		GetUniversityByID(gomock.Any(), uint(7)).
+		Return(&models.University{ID: 7, GPAScale: 4, AttendanceScale: 1, ScoreScale: 100}, nil)
+	mockRepo.EXPECT().
+		ImportCSV(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(ImportResult{}, &StudentConflictError{StudentID: 1})
+
+	w := httptest.NewRecorder()
+
+	handler.ImportCSV(w, csvRequest("1,7,3.2,75,0.85,80,78,85\n", ""))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Student ID 1 terdaftar di universitas lain") {
+		t.Errorf("expected the conflicting Student ID in the response, got %s", w.Body.String())
+	}
+}
+
 func TestAcademicHandler_GetAll_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAcademicRepository(ctrl)
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
 
 	mockRepo.EXPECT().
 		GetAll(gomock.Any()).
@@ -57,7 +265,7 @@ func TestAcademicHandler_GetAll_Error(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockAcademicRepository(ctrl)
-	handler := NewAcademicHandler(mockRepo)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
 
 	mockRepo.EXPECT().
 		GetAll(gomock.Any()).
@@ -71,3 +279,327 @@ func TestAcademicHandler_GetAll_Error(t *testing.T) {
 		t.Errorf("expected 500, got %d", w.Code)
 	}
 }
+
+func TestAcademicHandler_ImportCSV_MultipartUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+
+	mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(7)).Return(&models.University{ID: 7}, nil)
+	mockRepo.EXPECT().ImportCSV(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, academics []models.Academic, _ ImportRecorder) (ImportResult, error) {
+		if len(academics) != 2 {
+			t.Errorf("expected 2 records, got %d", len(academics))
+		}
+		return ImportResult{Created: len(academics)}, nil
+	})
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("university_id", "7")
+	part, _ := form.CreateFormFile("file", "academics.csv")
+	// BOM dari spreadsheet tidak boleh merusak header pertama
+	part.Write([]byte("\xEF\xBB\xBF" + csvHeader + "1,7,3.2,75,0.85,80,78,85\n2,,2.8,70,0.9,70,72,75\n"))
+	form.Close()
+
+	req := httptest.NewRequest("POST", importPath, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	handler.ImportCSV(w, asSuperAdmin(req))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAcademicHandler_ImportCSV_RejectedUploads(t *testing.T) {
+	multipartBody := func(field, name, content string) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile(field, name)
+		part.Write([]byte(content))
+		form.Close()
+		return &body, form.FormDataContentType()
+	}
+
+	tests := []struct {
+		name        string
+		body        func() (io.Reader, string)
+		maxSize     int64
+		expectedErr int
+	}{
+		{"unsupported content type", func() (io.Reader, string) {
+			return strings.NewReader("<xml/>"), "application/xml"
+		}, 0, http.StatusUnsupportedMediaType},
+		{"binary content", func() (io.Reader, string) {
+			return bytes.NewReader([]byte("PK\x03\x04\x14\x00\x06\x00")), "text/csv"
+		}, 0, http.StatusUnsupportedMediaType},
+		{"empty body", func() (io.Reader, string) {
+			return strings.NewReader(""), "text/csv"
+		}, 0, http.StatusBadRequest},
+		{"body over the limit", func() (io.Reader, string) {
+			return strings.NewReader(csvHeader + strings.Repeat("1,,3.2,75,0.85,80,78,85\n", 100)), "text/csv"
+		}, 1024, http.StatusRequestEntityTooLarge},
+		{"missing file field", func() (io.Reader, string) {
+			return multipartBody("attachment", "academics.csv", csvHeader)
+		}, 0, http.StatusBadRequest},
+		{"wrong extension", func() (io.Reader, string) {
+			return multipartBody("file", "academics.xlsx", csvHeader)
+		}, 0, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			options := DefaultImportOptions()
+			if tt.maxSize != 0 {
+				options.MaxSize = tt.maxSize
+			}
+			handler := NewAcademicHandler(NewMockAcademicRepository(ctrl), nil, nil, options)
+
+			body, contentType := tt.body()
+			req := httptest.NewRequest("POST", importPath, body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			handler.ImportCSV(w, req)
+			if w.Code != tt.expectedErr {
+				t.Errorf("expected %d, got %d: %s", tt.expectedErr, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAcademicHandler_ImportCSV_ServerPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	dir := t.TempDir()
+	options := DefaultImportOptions()
+	options.Dir = dir
+	handler := NewAcademicHandler(mockRepo, nil, nil, options)
+
+	mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(7)).Return(&models.University{ID: 7}, nil).Times(2)
+	mockRepo.EXPECT().ImportCSV(gomock.Any(), gomock.Any(), gomock.Any()).Return(ImportResult{Created: 1}, nil).Times(2)
+
+	path := writeCSV(t, dir, "1,7,3.2,75,0.85,80,78,85\n")
+	for _, filePath := range []string{path, "academics.csv"} {
+		w := httptest.NewRecorder()
+		handler.ImportCSV(w, pathRequest(map[string]interface{}{"file_path": filePath}))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d: %s", filePath, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAcademicHandler_ImportCSV_ServerPathDenied(t *testing.T) {
+	dir := t.TempDir()
+	outside := writeCSV(t, t.TempDir(), "1,7,3.2,75,0.85,80,78,85\n")
+	writeCSV(t, dir, "1,7,3.2,75,0.85,80,78,85\n")
+	// Symlink di dalam direktori impor yang menunjuk ke luar
+	link := filepath.Join(dir, "link.csv")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Fatal(err)
+	}
+
+	tenantAdmin := func(req *http.Request) *http.Request {
+		return req.WithContext(middleware.WithUser(context.Background(), middleware.AuthUser{ID: 2, Role: models.RoleAdmin, UniversityID: 1}))
+	}
+
+	tests := []struct {
+		name     string
+		dir      string
+		req      *http.Request
+		expected int
+	}{
+		{"path imports disabled", "", pathRequest(map[string]interface{}{"file_path": "academics.csv"}), http.StatusForbidden},
+		{"university admin", dir, tenantAdmin(pathRequest(map[string]interface{}{"file_path": "academics.csv"})), http.StatusForbidden},
+		{"absolute path outside", dir, pathRequest(map[string]interface{}{"file_path": outside}), http.StatusForbidden},
+		{"relative path outside", dir, pathRequest(map[string]interface{}{"file_path": "../" + filepath.Base(filepath.Dir(outside)) + "/academics.csv"}), http.StatusForbidden},
+		{"symlink outside", dir, pathRequest(map[string]interface{}{"file_path": "link.csv"}), http.StatusForbidden},
+		{"not a csv", dir, pathRequest(map[string]interface{}{"file_path": "/etc/passwd"}), http.StatusBadRequest},
+		{"missing file", dir, pathRequest(map[string]interface{}{"file_path": "missing.csv"}), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			options := DefaultImportOptions()
+			options.Dir = tt.dir
+			handler := NewAcademicHandler(NewMockAcademicRepository(ctrl), nil, nil, options)
+
+			w := httptest.NewRecorder()
+			handler.ImportCSV(w, tt.req)
+			if w.Code != tt.expected {
+				t.Errorf("expected %d, got %d: %s", tt.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// decodeReport reads the import report from a response
+func decodeReport(t *testing.T, w *httptest.ResponseRecorder) ImportReport {
+	var resp struct {
+		Data ImportReport `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	return resp.Data
+}
+
+func TestAcademicHandler_ImportCSV_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockAcademicRepository(ctrl)
+	store := &audit.MemoryStore{}
+	handler := NewAcademicHandler(mockRepo, nil, audit.NewLog(store), DefaultImportOptions())
+
+	mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(7)).Return(&models.University{ID: 7}, nil)
+	mockRepo.EXPECT().
+		PlanImport(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, academics []models.Academic) (ImportResult, error) {
+			if len(academics) != 2 {
+				t.Errorf("expected the 2 valid rows to be planned, got %d", len(academics))
+			}
+			return ImportResult{NewStudents: 1, ExistingStudents: 1, Created: 1, Updated: 1}, nil
+		})
+
+	// Dry run tidak pernah menulis, walaupun ada baris yang tidak valid
+	w := httptest.NewRecorder()
+	handler.ImportCSV(w, csvRequest("1,7,3.2,75,0.85,80,78,85\n2,7,abc,70,0.9,70,72,75\n3,7,2.8,70,0.9,70,72,75\n1,7,3.0,70,0.8,70,70,70\n", "?dry_run=true"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	report := decodeReport(t, w)
+	if !report.DryRun || report.Committed || report.OnError != OnErrorAbort {
+		t.Errorf("unexpected report flags %+v", report)
+	}
+	summary := report.Summary
+	if summary.Rows != 4 || summary.Valid != 2 || summary.Invalid != 2 || summary.Skipped != 0 {
+		t.Errorf("unexpected row counts %+v", summary)
+	}
+	if summary.NewStudents != 1 || summary.Created != 1 || summary.Updated != 1 {
+		t.Errorf("expected the planned changes in the summary, got %+v", summary)
+	}
+
+	expected := []RowError{
+		{Row: 3, Column: "GPA", Field: "gpa", Value: "abc", Error: "Nilai harus berupa angka"},
+		{Row: 5, Column: "Student ID", Field: "student_id", Value: "1", Error: "Student ID 1 sudah ada di baris 2"},
+	}
+	if len(report.Errors) != len(expected) {
+		t.Fatalf("expected %d row errors, got %+v", len(expected), report.Errors)
+	}
+	for i, e := range expected {
+		if report.Errors[i] != e {
+			t.Errorf("expected %+v, got %+v", e, report.Errors[i])
+		}
+	}
+	if len(store.Entries) != 0 {
+		t.Errorf("expected a dry run not to be audited, got %+v", store.Entries)
+	}
+}
+
+func TestAcademicHandler_ImportCSV_OnErrorPolicy(t *testing.T) {
+	rows := "1,7,3.2,75,0.85,80,78,85\n2,7,2.8,70,1.5,70,72,75\n3,7,2.8,70,0.9,70,72,75\n"
+
+	t.Run("abort writes nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockAcademicRepository(ctrl)
+		handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+		mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(7)).Return(&models.University{ID: 7}, nil)
+
+		w := httptest.NewRecorder()
+		handler.ImportCSV(w, csvRequest(rows, "?on_error=abort"))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+		report := decodeReport(t, w)
+		if report.Committed || len(report.Errors) != 1 || report.Errors[0].Row != 3 || report.Errors[0].Value != "1.5" {
+			t.Errorf("unexpected report %+v", report)
+		}
+	})
+
+	t.Run("skip imports the valid rows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockAcademicRepository(ctrl)
+		store := &audit.MemoryStore{}
+		handler := NewAcademicHandler(mockRepo, nil, audit.NewLog(store), DefaultImportOptions())
+		mockRepo.EXPECT().GetUniversityByID(gomock.Any(), uint(7)).Return(&models.University{ID: 7}, nil)
+		mockRepo.EXPECT().
+			ImportCSV(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, academics []models.Academic, record ImportRecorder) (ImportResult, error) {
+				if len(academics) != 2 || academics[0].UserID != 1 || academics[1].UserID != 3 {
+					t.Errorf("expected students 1 and 3 to be imported, got %v", academics)
+				}
+				result := ImportResult{NewStudents: 2, Created: 2}
+				return result, record(result).Run(nil)
+			})
+
+		w := httptest.NewRecorder()
+		handler.ImportCSV(w, csvRequest(rows, "?on_error=skip"))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		report := decodeReport(t, w)
+		if !report.Committed || report.Summary.Skipped != 1 || report.Summary.Created != 2 || len(report.Errors) != 1 {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if len(store.Entries) != 1 {
+			t.Errorf("expected the import to be audited, got %+v", store.Entries)
+		}
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := NewAcademicHandler(NewMockAcademicRepository(ctrl), nil, nil, DefaultImportOptions())
+		w := httptest.NewRecorder()
+		handler.ImportCSV(w, csvRequest(rows, "?on_error=ignore"))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestAcademicHandler_ImportCSV_MalformedFile(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing required column", "Student ID,University ID,GPA\n1,7,3.2\n"},
+		{"unterminated quote", csvHeader + "1,7,3.2,75,0.85,80,78,85\n2,7,\"2.8,70,0.9,70,72,75\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := NewMockAcademicRepository(ctrl)
+			mockRepo.EXPECT().GetUniversityByID(gomock.Any(), gomock.Any()).Return(&models.University{ID: 7}, nil).AnyTimes()
+			handler := NewAcademicHandler(mockRepo, nil, nil, DefaultImportOptions())
+
+			req := httptest.NewRequest("POST", importPath+"?on_error=skip", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "text/csv")
+			w := httptest.NewRecorder()
+			handler.ImportCSV(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -2,14 +2,37 @@ package datasets
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"tsukamoto/internal/audit"
 	"tsukamoto/internal/models"
 )
 
+// StudentConflictError is returned when a row's Student ID already belongs to
+// a student of another university
+type StudentConflictError struct {
+	StudentID uint
+}
+
+func (e *StudentConflictError) Error() string {
+	return fmt.Sprintf("student %d belongs to another university", e.StudentID)
+}
+
+// ImportRecorder builds the audit entry of an import from its result
+type ImportRecorder func(result ImportResult) audit.Recorder
+
 // AcademicRepository defines the interface for academic data operations
 type AcademicRepository interface {
-	ImportCSV(ctx context.Context, academics []models.Academic) error
+	// ImportCSV saves the records in one transaction, creating missing
+	// students and updating the record a student already has in the term.
+	// The audit entry from record, which may be nil, is written in the same
+	// transaction once the result is known.
+	ImportCSV(ctx context.Context, academics []models.Academic, record ImportRecorder) (ImportResult, error)
+	// PlanImport reports what ImportCSV would write without changing anything
+	PlanImport(ctx context.Context, academics []models.Academic) (ImportResult, error)
 	GetAll(ctx context.Context) ([]models.Academic, error)
+	GetUniversityByID(ctx context.Context, id uint) (*models.University, error)
+	GetTermByID(ctx context.Context, id uint) (*models.Term, error)
 }
 
 // AcademicHandler defines the interface for handling academic HTTP requests
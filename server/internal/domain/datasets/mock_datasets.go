@@ -51,18 +51,64 @@ func (mr *MockAcademicRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAcademicRepository)(nil).GetAll), ctx)
 }
 
+// GetTermByID mocks base method.
+func (m *MockAcademicRepository) GetTermByID(ctx context.Context, id uint) (*models.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTermByID", ctx, id)
+	ret0, _ := ret[0].(*models.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTermByID indicates an expected call of GetTermByID.
+func (mr *MockAcademicRepositoryMockRecorder) GetTermByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTermByID", reflect.TypeOf((*MockAcademicRepository)(nil).GetTermByID), ctx, id)
+}
+
+// GetUniversityByID mocks base method.
+func (m *MockAcademicRepository) GetUniversityByID(ctx context.Context, id uint) (*models.University, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUniversityByID", ctx, id)
+	ret0, _ := ret[0].(*models.University)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUniversityByID indicates an expected call of GetUniversityByID.
+func (mr *MockAcademicRepositoryMockRecorder) GetUniversityByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUniversityByID", reflect.TypeOf((*MockAcademicRepository)(nil).GetUniversityByID), ctx, id)
+}
+
 // ImportCSV mocks base method.
-func (m *MockAcademicRepository) ImportCSV(ctx context.Context, academics []models.Academic) error {
+func (m *MockAcademicRepository) ImportCSV(ctx context.Context, academics []models.Academic, record ImportRecorder) (ImportResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ImportCSV", ctx, academics)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ImportCSV", ctx, academics, record)
+	ret0, _ := ret[0].(ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ImportCSV indicates an expected call of ImportCSV.
-func (mr *MockAcademicRepositoryMockRecorder) ImportCSV(ctx, academics interface{}) *gomock.Call {
+func (mr *MockAcademicRepositoryMockRecorder) ImportCSV(ctx, academics, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCSV", reflect.TypeOf((*MockAcademicRepository)(nil).ImportCSV), ctx, academics, record)
+}
+
+// PlanImport mocks base method.
+func (m *MockAcademicRepository) PlanImport(ctx context.Context, academics []models.Academic) (ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanImport", ctx, academics)
+	ret0, _ := ret[0].(ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanImport indicates an expected call of PlanImport.
+func (mr *MockAcademicRepositoryMockRecorder) PlanImport(ctx, academics interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCSV", reflect.TypeOf((*MockAcademicRepository)(nil).ImportCSV), ctx, academics)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanImport", reflect.TypeOf((*MockAcademicRepository)(nil).PlanImport), ctx, academics)
 }
 
 // MockAcademicHandler is a mock of AcademicHandler interface.
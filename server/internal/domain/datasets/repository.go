@@ -2,13 +2,14 @@ package datasets
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
-	"time"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/tenant"
 	"tsukamoto/internal/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // academicRepository implements AcademicRepository interface
@@ -21,88 +22,185 @@ func NewAcademicRepository(db *gorm.DB) AcademicRepository {
 	return &academicRepository{db: db}
 }
 
-// ImportCSV saves academic records to the database, committing every 10 records
-func (r *academicRepository) ImportCSV(ctx context.Context, academics []models.Academic) error {
-	// Seed random number generator for university names
-	rand.Seed(time.Now().UnixNano())
-	universityNames := []string{"A", "B", "C"}
-
-	// Process records in batches of 10
-	batchSize := 10
-	for i := 0; i < len(academics); i += batchSize {
-		// Begin transaction for each batch
-		tx := r.db.WithContext(ctx).Begin()
-		if tx.Error != nil {
-			return tx.Error
-		}
-
-		// Determine the end index for the current batch
-		end := i + batchSize
-		if end > len(academics) {
-			end = len(academics)
-		}
-
-		// Process each academic record in the batch
-		for j := i; j < end; j++ {
-			academic := academics[j]
+// ImportCSV saves academic records in a single transaction, so a failed row
+// leaves the database untouched. A student's record in the same term is
+// updated; records without a term are added to the student's history.
+func (r *academicRepository) ImportCSV(ctx context.Context, academics []models.Academic, record ImportRecorder) (ImportResult, error) {
+	var result ImportResult
+	if err := checkScope(ctx, academics); err != nil {
+		return result, err
+	}
 
-			// Create or get user based on StudentID
-			username := fmt.Sprintf("student%d", academic.UserID)
-			plainPassword := username // Password sama dengan username
-			hashedPassword := utils.HashPassword(plainPassword)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result = ImportResult{}
+		for i := range academics {
+			academic := &academics[i]
 
-			user := models.User{
-				Username: username,
-				Name:     username,
-				Password: hashedPassword, // Gunakan password yang sudah di-hash
-				Role:     "student",
+			user, err := findStudent(tx, academic.UserID, academic.UniversityID)
+			if err != nil {
+				return err
 			}
-
-			// Check if user exists, if not create one
-			var existingUser models.User
-			if err := tx.Where("username = ?", username).First(&existingUser).Error; err == gorm.ErrRecordNotFound {
-				if err := tx.Create(&user).Error; err != nil {
-					tx.Rollback()
+			if user == nil {
+				// Create or get user based on StudentID
+				username := studentUsername(academic.UserID)
+				universityID := academic.UniversityID
+				user = &models.User{
+					UniversityID: &universityID,
+					Username:     username,
+					Name:         username,
+					Password:     utils.HashPassword(username), // Password sama dengan username
+					Role:         "student",
+					// Password awal sama dengan username, wajib diganti saat login pertama
+					MustChangePassword: true,
+				}
+				if err := tx.Create(user).Error; err != nil {
 					return err
 				}
-				academics[j].UserID = uint(user.ID)
-			} else if err != nil {
-				tx.Rollback()
-				return err
+				result.NewStudents++
 			} else {
-				academics[j].UserID = uint(existingUser.ID)
+				result.ExistingStudents++
 			}
+			academic.UserID = uint(user.ID)
 
-			// Create university with random name
-			university := models.University{
-				Name:    universityNames[rand.Intn(len(universityNames))],
-				Address: "", // Leave address empty
-			}
-			if err := tx.Create(&university).Error; err != nil {
-				tx.Rollback()
+			existing, err := findTermRecord(tx, academic.UserID, academic.TermID)
+			if err != nil {
 				return err
 			}
-			academics[j].UniversityID = uint(university.ID)
+			if existing == nil {
+				if err := tx.Create(academic).Error; err != nil {
+					return err
+				}
+				result.Created++
+				continue
+			}
 
-			// Insert academic record
-			if err := tx.Create(&academics[j]).Error; err != nil {
-				tx.Rollback()
+			// Nilai dari file menimpa record term tersebut dan dianggap diisi manual
+			provenance := models.StringMap{}
+			for field, source := range existing.Provenance {
+				provenance[field] = source
+			}
+			for _, field := range importedFields {
+				provenance[field] = models.ProvenanceManual
+			}
+			academic.ID = existing.ID
+			academic.CreatedAt = existing.CreatedAt
+			academic.Provenance = provenance
+			if err := tx.Omit(clause.Associations).Save(academic).Error; err != nil {
 				return err
 			}
+			result.Updated++
+		}
+		if record == nil {
+			return nil
+		}
+		return record(result).Run(tx)
+	})
+	return result, err
+}
+
+// PlanImport counts the students and records ImportCSV would create or update
+func (r *academicRepository) PlanImport(ctx context.Context, academics []models.Academic) (ImportResult, error) {
+	var result ImportResult
+	if err := checkScope(ctx, academics); err != nil {
+		return result, err
+	}
+
+	db := r.db.WithContext(ctx)
+	for _, academic := range academics {
+		user, err := findStudent(db, academic.UserID, academic.UniversityID)
+		if err != nil {
+			return result, err
+		}
+		if user == nil {
+			// Mahasiswa baru belum punya record apa pun
+			result.NewStudents++
+			result.Created++
+			continue
 		}
+		result.ExistingStudents++
 
-		// Commit transaction for the current batch
-		if err := tx.Commit().Error; err != nil {
-			return err
+		existing, err := findTermRecord(db, uint(user.ID), academic.TermID)
+		if err != nil {
+			return result, err
+		}
+		if existing == nil {
+			result.Created++
+		} else {
+			result.Updated++
 		}
 	}
+	return result, nil
+}
+
+// importedFields are the academic fields an import writes
+var importedFields = []string{"gpa", "core_course_average", "attendance_rate", "midterm_exam_score", "final_exam_score"}
 
+func checkScope(ctx context.Context, academics []models.Academic) error {
+	scope := tenant.FromContext(ctx)
+	for _, academic := range academics {
+		if !scope.Allows(academic.UniversityID) {
+			return tenant.ErrOutsideScope
+		}
+	}
 	return nil
 }
 
+func studentUsername(studentID uint) string {
+	return fmt.Sprintf("student%d", studentID)
+}
+
+// findStudent returns the user of a Student ID, or nil when there is none yet.
+// A user of another university is a StudentConflictError, so an import never
+// writes records for a student outside the row's university.
+func findStudent(db *gorm.DB, studentID, universityID uint) (*models.User, error) {
+	var user models.User
+	err := db.Where("username = ?", studentUsername(studentID)).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.UniversityID == nil || *user.UniversityID != universityID {
+		return nil, &StudentConflictError{StudentID: studentID}
+	}
+	return &user, nil
+}
+
+// findTermRecord returns the record a student already has in the term. Records
+// without a term are never replaced, so it returns nil for them.
+func findTermRecord(db *gorm.DB, userID uint, termID *uint) (*models.Academic, error) {
+	if termID == nil {
+		return nil, nil
+	}
+	var academic models.Academic
+	err := db.Where("user_id = ? AND term_id = ?", userID, *termID).First(&academic).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &academic, nil
+}
+
 // GetAll retrieves all academic records from the database
 func (r *academicRepository) GetAll(ctx context.Context) ([]models.Academic, error) {
 	var academics []models.Academic
-	err := r.db.WithContext(ctx).Find(&academics).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scoped(ctx, "university_id")).Find(&academics).Error
 	return academics, err
 }
+
+// GetUniversityByID retrieves the university whose grading scale applies to an import
+func (r *academicRepository) GetUniversityByID(ctx context.Context, id uint) (*models.University, error) {
+	var university models.University
+	err := r.db.WithContext(ctx).First(&university, id).Error
+	return &university, err
+}
+
+// GetTermByID retrieves the term the imported records belong to
+func (r *academicRepository) GetTermByID(ctx context.Context, id uint) (*models.Term, error) {
+	var term models.Term
+	err := r.db.WithContext(ctx).First(&term, id).Error
+	return &term, err
+}
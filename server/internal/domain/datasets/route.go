@@ -1,14 +1,18 @@
 package datasets
 
 import (
+	"tsukamoto/config"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
+
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
 )
 
 // DatasetsRoute mengatur rute untuk dataset akademik menggunakan Gorilla Mux
-func DatasetsRoute(r *mux.Router, db *gorm.DB) {
+func DatasetsRoute(r *mux.Router, db *gorm.DB, bus *events.Bus, cfg *config.Config, log *audit.Log) {
 	academicRepo := NewAcademicRepository(db)
-	academicHandler := NewAcademicHandler(academicRepo)
+	academicHandler := NewAcademicHandler(academicRepo, bus, log, ImportOptionsFromConfig(cfg))
 
 	// Rute untuk mengimpor CSV
 	r.HandleFunc("/datasets/import", academicHandler.ImportCSV).Methods("POST")
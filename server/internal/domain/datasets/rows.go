@@ -0,0 +1,247 @@
+package datasets
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/normalisasi"
+	"tsukamoto/internal/tenant"
+	"tsukamoto/internal/utils"
+)
+
+// csvColumn is a column of the dataset CSV and the JSON field it fills
+type csvColumn struct {
+	Header   string
+	Field    string
+	Required bool
+}
+
+// csvColumns lists the columns read from the CSV. University ID may be left
+// out when university_id is sent with the request; other columns are ignored.
+var csvColumns = []csvColumn{
+	{Header: "Student ID", Field: "student_id", Required: true},
+	{Header: "University ID", Field: "university_id"},
+	{Header: "GPA", Field: "gpa", Required: true},
+	{Header: "Core Course Average", Field: "core_course_average", Required: true},
+	{Header: "Attendance Rate", Field: "attendance_rate", Required: true},
+	{Header: "Final Exam Scores", Field: "final_exam_score", Required: true},
+	{Header: "Midterm Exam Scores", Field: "midterm_exam_score", Required: true},
+	{Header: "Project/Assignment Scores", Field: "project_assignment_score"},
+}
+
+// columnHeader returns the CSV header of a field, or "" for unknown fields
+func columnHeader(field string) string {
+	for _, column := range csvColumns {
+		if column.Field == field {
+			return column.Header
+		}
+	}
+	return ""
+}
+
+// newRowError reports a problem with one value of a row
+func newRowError(row int, field, value, message string) RowError {
+	return RowError{Row: row, Column: columnHeader(field), Field: field, Value: value, Error: message}
+}
+
+// Detail converts the row error to the error list of a failed response
+func (e RowError) Detail() utils.ErrorDetail {
+	message := fmt.Sprintf("Baris %d: %s", e.Row, e.Error)
+	if e.Column != "" {
+		message = fmt.Sprintf("Baris %d, kolom %s: %s", e.Row, e.Column, e.Error)
+	}
+	return utils.ErrorDetail{Field: e.Field, Message: message}
+}
+
+// csvRow is one data row of the CSV. Line is the line the row starts on, so
+// it matches the row number shown by spreadsheet programs.
+type csvRow struct {
+	Line   int
+	DTO    AcademicDTO
+	Values map[string]string
+	Errors []RowError
+}
+
+// rowParser reads the CSV row by row, matching columns by their header
+type rowParser struct {
+	reader *csv.Reader
+	index  map[string]int
+}
+
+// newRowParser reads the header and fails when a required column is missing
+func newRowParser(r io.Reader) (*rowParser, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Allow variable number of fields
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, badSource("file", "File CSV kosong")
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+
+	var missing []string
+	for _, column := range csvColumns {
+		if _, ok := index[column.Header]; column.Required && !ok {
+			missing = append(missing, column.Header)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, badSource("file", "Kolom wajib tidak ada: "+strings.Join(missing, ", "))
+	}
+	return &rowParser{reader: reader, index: index}, nil
+}
+
+// Next returns the next row with the values that could not be parsed as
+// errors. It returns io.EOF after the last row and a *csv.ParseError when the
+// file is malformed.
+func (p *rowParser) Next() (*csvRow, error) {
+	record, err := p.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	line, _ := p.reader.FieldPos(0)
+	row := &csvRow{Line: line, Values: make(map[string]string, len(csvColumns))}
+
+	for _, column := range csvColumns {
+		i, ok := p.index[column.Header]
+		if !ok {
+			continue
+		}
+		value := ""
+		if i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+		row.Values[column.Field] = value
+		if value == "" {
+			if column.Required {
+				row.Errors = append(row.Errors, newRowError(line, column.Field, value, "Nilai wajib diisi"))
+			}
+			continue
+		}
+		if err := row.set(column.Field, value); err != nil {
+			row.Errors = append(row.Errors, newRowError(line, column.Field, value, err.Error()))
+		}
+	}
+	return row, nil
+}
+
+func (row *csvRow) set(field, value string) error {
+	switch field {
+	case "student_id", "university_id":
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return errors.New("Nilai harus bilangan bulat positif")
+		}
+		if field == "student_id" {
+			row.DTO.StudentID = uint(id)
+		} else {
+			row.DTO.UniversityID = uint(id)
+		}
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return errors.New("Nilai harus berupa angka")
+	}
+	score := float32(number)
+	switch field {
+	case "gpa":
+		row.DTO.GPA = score
+	case "core_course_average":
+		row.DTO.CoreCourseAverage = score
+	case "attendance_rate":
+		row.DTO.AttendanceRate = score
+	case "final_exam_score":
+		row.DTO.FinalExamScore = score
+	case "midterm_exam_score":
+		row.DTO.MidtermExamScore = score
+	case "project_assignment_score":
+		row.DTO.ProjectAssignmentScore = score
+	}
+	return nil
+}
+
+// rowChecker validates parsed rows against the import parameters and the
+// rows before them
+type rowChecker struct {
+	repo         AcademicRepository
+	scope        tenant.Scope
+	universityID uint
+	termID       *uint
+	// Universitas yang tidak ditemukan disimpan sebagai nil agar hanya dicari sekali
+	universities map[uint]*models.University
+	// Baris pertama setiap Student ID, untuk mendeteksi duplikat
+	seen map[uint]int
+}
+
+// check returns the record a valid row imports, or the row's errors
+func (c *rowChecker) check(ctx context.Context, row *csvRow) (models.Academic, []RowError) {
+	if len(row.Errors) > 0 {
+		return models.Academic{}, row.Errors
+	}
+	dto := row.DTO
+	fail := func(field, message string) (models.Academic, []RowError) {
+		return models.Academic{}, []RowError{newRowError(row.Line, field, row.Values[field], message)}
+	}
+
+	if dto.StudentID == 0 {
+		return fail("student_id", "Student ID diperlukan")
+	}
+	if first, ok := c.seen[dto.StudentID]; ok {
+		return fail("student_id", fmt.Sprintf("Student ID %d sudah ada di baris %d", dto.StudentID, first))
+	}
+	c.seen[dto.StudentID] = row.Line
+
+	universityID := dto.UniversityID
+	if universityID == 0 {
+		universityID = c.universityID
+	}
+	if universityID == 0 {
+		return fail("university_id", "University ID diperlukan")
+	}
+	if c.universityID != 0 && universityID != c.universityID {
+		return fail("university_id", fmt.Sprintf("University ID %d tidak sesuai dengan university_id %d", universityID, c.universityID))
+	}
+	if !c.scope.Allows(universityID) {
+		return fail("university_id", fmt.Sprintf("University %d berada di luar cakupan Anda", universityID))
+	}
+	university, ok := c.universities[universityID]
+	if !ok {
+		found, err := c.repo.GetUniversityByID(ctx, universityID)
+		if err == nil {
+			university = found
+		}
+		c.universities[universityID] = university
+	}
+	if university == nil {
+		return fail("university_id", fmt.Sprintf("University %d tidak ditemukan", universityID))
+	}
+
+	input, errs := normalisasi.Normalize(dto.Input(), normalisasi.ScaleFor(university))
+	if len(errs) > 0 {
+		rowErrors := make([]RowError, 0, len(errs))
+		for _, e := range errs {
+			rowErrors = append(rowErrors, newRowError(row.Line, e.Field, row.Values[e.Field], e.Message))
+		}
+		return models.Academic{}, rowErrors
+	}
+
+	academic := dto.ToModel(dto.StudentID) // Gunakan uint, bukan float32
+	input.Apply(&academic)
+	academic.UniversityID = universityID
+	academic.TermID = c.termID
+	return academic, nil
+}
@@ -0,0 +1,369 @@
+package datasets
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"tsukamoto/config"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+)
+
+// Cara file CSV sampai ke server
+const (
+	SourceUpload = "upload"
+	SourceBody   = "body"
+	SourcePath   = "path"
+)
+
+// sniffSize is the number of bytes inspected to decide whether a file is text
+const sniffSize = 512
+
+// utf8BOM is written at the start of CSV files saved by spreadsheet programs
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ImportOptions limits what the import endpoint accepts
+type ImportOptions struct {
+	// MaxSize is the largest CSV accepted, in bytes
+	MaxSize int64
+	// Dir is the only server directory path imports may read from. Path
+	// imports are disabled when it is empty.
+	Dir string
+}
+
+// DefaultImportOptions accepts uploads up to 10 MB and disables path imports
+func DefaultImportOptions() ImportOptions {
+	return ImportOptions{MaxSize: 10 << 20}
+}
+
+// ImportOptionsFromConfig reads DATASET_MAX_UPLOAD_MB and DATASET_IMPORT_DIR
+func ImportOptionsFromConfig(cfg *config.Config) ImportOptions {
+	options := DefaultImportOptions()
+	if value, err := strconv.ParseInt(cfg.DatasetMaxUploadMB, 10, 64); err == nil && value > 0 {
+		options.MaxSize = value << 20
+	}
+	options.Dir = cfg.DatasetImportDir
+	return options
+}
+
+// importSource is an opened CSV together with the import parameters sent
+// alongside it
+type importSource struct {
+	Mode         string
+	Name         string
+	UniversityID uint
+	TermID       uint
+	// DryRun only validates the file and reports what would be imported
+	DryRun bool
+	// OnError is OnErrorAbort or OnErrorSkip
+	OnError string
+	Reader  io.Reader
+	closer  io.Closer
+}
+
+// Close releases the file opened by a path import
+func (s *importSource) Close() {
+	if s.closer != nil {
+		s.closer.Close()
+	}
+}
+
+// sourceError is a rejected import request and the response it gets
+type sourceError struct {
+	Status int
+	Field  string
+	Msg    string
+}
+
+func (e *sourceError) Error() string {
+	return e.Msg
+}
+
+func badSource(field, message string) *sourceError {
+	return &sourceError{Status: http.StatusBadRequest, Field: field, Msg: message}
+}
+
+// writeSourceError writes the response of an error returned while opening or
+// reading the CSV
+func writeSourceError(w http.ResponseWriter, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		err = tooLarge(maxErr.Limit)
+	}
+	var srcErr *sourceError
+	if !errors.As(err, &srcErr) {
+		srcErr = badSource("file", "Gagal membaca file CSV: "+err.Error())
+	}
+	utils.WriteResponse(w, srcErr.Status, []utils.ErrorDetail{{Field: srcErr.Field, Message: srcErr.Msg}}, nil)
+}
+
+func tooLarge(limit int64) *sourceError {
+	return &sourceError{
+		Status: http.StatusRequestEntityTooLarge,
+		Field:  "file",
+		Msg:    fmt.Sprintf("Ukuran file melebihi batas %d byte", limit),
+	}
+}
+
+// openSource reads the CSV from a multipart upload, a text/csv body or, for
+// super admins, a file inside the import directory named in a JSON body.
+// Uploads are read as a stream, so the file is never held in memory.
+func (o ImportOptions) openSource(w http.ResponseWriter, r *http.Request) (*importSource, error) {
+	mediaType := ""
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, &sourceError{Status: http.StatusUnsupportedMediaType, Msg: "Content-Type tidak valid"}
+		}
+		mediaType = parsed
+	}
+
+	var source *importSource
+	var err error
+	switch mediaType {
+	case "multipart/form-data":
+		source, err = o.openUpload(w, r)
+	case "text/csv":
+		source, err = o.openBody(w, r)
+	case "application/json", "":
+		source, err = o.openPath(r)
+	default:
+		return nil, &sourceError{
+			Status: http.StatusUnsupportedMediaType,
+			Msg:    "Kirim file sebagai multipart/form-data atau text/csv",
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := sniffCSV(source.Reader)
+	if err != nil {
+		source.Close()
+		return nil, err
+	}
+	source.Reader = reader
+	return source, nil
+}
+
+// openUpload streams the part named file. The import parameters may be sent as
+// query parameters or as form fields before the file.
+func (o ImportOptions) openUpload(w http.ResponseWriter, r *http.Request) (*importSource, error) {
+	source := &importSource{Mode: SourceUpload, OnError: OnErrorAbort}
+	if err := source.parseQuery(r); err != nil {
+		return nil, err
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, o.MaxSize)
+	parts, err := r.MultipartReader()
+	if err != nil {
+		return nil, badSource("file", "Body multipart tidak valid")
+	}
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			return nil, badSource("file", "File CSV diperlukan pada field file")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch part.FormName() {
+		case "file":
+			if ext := filepath.Ext(part.FileName()); ext != "" && !strings.EqualFold(ext, ".csv") {
+				return nil, &sourceError{Status: http.StatusUnsupportedMediaType, Field: "file", Msg: "File harus berekstensi .csv"}
+			}
+			source.Name = part.FileName()
+			source.Reader = part
+			return source, nil
+		case "university_id", "term_id", "dry_run", "on_error":
+			value, err := io.ReadAll(io.LimitReader(part, 32))
+			if err != nil {
+				return nil, err
+			}
+			if err := source.setParam(part.FormName(), strings.TrimSpace(string(value))); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// openBody streams a text/csv request body; parameters come from the query
+func (o ImportOptions) openBody(w http.ResponseWriter, r *http.Request) (*importSource, error) {
+	source := &importSource{Mode: SourceBody, OnError: OnErrorAbort}
+	if err := source.parseQuery(r); err != nil {
+		return nil, err
+	}
+	source.Reader = http.MaxBytesReader(w, r.Body, o.MaxSize)
+	return source, nil
+}
+
+// openPath opens a file inside the import directory. Only super admins may
+// read files from the server, and only when the directory is configured.
+func (o ImportOptions) openPath(r *http.Request) (*importSource, error) {
+	var requestBody struct {
+		FilePath     string `json:"file_path"`
+		UniversityID uint   `json:"university_id,omitempty"` // Universitas untuk baris tanpa University ID
+		TermID       uint   `json:"term_id,omitempty"`       // Term tempat semua nilai di file berlaku
+		DryRun       bool   `json:"dry_run,omitempty"`
+		OnError      string `json:"on_error,omitempty"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&requestBody); err != nil {
+		return nil, badSource("", "Gagal parsing JSON body: "+err.Error())
+	}
+	if requestBody.FilePath == "" {
+		return nil, badSource("file_path", "File path diperlukan")
+	}
+
+	if caller, ok := middleware.UserFromContext(r.Context()); !ok || caller.Role != models.RoleSuperAdmin {
+		return nil, &sourceError{Status: http.StatusForbidden, Field: "file_path", Msg: "Impor dari file server hanya untuk super admin, unggah file sebagai gantinya"}
+	}
+	if o.Dir == "" {
+		return nil, &sourceError{Status: http.StatusForbidden, Field: "file_path", Msg: "Impor dari file server tidak diaktifkan"}
+	}
+
+	source := &importSource{
+		Mode:         SourcePath,
+		Name:         requestBody.FilePath,
+		UniversityID: requestBody.UniversityID,
+		TermID:       requestBody.TermID,
+		DryRun:       requestBody.DryRun,
+		OnError:      OnErrorAbort,
+	}
+	if err := source.setParam("on_error", requestBody.OnError); err != nil {
+		return nil, err
+	}
+
+	path, err := o.resolvePath(requestBody.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, badSource("file_path", "Gagal membuka file CSV")
+	}
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		file.Close()
+		return nil, badSource("file_path", "File path bukan file biasa")
+	}
+	if info.Size() > o.MaxSize {
+		file.Close()
+		return nil, tooLarge(o.MaxSize)
+	}
+
+	source.Reader = file
+	source.closer = file
+	return source, nil
+}
+
+// resolvePath returns the real location of a path relative to, or absolute
+// inside, the import directory. Symbolic links are followed before the check
+// so they cannot point outside the directory.
+func (o ImportOptions) resolvePath(path string) (string, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		return "", badSource("file_path", "File path harus berakhir dengan .csv")
+	}
+	dir, err := filepath.EvalSymlinks(o.Dir)
+	if err != nil {
+		return "", &sourceError{Status: http.StatusInternalServerError, Field: "file_path", Msg: "Direktori impor tidak dapat dibaca"}
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(o.Dir, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", badSource("file_path", "File CSV tidak ditemukan di direktori impor")
+	}
+	resolved, err = filepath.Abs(resolved)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(dir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &sourceError{Status: http.StatusForbidden, Field: "file_path", Msg: "File path berada di luar direktori impor"}
+	}
+	return resolved, nil
+}
+
+// parseQuery reads the import parameters from the query string
+func (s *importSource) parseQuery(r *http.Request) error {
+	for _, name := range []string{"university_id", "term_id", "dry_run", "on_error"} {
+		if value := r.URL.Query().Get(name); value != "" {
+			if err := s.setParam(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *importSource) setParam(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch name {
+	case "dry_run":
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return badSource(name, "dry_run harus true atau false")
+		}
+		s.DryRun = dryRun
+		return nil
+	case "on_error":
+		if value != OnErrorAbort && value != OnErrorSkip {
+			return badSource(name, "on_error harus abort atau skip")
+		}
+		s.OnError = value
+		return nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return badSource(name, name+" tidak valid")
+	}
+	if name == "university_id" {
+		s.UniversityID = uint(id)
+	} else {
+		s.TermID = uint(id)
+	}
+	return nil
+}
+
+// sniffCSV rejects content that is not text before any row is parsed and
+// drops the byte order mark so the first header is matched
+func sniffCSV(reader io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReaderSize(reader, sniffSize)
+	head, err := buffered.Peek(sniffSize)
+	if err != nil && err != io.EOF && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(head)) == 0 {
+		return nil, badSource("file", "File CSV kosong")
+	}
+	contentType := http.DetectContentType(head)
+	if !strings.HasPrefix(contentType, "text/plain") || bytes.IndexByte(head, 0) >= 0 {
+		return nil, &sourceError{
+			Status: http.StatusUnsupportedMediaType,
+			Field:  "file",
+			Msg:    fmt.Sprintf("File bukan CSV (terdeteksi %s)", contentType),
+		}
+	}
+	if bytes.HasPrefix(head, utf8BOM) {
+		buffered.Discard(len(utf8BOM))
+	}
+	return buffered, nil
+}
@@ -0,0 +1,186 @@
+package fuzzy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/asesmen"
+	"tsukamoto/internal/modules/deffuzifikasi"
+	"tsukamoto/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// loadModel returns the fuzzy model of the university. It writes the error
+// response itself and returns ok=false when the model cannot be loaded.
+func (h *fuzzyHandler) loadModel(w http.ResponseWriter, r *http.Request, universityID uint) (asesmen.Model, bool) {
+	config, err := h.repo.GetFuzzyConfig(r.Context(), universityID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memuat model fuzzy universitas"}}, nil)
+		return asesmen.Model{}, false
+	}
+	model, err := asesmen.ModelFrom(config)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Model fuzzy universitas tidak valid: " + err.Error()}}, nil)
+		return asesmen.Model{}, false
+	}
+	return model, true
+}
+
+// parseUniversityID reads the university in the URL
+func parseUniversityID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil || id == 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "ID universitas tidak valid"}}, nil)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func configResponse(universityID uint, config *models.FuzzyConfig, model asesmen.Model) ConfigResponse {
+	response := ConfigResponse{
+		UniversityID: universityID,
+		Config:       models.JSON("{}"),
+		Thresholds:   model.Thresholds,
+		Categories:   deffuzifikasi.Categories(),
+	}
+	if config != nil {
+		response.Custom = true
+		response.Config = config.Config
+		response.UpdatedAt = &config.UpdatedAt
+	}
+	return response
+}
+
+// GetConfig handles GET /university/:id/fuzzy-config
+func (h *fuzzyHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	universityID, ok := parseUniversityID(w, r)
+	if !ok {
+		return
+	}
+
+	config, err := h.repo.GetFuzzyConfig(r.Context(), universityID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memuat model fuzzy universitas"}}, nil)
+		return
+	}
+	model, err := asesmen.ModelFrom(config)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Model fuzzy universitas tidak valid: " + err.Error()}}, nil)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, configResponse(universityID, config, model))
+}
+
+// UpdateConfig handles PUT /university/:id/fuzzy-config and replaces the
+// university's variables, rules and thresholds
+func (h *fuzzyHandler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
+	universityID, ok := parseUniversityID(w, r)
+	if !ok {
+		return
+	}
+
+	var req asesmen.Config
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+	model, err := req.Build()
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "config", Message: err.Error()}}, nil)
+		return
+	}
+
+	exists, err := h.repo.UniversityExists(r.Context(), universityID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa universitas"}}, nil)
+		return
+	}
+	if !exists {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Universitas tidak ditemukan"}}, nil)
+		return
+	}
+
+	before, err := h.repo.GetFuzzyConfig(r.Context(), universityID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memuat model fuzzy universitas"}}, nil)
+		return
+	}
+
+	data, err := models.NewJSON(req)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+		return
+	}
+	config := &models.FuzzyConfig{UniversityID: universityID, Config: data}
+	if caller, ok := middleware.UserFromContext(r.Context()); ok {
+		id := caller.ID
+		config.UpdatedBy = &id
+	}
+	if before != nil {
+		config.ID = before.ID
+		config.CreatedAt = before.CreatedAt
+	}
+	if err := h.repo.SaveFuzzyConfig(r.Context(), config); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan model fuzzy universitas"}}, nil)
+		return
+	}
+
+	action := models.AuditUpdate
+	if before == nil {
+		action = models.AuditCreate
+	} else if bytes.Equal(before.Config, config.Config) {
+		// Konfigurasi sama, tidak ada yang perlu dicatat
+		action = ""
+	}
+	if action != "" {
+		h.audit.Record(r, audit.Entry{
+			Action:     action,
+			EntityType: models.AuditEntityFuzzyConfig,
+			EntityID:   universityID,
+			Before:     before,
+			After:      config,
+		})
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, configResponse(universityID, config, model))
+}
+
+// DeleteConfig handles DELETE /university/:id/fuzzy-config and returns the
+// university to the default model
+func (h *fuzzyHandler) DeleteConfig(w http.ResponseWriter, r *http.Request) {
+	universityID, ok := parseUniversityID(w, r)
+	if !ok {
+		return
+	}
+
+	before, err := h.repo.GetFuzzyConfig(r.Context(), universityID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memuat model fuzzy universitas"}}, nil)
+		return
+	}
+	if before == nil {
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Universitas memakai model bawaan"}}, nil)
+		return
+	}
+
+	if err := h.repo.DeleteFuzzyConfig(r.Context(), universityID); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghapus model fuzzy universitas"}}, nil)
+		return
+	}
+	h.audit.Record(r, audit.Entry{
+		Action:     models.AuditDelete,
+		EntityType: models.AuditEntityFuzzyConfig,
+		EntityID:   universityID,
+		Before:     before,
+	})
+
+	utils.WriteResponse(w, http.StatusOK, nil, configResponse(universityID, nil, asesmen.DefaultModel()))
+}
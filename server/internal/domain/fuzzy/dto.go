@@ -0,0 +1,27 @@
+package fuzzy
+
+import (
+	"time"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/deffuzifikasi"
+)
+
+// RecommendationRequest meminta rencana perubahan untuk mencapai kategori target
+type RecommendationRequest struct {
+	Target    string             `json:"target"`
+	Method    string             `json:"method"`
+	Locked    []string           `json:"locked"`
+	MaxChange map[string]float64 `json:"max_change"`
+	Limit     int                `json:"limit"`
+}
+
+// ConfigResponse adalah model fuzzy sebuah universitas. Custom false berarti
+// universitas memakai model bawaan dan Config kosong.
+type ConfigResponse struct {
+	UniversityID uint                     `json:"university_id"`
+	Custom       bool                     `json:"custom"`
+	Config       models.JSON              `json:"config"`
+	Thresholds   deffuzifikasi.Thresholds `json:"thresholds"`
+	Categories   []string                 `json:"categories"`
+	UpdatedAt    *time.Time               `json:"updated_at"`
+}
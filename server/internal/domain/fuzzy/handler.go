@@ -1,118 +1,156 @@
 package fuzzy
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/asesmen"
 	"tsukamoto/internal/modules/deffuzifikasi"
-	"tsukamoto/internal/modules/fuzzifikasi"
 	"tsukamoto/internal/modules/inferensi"
+	"tsukamoto/internal/modules/normalisasi"
+	"tsukamoto/internal/modules/rekomendasi"
 	"tsukamoto/internal/utils"
 
 	"github.com/gorilla/mux"
-	"gorm.io/gorm"
 )
 
-// Handler struct holds dependencies for fuzzy handlers
-type Handler struct {
-	DB *gorm.DB
+type fuzzyHandler struct {
+	repo  FuzzyRepository
+	bus   *events.Bus
+	audit *audit.Log
 }
 
-// NewHandler creates a new Handler
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{DB: db}
+func NewFuzzyHandler(repo FuzzyRepository, bus *events.Bus, log *audit.Log) FuzzyHandler {
+	return &fuzzyHandler{repo: repo, bus: bus, audit: log}
 }
 
-// FuzzyByUserID handles GET /fuzzy/:id
-func (h *Handler) FuzzyByUserID(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	idStr := vars["id"]
-	userID, err := strconv.Atoi(idStr)
-	if err != nil {
-		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "ID user tidak valid"}}, nil)
+// FuzzyByUserID handles GET /fuzzy/:id?method=tsukamoto|mamdani|mamdani_product|sugeno.
+// Reading a result changes nothing; with store=true the assessment is saved
+// and published as events.AssessmentCreated.
+func (h *fuzzyHandler) FuzzyByUserID(w http.ResponseWriter, r *http.Request) {
+	method, ok := parseMethod(w, r)
+	if !ok {
 		return
 	}
-
-	var academic models.Academic
-	if err := h.DB.Where("user_id = ?", userID).First(&academic).Error; err != nil {
-		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "User tidak ditemukan"}}, nil)
-		return
+	store := false
+	if value := r.URL.Query().Get("store"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "store", Message: "store harus true atau false"}}, nil)
+			return
+		}
+		store = parsed
 	}
 
-	// Ambil input yang diperlukan untuk aturan fuzzy
-	gpa := float64(academic.GPA)
-	cca := float64(academic.CoreCourseAverage)
-	attendance := float64(academic.AttendanceRate)
-	midterm := float64(academic.MidtermExamScore)
-	finalExam := float64(academic.FinalExamScore)
-
-	// Validasi input
-	if attendance < 0 || attendance > 1 {
-		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Nilai attendance tidak valid"}}, nil)
+	userID, academic, gpa, cca, attendance, midterm, finalExam, ok := h.loadInputs(w, r)
+	if !ok {
 		return
 	}
-	if midterm < 0 || midterm > 100 {
-		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Nilai midterm exam tidak valid"}}, nil)
+	model, ok := h.loadModel(w, r, academic.UniversityID)
+	if !ok {
 		return
 	}
-	if finalExam < 0 || finalExam > 100 {
-		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Nilai final exam tidak valid"}}, nil)
+
+	// Inferensi beserta penjelasan hasil dengan model universitas
+	assessment, result, err := asesmen.Evaluate(model, *academic, method)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
 		return
 	}
-	if gpa < 0 || gpa > 4 {
-		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Nilai GPA tidak valid"}}, nil)
-		return
+
+	// Membership per term dengan nama term huruf kecil
+	memberships := make(map[string]map[string]float64, len(result.Memberships))
+	for variable, terms := range result.Memberships {
+		memberships[variable] = make(map[string]float64, len(terms))
+		for term, value := range terms {
+			memberships[variable][strings.ToLower(term)] = value
+		}
 	}
-	if cca < 0 || cca > 100 {
-		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Nilai CCA tidak valid"}}, nil)
-		return
+
+	// Output kategori mengikuti batas kategori model universitas, sama dengan category
+	output := make(map[string]float64, len(deffuzifikasi.Categories()))
+	for _, category := range deffuzifikasi.Categories() {
+		output[category] = 0
 	}
+	output[assessment.Category] = 1
+	// Nilai numerik 1 (Poor) sampai 5 (Excellent) sesuai peringkat kategori
+	defuzzValue := float64(deffuzifikasi.CategoryRank(assessment.Category) + 1)
 
-	// Fuzzifikasi
-	gpaLow, gpaMed, gpaHigh := fuzzifikasi.FuzzifyGPA(gpa)
-	ccaLow, ccaMed, ccaHigh := fuzzifikasi.FuzzifyCCA(cca)
-	attLow, attMed, attHigh := fuzzifikasi.FuzzifyAttendance(attendance)
-	midLow, midMed, midHigh := fuzzifikasi.FuzzifyMES(midterm)
-	finLow, finMed, finHigh := fuzzifikasi.FuzzifyFinalExam(finalExam)
+	// Hasil hanya disimpan bila diminta, agar membuka halaman tidak menambah riwayat
+	if store {
+		if err := h.repo.CreateAssessment(r.Context(), assessment); err != nil {
+			utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan assessment"}}, nil)
+			return
+		}
+		h.bus.Publish(r.Context(), events.AssessmentCreated, assessment)
+	}
 
-	// Inferensi
-	output := inferensi.Inference(gpa, cca, attendance, midterm, finalExam)
+	response := map[string]interface{}{
+		"user_id":               userID,
+		"term_id":               academic.TermID,
+		"method":                method,
+		"category":              assessment.Category,
+		"crisp_output":          result.CrispOutput,
+		"defuzzification_value": defuzzValue,
+		"inputs": map[string]interface{}{
+			"gpa":        gpa,
+			"cca":        cca,
+			"attendance": attendance,
+			"midterm":    midterm,
+			"final_exam": finalExam,
+		},
+		"fuzzy_membership": memberships,
+		"inference_output": output,
+		"explanation":      assessment.Explanation,
+	}
+	if store {
+		response["assessment_id"] = assessment.ID
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, response)
+}
 
-	// Defuzzifikasi
-	category, err := deffuzifikasi.Defuzzify(output)
-	if err != nil {
-		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+// HierarchicalByUserID handles GET /fuzzy/:id/hierarchical?method=...
+// The hierarchy has its own fixed subsystems that cannot be derived from a
+// university's variables and rules, so universities with custom ones get 409
+// instead of a result that does not match their model.
+func (h *fuzzyHandler) HierarchicalByUserID(w http.ResponseWriter, r *http.Request) {
+	method, ok := parseMethod(w, r)
+	if !ok {
 		return
 	}
 
-	// Defuzzifikasi numerik (average)
-	// Mapping kategori ke nilai numerik representative
-	categoryValues := map[string]float64{
-		"Poor":              1,
-		"Needs Improvement": 2,
-		"Satisfactory":      3,
-		"Good":              4,
-		"Excellent":         5,
+	userID, academic, gpa, cca, attendance, midterm, finalExam, ok := h.loadInputs(w, r)
+	if !ok {
+		return
+	}
+	model, ok := h.loadModel(w, r, academic.UniversityID)
+	if !ok {
+		return
 	}
-	var sum float64
-	var total float64
-	for cat, val := range categoryValues {
-		membership := output[cat]
-		sum += membership * val
-		total += membership
+
+	if model.Custom {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Message: "Analisis hierarkis hanya tersedia untuk model fuzzy bawaan, universitas ini memakai variabel atau rule sendiri"}}, nil)
+		return
 	}
-	var defuzzValue float64
-	if total > 0 {
-		defuzzValue = sum / total
-	} else {
-		defuzzValue = 0
+
+	// Hierarki memakai subsistem bawaan, hanya batas kategori yang mengikuti universitas
+	_, result, err := deffuzifikasi.HierarchicalDefuzzify(method, gpa, cca, attendance, midterm, finalExam)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+		return
 	}
+	category := model.Categorize(result.CrispOutput)
 
 	utils.WriteResponse(w, http.StatusOK, nil, map[string]interface{}{
-		"user_id":               userID,
-		"category":              category,
-		"defuzzification_value": defuzzValue,
+		"user_id":      userID,
+		"method":       method,
+		"category":     category,
+		"crisp_output": result.CrispOutput,
 		"inputs": map[string]interface{}{
 			"gpa":        gpa,
 			"cca":        cca,
@@ -120,33 +158,170 @@ func (h *Handler) FuzzyByUserID(w http.ResponseWriter, r *http.Request) {
 			"midterm":    midterm,
 			"final_exam": finalExam,
 		},
-		"fuzzy_membership": map[string]interface{}{
-			"gpa": map[string]float64{
-				"low":    gpaLow,
-				"medium": gpaMed,
-				"high":   gpaHigh,
-			},
-			"cca": map[string]float64{
-				"low":    ccaLow,
-				"medium": ccaMed,
-				"high":   ccaHigh,
-			},
-			"attendance": map[string]float64{
-				"low":    attLow,
-				"medium": attMed,
-				"high":   attHigh,
-			},
-			"midterm": map[string]float64{
-				"low":    midLow,
-				"medium": midMed,
-				"high":   midHigh,
-			},
-			"final_exam": map[string]float64{
-				"low":    finLow,
-				"medium": finMed,
-				"high":   finHigh,
-			},
+		"stages": result.Stages,
+	})
+}
+
+// CompareByUserID handles GET /fuzzy/:id/compare and scores the same student
+// with every inference method side by side
+func (h *fuzzyHandler) CompareByUserID(w http.ResponseWriter, r *http.Request) {
+	userID, academic, gpa, cca, attendance, midterm, finalExam, ok := h.loadInputs(w, r)
+	if !ok {
+		return
+	}
+	model, ok := h.loadModel(w, r, academic.UniversityID)
+	if !ok {
+		return
+	}
+
+	inputs := inferensi.DefaultInputs(gpa, cca, attendance, midterm, finalExam)
+	results := make(map[inferensi.Method]interface{}, len(inferensi.Methods()))
+	for _, method := range inferensi.Methods() {
+		result, err := model.System.InferWith(method, inputs)
+		if err == nil && result.TotalWeight == 0 {
+			err = inferensi.ErrNoRuleActivated
+		}
+		if err != nil {
+			utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+			return
+		}
+		results[method] = map[string]interface{}{
+			"category":     model.Categorize(result.CrispOutput),
+			"crisp_output": result.CrispOutput,
+			"rule_outputs": result.RuleOutputs,
+		}
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, map[string]interface{}{
+		"user_id": userID,
+		"inputs":  inputs,
+		"methods": results,
+	})
+}
+
+// RecommendByUserID handles POST /fuzzy/:id/recommendations and returns the
+// cheapest plans of input changes that reach the requested category
+func (h *fuzzyHandler) RecommendByUserID(w http.ResponseWriter, r *http.Request) {
+	var req RecommendationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	method, err := inferensi.ParseMethod(req.Method)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "method", Message: "Metode inferensi tidak valid"}}, nil)
+		return
+	}
+	if deffuzifikasi.CategoryRank(req.Target) < 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "target", Message: "Kategori target tidak valid"}}, nil)
+		return
+	}
+
+	userID, academic, gpa, cca, attendance, midterm, finalExam, ok := h.loadInputs(w, r)
+	if !ok {
+		return
+	}
+	model, ok := h.loadModel(w, r, academic.UniversityID)
+	if !ok {
+		return
+	}
+
+	system := model.System
+	inputs := inferensi.DefaultInputs(gpa, cca, attendance, midterm, finalExam)
+	current, err := system.InferWith(method, inputs)
+	if err == nil && current.TotalWeight == 0 {
+		err = inferensi.ErrNoRuleActivated
+	}
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+		return
+	}
+
+	plans, err := rekomendasi.Recommend(system, inputs, rekomendasi.Options{
+		Method:     method,
+		Target:     req.Target,
+		Locked:     req.Locked,
+		MaxChange:  req.MaxChange,
+		Limit:      req.Limit,
+		Thresholds: &model.Thresholds,
+	})
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: err.Error()}}, nil)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, nil, map[string]interface{}{
+		"user_id": userID,
+		"method":  method,
+		"target":  req.Target,
+		"current": map[string]interface{}{
+			"category":     model.Categorize(current.CrispOutput),
+			"crisp_output": current.CrispOutput,
+			"inputs":       inputs,
 		},
-		"inference_output": output,
+		"reachable": len(plans) > 0,
+		"plans":     plans,
 	})
 }
+
+// parseMethod reads the optional method query parameter
+func parseMethod(w http.ResponseWriter, r *http.Request) (inferensi.Method, bool) {
+	method, err := inferensi.ParseMethod(r.URL.Query().Get("method"))
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "method", Message: "Metode inferensi tidak valid"}}, nil)
+		return "", false
+	}
+	return method, true
+}
+
+// loadInputs reads the academic record of the user in the URL, in the term
+// given by ?term_id= or the latest term, and validates the fuzzy inputs. It
+// writes the error response itself and returns ok=false when the request
+// cannot proceed.
+func (h *fuzzyHandler) loadInputs(w http.ResponseWriter, r *http.Request) (userID int, academic *models.Academic, gpa, cca, attendance, midterm, finalExam float64, ok bool) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	userID, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "ID user tidak valid"}}, nil)
+		return
+	}
+
+	// Term tertentu lewat ?term_id=, default term terbaru
+	var termID uint
+	if value := r.URL.Query().Get("term_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || parsed == 0 {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "term_id", Message: "Term ID tidak valid"}}, nil)
+			return
+		}
+		termID = uint(parsed)
+	}
+
+	academic, err = h.repo.GetAcademicByUserID(r.Context(), userID, termID)
+	if err != nil {
+		if termID != 0 {
+			utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Data akademik user pada term tersebut tidak ditemukan"}}, nil)
+			return
+		}
+		utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "User tidak ditemukan"}}, nil)
+		return
+	}
+
+	// Nilai tersimpan sudah dalam skala model, cukup divalidasi
+	input := normalisasi.FromAcademic(*academic)
+	if errs := normalisasi.Validate(input); len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	// Ambil input yang diperlukan untuk aturan fuzzy
+	gpa = input.GPA
+	cca = input.CoreCourseAverage
+	attendance = input.AttendanceRate
+	midterm = input.MidtermExamScore
+	finalExam = input.FinalExamScore
+
+	return userID, academic, gpa, cca, attendance, midterm, finalExam, true
+}
@@ -0,0 +1,600 @@
+package fuzzy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const fuzzyPathID = "/fuzzy/1"
+
+func newRequest(path string) *http.Request {
+	req := httptest.NewRequest("GET", path, nil)
+	return mux.SetURLVars(req, map[string]string{"id": "1"})
+}
+
+func sampleAcademic() *models.Academic {
+	return &models.Academic{
+		ID:                7,
+		UserID:            1,
+		GPA:               2.6,
+		CoreCourseAverage: 65,
+		AttendanceRate:    0.62,
+		MidtermExamScore:  60,
+		FinalExamScore:    70,
+	}
+}
+
+func decodeData(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return body.Data
+}
+
+func TestFuzzyHandler_FuzzyByUserID_StoresAssessment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(sampleAcademic(), nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+
+	var stored *models.Assessment
+	mockRepo.EXPECT().
+		CreateAssessment(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, assessment *models.Assessment) error {
+			assessment.ID = 11
+			stored = assessment
+			return nil
+		})
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID+"?store=true"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	data := decodeData(t, w)
+	if data["assessment_id"] != float64(11) {
+		t.Errorf("expected assessment_id 11, got %v", data["assessment_id"])
+	}
+	explanation, ok := data["explanation"].(map[string]interface{})
+	if !ok || explanation["id"] == "" || explanation["en"] == "" {
+		t.Fatalf("expected explanation texts, got %v", data["explanation"])
+	}
+	if factors, ok := explanation["factors"].([]interface{}); !ok || len(factors) != 5 {
+		t.Errorf("expected 5 ranked factors, got %v", explanation["factors"])
+	}
+
+	if stored == nil {
+		t.Fatal("assessment was not stored")
+	}
+	if stored.UserID != 1 || stored.AcademicID != 7 || stored.Method != "tsukamoto" || stored.Category != data["category"] {
+		t.Errorf("unexpected stored assessment: %+v", stored)
+	}
+	if len(stored.Explanation) == 0 || len(stored.Inputs) == 0 {
+		t.Error("expected stored inputs and explanation")
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_StoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(sampleAcademic(), nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID+"?store=true"))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_ReadDoesNotStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// CreateAssessment tidak diharapkan, gomock gagal bila dipanggil
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(sampleAcademic(), nil).Times(2)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil).Times(2)
+
+	for _, path := range []string{fuzzyPathID, fuzzyPathID + "?store=false"} {
+		w := httptest.NewRecorder()
+		handler.FuzzyByUserID(w, newRequest(path))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		if _, ok := decodeData(t, w)["assessment_id"]; ok {
+			t.Errorf("%s: expected no assessment_id for a read", path)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID+"?store=maybe"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid store, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(nil, errors.New("academic record not found"))
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_SpecificTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	termID := uint(3)
+	academic := sampleAcademic()
+	academic.TermID = &termID
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, termID).Return(academic, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+	mockRepo.EXPECT().CreateAssessment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, assessment *models.Assessment) error {
+		if assessment.TermID == nil || *assessment.TermID != termID {
+			t.Errorf("expected assessment for term 3, got %v", assessment.TermID)
+		}
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID+"?term_id=3&store=true"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if data := decodeData(t, w); data["term_id"] != float64(3) {
+		t.Errorf("expected term_id 3, got %v", data["term_id"])
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_TermErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID+"?term_id=x"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid term, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(9)).Return(nil, errors.New("academic record not found"))
+	w = httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID+"?term_id=9"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for missing term record, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_InvalidMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewFuzzyHandler(NewMockFuzzyRepository(ctrl), nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID+"?method=unknown"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewFuzzyHandler(NewMockFuzzyRepository(ctrl), nil, nil)
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/fuzzy/abc", nil), map[string]string{"id": "abc"})
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+// uncoveredAcademic has inputs for which no rule of the default rule base fires
+func uncoveredAcademic() *models.Academic {
+	return &models.Academic{
+		ID:                8,
+		UserID:            1,
+		GPA:               3.8,
+		CoreCourseAverage: 40,
+		AttendanceRate:    0.5,
+		MidtermExamScore:  40,
+		FinalExamScore:    40,
+	}
+}
+
+func TestFuzzyHandler_NoRuleActivated(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle func(FuzzyHandler, http.ResponseWriter, *http.Request)
+		path   string
+	}{
+		{"fuzzy", FuzzyHandler.FuzzyByUserID, fuzzyPathID + "?store=true"},
+		{"compare", FuzzyHandler.CompareByUserID, fuzzyPathID + "/compare"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := NewMockFuzzyRepository(ctrl)
+			handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+			// CreateAssessment tidak boleh dipanggil
+			mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(uncoveredAcademic(), nil)
+			mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+
+			w := httptest.NewRecorder()
+			tt.handle(handler, w, newRequest(tt.path))
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("expected 500, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestFuzzyHandler_CompareByUserID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(sampleAcademic(), nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.CompareByUserID(w, newRequest(fuzzyPathID+"/compare"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if methods, ok := decodeData(t, w)["methods"].(map[string]interface{}); !ok || len(methods) != 4 {
+		t.Errorf("expected 4 methods in comparison")
+	}
+}
+
+func newRecommendationRequest(body string) *http.Request {
+	req := httptest.NewRequest("POST", fuzzyPathID+"/recommendations", bytes.NewReader([]byte(body)))
+	return mux.SetURLVars(req, map[string]string{"id": "1"})
+}
+
+func TestFuzzyHandler_RecommendByUserID_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(sampleAcademic(), nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.RecommendByUserID(w, newRecommendationRequest(`{"target":"Satisfactory","locked":["gpa"],"limit":2}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	data := decodeData(t, w)
+	plans, ok := data["plans"].([]interface{})
+	if !ok || len(plans) == 0 || len(plans) > 2 {
+		t.Fatalf("expected 1 or 2 plans, got %v", data["plans"])
+	}
+	for _, p := range plans {
+		plan := p.(map[string]interface{})
+		if plan["category"] != "Satisfactory" {
+			t.Errorf("plan does not reach target: %v", plan)
+		}
+		for _, c := range plan["changes"].([]interface{}) {
+			if c.(map[string]interface{})["variable"] == "gpa" {
+				t.Errorf("locked variable changed: %v", c)
+			}
+		}
+	}
+}
+
+func TestFuzzyHandler_RecommendByUserID_InvalidTarget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewFuzzyHandler(NewMockFuzzyRepository(ctrl), nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.RecommendByUserID(w, newRecommendationRequest(`{"target":"Legendary"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_RecommendByUserID_UnknownLockedVariable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(sampleAcademic(), nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(0)).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.RecommendByUserID(w, newRecommendationRequest(`{"target":"Good","locked":["height"]}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_RecommendByUserID_InvalidJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewFuzzyHandler(NewMockFuzzyRepository(ctrl), nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.RecommendByUserID(w, newRecommendationRequest(`invalid`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_FuzzyByUserID_UniversityThresholds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	academic := sampleAcademic()
+	academic.UniversityID = 3
+	mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(academic, nil)
+	// Batas longgar membuat mahasiswa yang sama masuk Satisfactory
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(3)).Return(&models.FuzzyConfig{
+		UniversityID: 3,
+		Config:       models.JSON(`{"thresholds":[20,30,80,95]}`),
+	}, nil)
+
+	w := httptest.NewRecorder()
+	handler.FuzzyByUserID(w, newRequest(fuzzyPathID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	data := decodeData(t, w)
+	if category := data["category"]; category != "Satisfactory" {
+		t.Errorf("expected Satisfactory, got %v", category)
+	}
+	// Output kategori dan nilainya memakai batas yang sama dengan category
+	output, _ := data["inference_output"].(map[string]interface{})
+	for category, membership := range output {
+		if want := map[bool]float64{true: 1, false: 0}[category == "Satisfactory"]; membership != want {
+			t.Errorf("expected %s membership %v, got %v", category, want, membership)
+		}
+	}
+	if len(output) != 5 {
+		t.Errorf("expected every category in the output, got %v", output)
+	}
+	if value := data["defuzzification_value"]; value != 3.0 {
+		t.Errorf("expected the rank of Satisfactory, got %v", value)
+	}
+}
+
+func TestFuzzyHandler_HierarchicalByUserID_UniversityModel(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		wantCode int
+	}{
+		// Batas kategori saja tetap bisa dipakai pada hierarki bawaan
+		{"thresholds only", `{"thresholds":[20,30,80,95]}`, http.StatusOK},
+		{"custom rules", `{"rules":[{"conditions":{"gpa":"High"},"output":"Excellent"}]}`, http.StatusConflict},
+		{"custom variables", `{"variables":[{"name":"attendance","terms":[
+			{"name":"Low","a":null,"b":null,"c":0.7,"d":0.75},
+			{"name":"Medium","a":0.7,"b":0.8,"c":0.8,"d":0.9},
+			{"name":"High","a":0.85,"b":0.95,"c":null,"d":null}]}]}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := NewMockFuzzyRepository(ctrl)
+			handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+			academic := sampleAcademic()
+			academic.UniversityID = 3
+			mockRepo.EXPECT().GetAcademicByUserID(gomock.Any(), 1, uint(0)).Return(academic, nil)
+			mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(3)).Return(&models.FuzzyConfig{UniversityID: 3, Config: models.JSON(tt.config)}, nil)
+
+			w := httptest.NewRecorder()
+			handler.HierarchicalByUserID(w, newRequest(fuzzyPathID+"/hierarchical"))
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode == http.StatusOK {
+				if category := decodeData(t, w)["category"]; category != "Satisfactory" {
+					t.Errorf("expected the university thresholds, got %v", category)
+				}
+			}
+		})
+	}
+}
+
+func newConfigRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/university/3/fuzzy-config", bytes.NewReader([]byte(body)))
+	return mux.SetURLVars(req, map[string]string{"id": "3"})
+}
+
+func TestFuzzyHandler_GetConfig_Default(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(3)).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.GetConfig(w, newConfigRequest("GET", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	data := decodeData(t, w)
+	if data["custom"] != false {
+		t.Errorf("expected the default model, got %v", data)
+	}
+	if thresholds, ok := data["thresholds"].([]interface{}); !ok || len(thresholds) != 4 || thresholds[0] != 40.0 {
+		t.Errorf("expected the default thresholds, got %v", data["thresholds"])
+	}
+}
+
+func TestFuzzyHandler_UpdateConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	body := `{
+		"variables": [{"name": "attendance", "terms": [
+			{"name": "Low", "a": null, "b": null, "c": 0.7, "d": 0.75},
+			{"name": "Medium", "a": 0.7, "b": 0.8, "c": 0.8, "d": 0.9},
+			{"name": "High", "a": 0.85, "b": 0.95, "c": null, "d": null}
+		]}],
+		"thresholds": [35, 55, 75, 90]
+	}`
+	mockRepo.EXPECT().UniversityExists(gomock.Any(), uint(3)).Return(true, nil)
+	mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(3)).Return(nil, nil)
+	mockRepo.EXPECT().SaveFuzzyConfig(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, config *models.FuzzyConfig) error {
+		if config.UniversityID != 3 || len(config.Config) == 0 {
+			t.Errorf("unexpected config: %+v", config)
+		}
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.UpdateConfig(w, newConfigRequest("PUT", body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	data := decodeData(t, w)
+	if data["custom"] != true {
+		t.Errorf("expected a custom model, got %v", data)
+	}
+	if thresholds := data["thresholds"].([]interface{}); thresholds[0] != 35.0 {
+		t.Errorf("expected the new thresholds, got %v", thresholds)
+	}
+}
+
+func TestFuzzyHandler_UpdateConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"unknown variable", `{"variables": [{"name": "height", "terms": [{"name": "Low"}]}]}`},
+		{"unordered points", `{"variables": [{"name": "gpa", "terms": [{"name": "Low", "a": 3, "b": 2, "c": 2.5, "d": 4}]}]}`},
+		{"rule with unknown term", `{"rules": [{"conditions": {"gpa": "Brilliant"}, "output": "Excellent"}]}`},
+		{"rule with unknown output", `{"rules": [{"conditions": {"gpa": "High"}, "output": "Legendary"}]}`},
+		{"falling thresholds", `{"thresholds": [40, 30, 80, 95]}`},
+		{"unknown field", `{"threshold": [40, 60, 80, 95]}`},
+		// Term default dihapus sehingga rule bawaan tidak lagi valid
+		{"default rules lose their terms", `{"variables": [{"name": "gpa", "terms": [{"name": "Weak", "a": null, "b": null, "c": 2, "d": 3}]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewFuzzyHandler(NewMockFuzzyRepository(ctrl), nil, nil)
+
+			w := httptest.NewRecorder()
+			handler.UpdateConfig(w, newConfigRequest("PUT", tt.body))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestFuzzyHandler_UpdateConfig_UnknownUniversity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().UniversityExists(gomock.Any(), uint(3)).Return(false, nil)
+
+	w := httptest.NewRecorder()
+	handler.UpdateConfig(w, newConfigRequest("PUT", `{"thresholds": [35, 55, 75, 90]}`))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestFuzzyHandler_DeleteConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockFuzzyRepository(ctrl)
+	handler := NewFuzzyHandler(mockRepo, nil, nil)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(3)).Return(&models.FuzzyConfig{UniversityID: 3, Config: models.JSON(`{}`)}, nil),
+		mockRepo.EXPECT().DeleteFuzzyConfig(gomock.Any(), uint(3)).Return(nil),
+		mockRepo.EXPECT().GetFuzzyConfig(gomock.Any(), uint(3)).Return(nil, nil),
+	)
+
+	w := httptest.NewRecorder()
+	handler.DeleteConfig(w, newConfigRequest("DELETE", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	// Tanpa konfigurasi tidak ada yang bisa dihapus
+	w = httptest.NewRecorder()
+	handler.DeleteConfig(w, newConfigRequest("DELETE", ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
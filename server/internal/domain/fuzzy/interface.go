@@ -0,0 +1,30 @@
+package fuzzy
+
+import (
+	"context"
+	"net/http"
+	"tsukamoto/internal/models"
+)
+
+type FuzzyRepository interface {
+	// GetAcademicByUserID returns the user's record in the term, or in the
+	// latest term when termID is 0
+	GetAcademicByUserID(ctx context.Context, userID int, termID uint) (*models.Academic, error)
+	CreateAssessment(ctx context.Context, assessment *models.Assessment) error
+	// GetFuzzyConfig returns the university's fuzzy model, or nil when it uses the default
+	GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error)
+	// SaveFuzzyConfig creates or replaces the configuration of its university
+	SaveFuzzyConfig(ctx context.Context, config *models.FuzzyConfig) error
+	DeleteFuzzyConfig(ctx context.Context, universityID uint) error
+	UniversityExists(ctx context.Context, id uint) (bool, error)
+}
+
+type FuzzyHandler interface {
+	FuzzyByUserID(w http.ResponseWriter, r *http.Request)
+	HierarchicalByUserID(w http.ResponseWriter, r *http.Request)
+	CompareByUserID(w http.ResponseWriter, r *http.Request)
+	RecommendByUserID(w http.ResponseWriter, r *http.Request)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	UpdateConfig(w http.ResponseWriter, r *http.Request)
+	DeleteConfig(w http.ResponseWriter, r *http.Request)
+}
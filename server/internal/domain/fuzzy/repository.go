@@ -0,0 +1,70 @@
+package fuzzy
+
+import (
+	"context"
+	"errors"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type fuzzyRepository struct {
+	db *gorm.DB
+}
+
+func NewFuzzyRepository(db *gorm.DB) FuzzyRepository {
+	return &fuzzyRepository{db: db}
+}
+
+func (r *fuzzyRepository) GetAcademicByUserID(ctx context.Context, userID int, termID uint) (*models.Academic, error) {
+	query := r.db.WithContext(ctx).Preload("Term").Where("academics.user_id = ?", userID)
+	if termID != 0 {
+		query = query.Where("academics.term_id = ?", termID)
+	} else {
+		query = query.Scopes(models.LatestTermFirst)
+	}
+
+	var academic models.Academic
+	if err := query.First(&academic).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("academic record not found")
+		}
+		return nil, err
+	}
+	return &academic, nil
+}
+
+func (r *fuzzyRepository) CreateAssessment(ctx context.Context, assessment *models.Assessment) error {
+	return r.db.WithContext(ctx).Create(assessment).Error
+}
+
+func (r *fuzzyRepository) GetFuzzyConfig(ctx context.Context, universityID uint) (*models.FuzzyConfig, error) {
+	var config models.FuzzyConfig
+	err := r.db.WithContext(ctx).Where("university_id = ?", universityID).First(&config).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (r *fuzzyRepository) SaveFuzzyConfig(ctx context.Context, config *models.FuzzyConfig) error {
+	// Satu konfigurasi per universitas, simpan ulang menimpa yang lama
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "university_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"config", "updated_by", "updated_at"}),
+	}).Create(config).Error
+}
+
+func (r *fuzzyRepository) DeleteFuzzyConfig(ctx context.Context, universityID uint) error {
+	return r.db.WithContext(ctx).Where("university_id = ?", universityID).Delete(&models.FuzzyConfig{}).Error
+}
+
+func (r *fuzzyRepository) UniversityExists(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.University{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
@@ -1,12 +1,22 @@
 package fuzzy
 
 import (
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/events"
+
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
 )
 
 // RegisterRoutes registers fuzzy routes
-func FuzzyRoute(r *mux.Router, db *gorm.DB) {
-	handler := NewHandler(db)
+func FuzzyRoute(r *mux.Router, db *gorm.DB, bus *events.Bus, log *audit.Log) {
+	repo := NewFuzzyRepository(db)
+	handler := NewFuzzyHandler(repo, bus, log)
 	r.HandleFunc("/fuzzy/{id}", handler.FuzzyByUserID).Methods("GET")
+	r.HandleFunc("/fuzzy/{id}/hierarchical", handler.HierarchicalByUserID).Methods("GET")
+	r.HandleFunc("/fuzzy/{id}/compare", handler.CompareByUserID).Methods("GET")
+	r.HandleFunc("/fuzzy/{id}/recommendations", handler.RecommendByUserID).Methods("POST")
+	r.HandleFunc("/university/{id}/fuzzy-config", handler.GetConfig).Methods("GET")
+	r.HandleFunc("/university/{id}/fuzzy-config", handler.UpdateConfig).Methods("PUT")
+	r.HandleFunc("/university/{id}/fuzzy-config", handler.DeleteConfig).Methods("DELETE")
 }
@@ -0,0 +1,15 @@
+package notifications
+
+// NotificationFilter narrows the notification queue listing
+type NotificationFilter struct {
+	Status string
+	UserID uint
+	Limit  int
+}
+
+// UpdatePreferenceRequest changes the provided preference fields
+type UpdatePreferenceRequest struct {
+	Language     *string   `json:"language"`
+	Unsubscribed *bool     `json:"unsubscribed"`
+	OptOut       *[]string `json:"opt_out"`
+}
@@ -0,0 +1,173 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+type notificationHandler struct {
+	repo NotificationRepository
+}
+
+func NewNotificationHandler(repo NotificationRepository) NotificationHandler {
+	return &notificationHandler{repo: repo}
+}
+
+func validStatus(status string) bool {
+	switch status {
+	case models.NotificationPending, models.NotificationSent, models.NotificationFailed:
+		return true
+	}
+	return false
+}
+
+func validKind(kind string) bool {
+	for _, known := range Kinds() {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPreference handles GET /users/:id/notification-preferences
+func (h *notificationHandler) GetPreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.loadUserID(w, r)
+	if !ok {
+		return
+	}
+
+	preference, err := h.repo.GetPreference(r.Context(), userID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil preferensi notifikasi"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, preference)
+}
+
+// UpdatePreference handles PUT /users/:id/notification-preferences
+func (h *notificationHandler) UpdatePreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.loadUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdatePreferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	var validationErrors []utils.ErrorDetail
+	if req.Language != nil && *req.Language != models.LanguageIndonesian && *req.Language != models.LanguageEnglish {
+		validationErrors = append(validationErrors, utils.ErrorDetail{Field: "language", Message: "Bahasa harus 'id' atau 'en'"})
+	}
+	if req.OptOut != nil {
+		for _, kind := range *req.OptOut {
+			if !validKind(kind) {
+				validationErrors = append(validationErrors, utils.ErrorDetail{Field: "opt_out", Message: "Jenis notifikasi tidak dikenal: " + kind})
+			}
+		}
+	}
+	if len(validationErrors) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, validationErrors, nil)
+		return
+	}
+
+	preference, err := h.repo.GetPreference(r.Context(), userID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil preferensi notifikasi"}}, nil)
+		return
+	}
+	if req.Language != nil {
+		preference.Language = *req.Language
+	}
+	if req.Unsubscribed != nil {
+		preference.Unsubscribed = *req.Unsubscribed
+	}
+	if req.OptOut != nil {
+		preference.OptOut = models.StringList(*req.OptOut)
+	}
+	if preference.OptOut == nil {
+		preference.OptOut = models.StringList{}
+	}
+
+	if err := h.repo.SavePreference(r.Context(), preference); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan preferensi notifikasi"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, preference)
+}
+
+// List handles GET /notifications?status=&user_id=&limit=
+func (h *notificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := NotificationFilter{Status: query.Get("status"), Limit: defaultListLimit}
+	if filter.Status != "" && !validStatus(filter.Status) {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "status", Message: "Status notifikasi tidak valid"}}, nil)
+		return
+	}
+	if value := query.Get("user_id"); value != "" {
+		userID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "user_id", Message: "ID user tidak valid"}}, nil)
+			return
+		}
+		filter.UserID = uint(userID)
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "limit", Message: "Limit tidak valid"}}, nil)
+			return
+		}
+		filter.Limit = limit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	notifications, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data notifikasi"}}, nil)
+		return
+	}
+	for i := range notifications {
+		// Token reset password hanya untuk pemilik akun
+		if notifications[i].Kind == models.NotificationPasswordReset {
+			notifications[i].Body = ""
+		}
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, notifications)
+}
+
+// loadUserID parses the user ID from the URL and checks that the user exists,
+// writing the error response otherwise
+func (h *notificationHandler) loadUserID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "id", Message: "ID user tidak valid"}}, nil)
+		return 0, false
+	}
+
+	if _, err := h.repo.GetUserByID(r.Context(), uint(id)); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "User tidak ditemukan"}}, nil)
+			return 0, false
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data user"}}, nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,136 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const preferencesPath = "/users/1/notification-preferences"
+
+func withUserID(req *http.Request) *http.Request {
+	return mux.SetURLVars(req, map[string]string{"id": "1"})
+}
+
+func TestNotificationHandler_GetPreference_Default(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	handler := NewNotificationHandler(mockRepo)
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(&models.User{ID: 1}, nil)
+	mockRepo.EXPECT().GetPreference(gomock.Any(), uint(1)).Return(defaultPreference(1), nil)
+
+	w := httptest.NewRecorder()
+	handler.GetPreference(w, withUserID(httptest.NewRequest("GET", preferencesPath, nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data models.NotificationPreference `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	if response.Data.Language != "id" || response.Data.Unsubscribed {
+		t.Errorf("unexpected preference %+v", response.Data)
+	}
+}
+
+func TestNotificationHandler_GetPreference_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	handler := NewNotificationHandler(mockRepo)
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(nil, ErrUserNotFound)
+
+	w := httptest.NewRecorder()
+	handler.GetPreference(w, withUserID(httptest.NewRequest("GET", preferencesPath, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestNotificationHandler_UpdatePreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	handler := NewNotificationHandler(mockRepo)
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(&models.User{ID: 1}, nil)
+	mockRepo.EXPECT().GetPreference(gomock.Any(), uint(1)).Return(defaultPreference(1), nil)
+	mockRepo.EXPECT().SavePreference(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *models.NotificationPreference) error {
+		if p.Language != "en" || p.Unsubscribed || !p.OptOut.Contains(models.NotificationCategoryChanged) {
+			t.Errorf("unexpected preference %+v", p)
+		}
+		return nil
+	})
+
+	body := `{"language":"en","opt_out":["category_changed"]}`
+	w := httptest.NewRecorder()
+	handler.UpdatePreference(w, withUserID(httptest.NewRequest("PUT", preferencesPath, bytes.NewReader([]byte(body)))))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNotificationHandler_UpdatePreference_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	handler := NewNotificationHandler(mockRepo)
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(&models.User{ID: 1}, nil)
+
+	body := `{"language":"fr","opt_out":["newsletter"]}`
+	w := httptest.NewRecorder()
+	handler.UpdatePreference(w, withUserID(httptest.NewRequest("PUT", preferencesPath, bytes.NewReader([]byte(body)))))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var response struct {
+		Errors []map[string]string `json:"errors"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	if len(response.Errors) != 2 {
+		t.Errorf("expected language and opt_out errors, got %v", response.Errors)
+	}
+}
+
+func TestNotificationHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	handler := NewNotificationHandler(mockRepo)
+
+	mockRepo.EXPECT().List(gomock.Any(), NotificationFilter{Status: models.NotificationFailed, UserID: 3, Limit: maxListLimit}).
+		Return([]models.Notification{
+			{ID: 1, Status: models.NotificationFailed, Kind: models.NotificationAlertOpened, Body: "alert"},
+			{ID: 2, Status: models.NotificationFailed, Kind: models.NotificationPasswordReset, Body: "token=secret"},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	handler.List(w, httptest.NewRequest("GET", "/notifications?status=failed&user_id=3&limit=500", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	// Isi email reset password memuat token dan tidak ikut ditampilkan
+	if body := w.Body.String(); strings.Contains(body, "secret") || !strings.Contains(body, "alert") {
+		t.Errorf("expected the reset email body to be hidden, got %s", body)
+	}
+
+	w = httptest.NewRecorder()
+	handler.List(w, httptest.NewRequest("GET", "/notifications?status=queued", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown status, got %d", w.Code)
+	}
+}
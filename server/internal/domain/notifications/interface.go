@@ -0,0 +1,37 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+	"tsukamoto/internal/models"
+)
+
+// ErrUserNotFound is returned when the user does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+type NotificationRepository interface {
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	// GetPreference returns the stored preference or the default one when the user has none
+	GetPreference(ctx context.Context, userID uint) (*models.NotificationPreference, error)
+	SavePreference(ctx context.Context, preference *models.NotificationPreference) error
+	// GetPreviousAssessment returns the latest assessment of the user with method
+	// stored before beforeID, or nil when there is none
+	GetPreviousAssessment(ctx context.Context, userID uint, method string, beforeID int) (*models.Assessment, error)
+	// GetStudentAdvisor returns the student's advisor (users.advisor_id), or
+	// nil when none is assigned
+	GetStudentAdvisor(ctx context.Context, userID uint) (*models.User, error)
+	Create(ctx context.Context, notification *models.Notification) error
+	Update(ctx context.Context, notification *models.Notification) error
+	// ClaimDue returns up to limit pending notifications due at now and postpones
+	// them by lease, so that no other worker picks them up while they are sent
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Notification, error)
+	List(ctx context.Context, filter NotificationFilter) ([]models.Notification, error)
+}
+
+type NotificationHandler interface {
+	GetPreference(w http.ResponseWriter, r *http.Request)
+	UpdatePreference(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+}
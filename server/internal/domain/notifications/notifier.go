@@ -0,0 +1,245 @@
+package notifications
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/mailer"
+	"tsukamoto/internal/models"
+
+	"github.com/sirupsen/logrus"
+)
+
+// claimLease is how long a claimed notification stays hidden from other
+// workers; it must exceed the time needed to send one email
+const claimLease = 5 * time.Minute
+
+// Options configures the email queue. A failed notification is retried after
+// Backoff, doubling for each further attempt, until MaxAttempts is reached.
+type Options struct {
+	From         string
+	MaxAttempts  int
+	Backoff      time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// DefaultOptions retries five times starting one minute apart and polls the queue every 30 seconds
+func DefaultOptions() Options {
+	return Options{
+		From:         mailer.DefaultFrom,
+		MaxAttempts:  5,
+		Backoff:      time.Minute,
+		PollInterval: 30 * time.Second,
+		BatchSize:    20,
+	}
+}
+
+// OptionsFromConfig reads the email settings, keeping the default for any
+// value that is missing or invalid
+func OptionsFromConfig(cfg *config.Config) Options {
+	options := DefaultOptions()
+	options.From = mailer.FromAddress(cfg)
+	if value, err := strconv.Atoi(cfg.EmailMaxAttempts); err == nil && value > 0 {
+		options.MaxAttempts = value
+	}
+	if value, err := time.ParseDuration(cfg.EmailBackoff); err == nil && value > 0 {
+		options.Backoff = value
+	}
+	if value, err := time.ParseDuration(cfg.EmailPollInterval); err == nil && value > 0 {
+		options.PollInterval = value
+	}
+	return options
+}
+
+// Notifier turns application events into queued emails for the student and
+// their advisor, and sends the queue through a mailer.Sender.
+type Notifier struct {
+	repo    NotificationRepository
+	sender  mailer.Sender
+	options Options
+	now     func() time.Time
+}
+
+// NewNotifier creates a Notifier sending through sender
+func NewNotifier(repo NotificationRepository, sender mailer.Sender, options Options) *Notifier {
+	return &Notifier{repo: repo, sender: sender, options: options, now: time.Now}
+}
+
+// Subscribe queues notifications for category changes and opened alerts
+func (n *Notifier) Subscribe(bus *events.Bus) {
+	bus.Subscribe(events.AssessmentCreated, n.HandleAssessmentCreated)
+	bus.Subscribe(events.AlertOpened, n.HandleAlertOpened)
+}
+
+// HandleAssessmentCreated notifies when the category differs from the previous
+// assessment with the same method
+func (n *Notifier) HandleAssessmentCreated(ctx context.Context, event events.Event) {
+	assessment, ok := event.Payload.(*models.Assessment)
+	if !ok {
+		return
+	}
+
+	previous, err := n.repo.GetPreviousAssessment(ctx, assessment.UserID, assessment.Method, assessment.ID)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", assessment.UserID).Warn("Failed to load previous assessment for notification")
+		return
+	}
+	if previous == nil || previous.Category == assessment.Category {
+		return
+	}
+
+	n.notify(ctx, assessment.UserID, models.NotificationCategoryChanged, TemplateData{
+		Category:         assessment.Category,
+		PreviousCategory: previous.Category,
+		Score:            assessment.CrispOutput,
+		PreviousScore:    previous.CrispOutput,
+	})
+}
+
+// HandleAlertOpened notifies about a newly opened early-warning alert
+func (n *Notifier) HandleAlertOpened(ctx context.Context, event events.Event) {
+	alert, ok := event.Payload.(*models.Alert)
+	if !ok {
+		return
+	}
+
+	data := TemplateData{
+		Score:     alert.CrispOutput,
+		Reason:    alert.Reason,
+		Threshold: alert.Threshold,
+	}
+	if alert.PreviousScore != nil {
+		data.PreviousScore = *alert.PreviousScore
+	}
+	n.notify(ctx, alert.UserID, models.NotificationAlertOpened, data)
+}
+
+// notify queues the notification for the student and their advisor, logging failures
+func (n *Notifier) notify(ctx context.Context, studentID uint, kind string, data TemplateData) {
+	log := logrus.WithFields(logrus.Fields{"user_id": studentID, "kind": kind})
+
+	student, err := n.repo.GetUserByID(ctx, studentID)
+	if err != nil {
+		log.WithError(err).Warn("Failed to load student for notification")
+		return
+	}
+	data.StudentName = displayName(student)
+	if err := n.Enqueue(ctx, student, kind, data); err != nil {
+		log.WithError(err).Warn("Failed to queue student notification")
+	}
+
+	advisor, err := n.repo.GetStudentAdvisor(ctx, studentID)
+	if err != nil {
+		log.WithError(err).Warn("Failed to load advisor for notification")
+		return
+	}
+	if advisor == nil {
+		return
+	}
+	data.ForAdvisor = true
+	if err := n.Enqueue(ctx, advisor, kind, data); err != nil {
+		log.WithError(err).Warn("Failed to queue advisor notification")
+	}
+}
+
+// Enqueue renders the notification in the recipient's language and queues it.
+// Recipients without an email address or who opted out of kind are skipped.
+func (n *Notifier) Enqueue(ctx context.Context, recipient *models.User, kind string, data TemplateData) error {
+	if recipient.Email == "" {
+		return nil
+	}
+	preference, err := n.repo.GetPreference(ctx, uint(recipient.ID))
+	if err != nil {
+		return err
+	}
+	if !preference.Allows(kind) {
+		return nil
+	}
+
+	data.RecipientName = displayName(recipient)
+	subject, body, err := Render(kind, preference.Language, data)
+	if err != nil {
+		return err
+	}
+
+	return n.repo.Create(ctx, &models.Notification{
+		UserID:        uint(recipient.ID),
+		Recipient:     recipient.Email,
+		Kind:          kind,
+		Language:      preference.Language,
+		Subject:       subject,
+		Body:          body,
+		Status:        models.NotificationPending,
+		NextAttemptAt: n.now(),
+	})
+}
+
+// ProcessDue sends the notifications that are due and returns how many were
+// sent. Failed sends are rescheduled with exponential backoff and marked
+// failed after the last attempt.
+func (n *Notifier) ProcessDue(ctx context.Context) (int, error) {
+	notifications, err := n.repo.ClaimDue(ctx, n.now(), claimLease, n.options.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range notifications {
+		notification := &notifications[i]
+		notification.Attempts++
+
+		err := n.sender.Send(ctx, mailer.Message{
+			From:    n.options.From,
+			To:      []string{notification.Recipient},
+			Subject: notification.Subject,
+			Body:    notification.Body,
+		})
+		now := n.now()
+		switch {
+		case err == nil:
+			notification.Status = models.NotificationSent
+			notification.LastError = ""
+			notification.SentAt = &now
+			sent++
+		case notification.Attempts >= n.options.MaxAttempts:
+			notification.Status = models.NotificationFailed
+			notification.LastError = err.Error()
+		default:
+			notification.LastError = err.Error()
+			notification.NextAttemptAt = now.Add(n.options.Backoff << (notification.Attempts - 1))
+		}
+
+		if err := n.repo.Update(ctx, notification); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// Run processes the queue every PollInterval until ctx is cancelled
+func (n *Notifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(n.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := n.ProcessDue(ctx); err != nil {
+			logrus.WithError(err).Warn("Failed to process email queue")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func displayName(user *models.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Username
+}
@@ -0,0 +1,218 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/mailer"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+)
+
+// recordingSender fails the first `failures` sends and records the rest
+type recordingSender struct {
+	failures int
+	sent     []mailer.Message
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg mailer.Message) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("connection refused")
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+var fixedNow = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func newTestNotifier(repo NotificationRepository, sender mailer.Sender) *Notifier {
+	notifier := NewNotifier(repo, sender, Options{From: "akademik@kampus.ac.id", MaxAttempts: 3, Backoff: time.Minute, PollInterval: time.Second, BatchSize: 10})
+	notifier.now = func() time.Time { return fixedNow }
+	return notifier
+}
+
+func defaultPreference(userID uint) *models.NotificationPreference {
+	return &models.NotificationPreference{UserID: userID, Language: models.LanguageIndonesian, OptOut: models.StringList{}}
+}
+
+func TestRender_BothLanguages(t *testing.T) {
+	data := TemplateData{RecipientName: "Budi", StudentName: "Budi", Category: "Poor", PreviousCategory: "Good", Score: 35, PreviousScore: 72.5}
+
+	subject, body, err := Render(models.NotificationCategoryChanged, models.LanguageIndonesian, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Kategori performa Anda berubah menjadi Buruk" || !strings.Contains(body, "dari Baik (skor 72.5) menjadi Buruk (skor 35.0)") {
+		t.Errorf("unexpected Indonesian email %q / %q", subject, body)
+	}
+
+	data.ForAdvisor = true
+	data.RecipientName = "Dr. Sari"
+	subject, body, err = Render(models.NotificationCategoryChanged, models.LanguageEnglish, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Budi's performance category changed to Poor" || !strings.Contains(body, "Hello Dr. Sari") || !strings.Contains(body, "your advisee Budi") {
+		t.Errorf("unexpected English email %q / %q", subject, body)
+	}
+}
+
+func TestRender_AlertReasons(t *testing.T) {
+	_, body, _ := Render(models.NotificationAlertOpened, models.LanguageEnglish, TemplateData{Reason: models.AlertReasonScoreDrop, Score: 50, PreviousScore: 70, Threshold: 10})
+	if !strings.Contains(body, "fell from 70.0 to 50.0, more than the limit of 10.0 points") {
+		t.Errorf("unexpected score drop body %q", body)
+	}
+
+	_, body, _ = Render(models.NotificationAlertOpened, "fr", TemplateData{Reason: models.AlertReasonBelowThreshold, Score: 50, Threshold: 60})
+	if !strings.Contains(body, "Skor 50.0 berada di bawah ambang 60.0") {
+		t.Errorf("expected Indonesian fallback, got %q", body)
+	}
+
+	if _, _, err := Render("unknown", models.LanguageEnglish, TemplateData{}); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestOptionsFromConfig(t *testing.T) {
+	options := OptionsFromConfig(&config.Config{SMTPFrom: "a@b.c", EmailMaxAttempts: "2", EmailBackoff: "10s", EmailPollInterval: "-1s"})
+	if options.From != "a@b.c" || options.MaxAttempts != 2 || options.Backoff != 10*time.Second || options.PollInterval != DefaultOptions().PollInterval {
+		t.Errorf("unexpected options %+v", options)
+	}
+}
+
+func TestNotifier_CategoryChangeQueuesStudentAndAdvisor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	notifier := newTestNotifier(mockRepo, &recordingSender{})
+	bus := events.NewBus()
+	notifier.Subscribe(bus)
+
+	mockRepo.EXPECT().GetPreviousAssessment(gomock.Any(), uint(1), "tsukamoto", 12).
+		Return(&models.Assessment{ID: 11, Category: "Good", CrispOutput: 72}, nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(&models.User{ID: 1, Name: "Budi", Email: "budi@kampus.ac.id"}, nil)
+	mockRepo.EXPECT().GetStudentAdvisor(gomock.Any(), uint(1)).Return(&models.User{ID: 4, Name: "Dr. Sari", Email: "sari@kampus.ac.id"}, nil)
+	mockRepo.EXPECT().GetPreference(gomock.Any(), uint(1)).Return(defaultPreference(1), nil)
+	mockRepo.EXPECT().GetPreference(gomock.Any(), uint(4)).Return(&models.NotificationPreference{UserID: 4, Language: models.LanguageEnglish}, nil)
+
+	var queued []*models.Notification
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *models.Notification) error {
+		queued = append(queued, n)
+		return nil
+	}).Times(2)
+
+	bus.Publish(context.Background(), events.AssessmentCreated, &models.Assessment{ID: 12, UserID: 1, Method: "tsukamoto", Category: "Needs Improvement", CrispOutput: 48})
+
+	if len(queued) != 2 {
+		t.Fatalf("expected two notifications, got %d", len(queued))
+	}
+	student, advisor := queued[0], queued[1]
+	if student.Recipient != "budi@kampus.ac.id" || student.Language != "id" || student.Status != models.NotificationPending || !student.NextAttemptAt.Equal(fixedNow) {
+		t.Errorf("unexpected student notification %+v", student)
+	}
+	if !strings.Contains(student.Subject, "Perlu Perbaikan") {
+		t.Errorf("unexpected student subject %q", student.Subject)
+	}
+	if advisor.Recipient != "sari@kampus.ac.id" || advisor.Language != "en" || !strings.Contains(advisor.Body, "your advisee Budi") {
+		t.Errorf("unexpected advisor notification %+v", advisor)
+	}
+}
+
+func TestNotifier_SameCategoryIsIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	notifier := newTestNotifier(mockRepo, &recordingSender{})
+
+	mockRepo.EXPECT().GetPreviousAssessment(gomock.Any(), uint(1), "tsukamoto", 12).
+		Return(&models.Assessment{ID: 11, Category: "Good"}, nil)
+
+	notifier.HandleAssessmentCreated(context.Background(), events.Event{Payload: &models.Assessment{ID: 12, UserID: 1, Method: "tsukamoto", Category: "Good"}})
+}
+
+func TestNotifier_AlertOpenedRespectsOptOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	notifier := newTestNotifier(mockRepo, &recordingSender{})
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(&models.User{ID: 1, Username: "budi", Email: "budi@kampus.ac.id"}, nil)
+	mockRepo.EXPECT().GetPreference(gomock.Any(), uint(1)).
+		Return(&models.NotificationPreference{UserID: 1, Language: "id", OptOut: models.StringList{models.NotificationAlertOpened}}, nil)
+	// Advisor without an email address is skipped without loading preferences
+	mockRepo.EXPECT().GetStudentAdvisor(gomock.Any(), uint(1)).Return(&models.User{ID: 4, Name: "Dr. Sari"}, nil)
+
+	notifier.HandleAlertOpened(context.Background(), events.Event{Payload: &models.Alert{UserID: 1, Reason: models.AlertReasonBelowThreshold, CrispOutput: 40, Threshold: 60}})
+}
+
+func TestNotifier_ProcessDueRetriesWithBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	sender := &recordingSender{failures: 2}
+	notifier := newTestNotifier(mockRepo, sender)
+
+	due := []models.Notification{
+		{ID: 1, Recipient: "a@kampus.ac.id", Subject: "A", Body: "a", Status: models.NotificationPending},
+		{ID: 2, Recipient: "b@kampus.ac.id", Subject: "B", Body: "b", Status: models.NotificationPending, Attempts: 2},
+		{ID: 3, Recipient: "c@kampus.ac.id", Subject: "C", Body: "c", Status: models.NotificationPending, Attempts: 1},
+	}
+	mockRepo.EXPECT().ClaimDue(gomock.Any(), fixedNow, claimLease, 10).Return(due, nil)
+
+	updated := map[int]models.Notification{}
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *models.Notification) error {
+		updated[n.ID] = *n
+		return nil
+	}).Times(3)
+
+	sent, err := notifier.ProcessDue(context.Background())
+	if err != nil || sent != 1 {
+		t.Fatalf("expected one sent notification, got %d (%v)", sent, err)
+	}
+
+	if first := updated[1]; first.Status != models.NotificationPending || first.Attempts != 1 || !first.NextAttemptAt.Equal(fixedNow.Add(time.Minute)) || first.LastError == "" {
+		t.Errorf("first notification must be retried in one minute: %+v", first)
+	}
+	if second := updated[2]; second.Status != models.NotificationFailed || second.Attempts != 3 {
+		t.Errorf("second notification must fail after the last attempt: %+v", second)
+	}
+	if third := updated[3]; third.Status != models.NotificationSent || third.SentAt == nil || third.LastError != "" {
+		t.Errorf("third notification must be sent: %+v", third)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].From != "akademik@kampus.ac.id" || sender.sent[0].To[0] != "c@kampus.ac.id" {
+		t.Errorf("unexpected sent messages %+v", sender.sent)
+	}
+}
+
+func TestNotifier_DevModeWritesQueuedEmails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockNotificationRepository(ctrl)
+	dir := t.TempDir()
+	notifier := newTestNotifier(mockRepo, mailer.FromConfig(&config.Config{EmailDevDir: dir}))
+
+	mockRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]models.Notification{{ID: 1, Recipient: "budi@kampus.ac.id", Subject: "Peringatan dini untuk Anda", Body: "Halo Budi"}}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	if _, err := notifier.ProcessDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v", files)
+	}
+	raw, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(raw), "To: budi@kampus.ac.id") || !strings.Contains(string(raw), "Halo Budi") {
+		t.Errorf("unexpected message %s", raw)
+	}
+}
@@ -0,0 +1,118 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"time"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *notificationRepository) GetPreference(ctx context.Context, userID uint) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.NotificationPreference{
+			UserID:   userID,
+			Language: models.LanguageIndonesian,
+			OptOut:   models.StringList{},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *notificationRepository) SavePreference(ctx context.Context, preference *models.NotificationPreference) error {
+	return r.db.WithContext(ctx).Save(preference).Error
+}
+
+func (r *notificationRepository) GetPreviousAssessment(ctx context.Context, userID uint, method string, beforeID int) (*models.Assessment, error) {
+	var assessment models.Assessment
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND method = ? AND id < ?", userID, method, beforeID).
+		Order("id DESC").
+		First(&assessment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+func (r *notificationRepository) GetStudentAdvisor(ctx context.Context, userID uint) (*models.User, error) {
+	advisorID := r.db.Model(&models.User{}).Select("advisor_id").Where("id = ?", userID)
+
+	var advisor models.User
+	err := r.db.WithContext(ctx).Where("id = (?)", advisorID).First(&advisor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &advisor, nil
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+func (r *notificationRepository) Update(ctx context.Context, notification *models.Notification) error {
+	return r.db.WithContext(ctx).Save(notification).Error
+}
+
+func (r *notificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Notification, error) {
+	due := r.db.Model(&models.Notification{}).
+		Select("id").
+		Where("status = ? AND next_attempt_at <= ?", models.NotificationPending, now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+	var notifications []models.Notification
+	err := r.db.WithContext(ctx).
+		Model(&notifications).
+		Clauses(clause.Returning{}).
+		Where("id IN (?)", due).
+		Update("next_attempt_at", now.Add(lease)).Error
+	return notifications, err
+}
+
+func (r *notificationRepository) List(ctx context.Context, filter NotificationFilter) ([]models.Notification, error) {
+	query := r.db.WithContext(ctx).Order("created_at DESC").Limit(filter.Limit)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	var notifications []models.Notification
+	err := query.Find(&notifications).Error
+	return notifications, err
+}
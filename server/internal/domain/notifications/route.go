@@ -0,0 +1,33 @@
+package notifications
+
+import (
+	"context"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/mailer"
+	"tsukamoto/internal/tenant"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// NotificationRoute registers the notification routes. When email is
+// configured it also queues emails for category changes and opened alerts and
+// starts the worker that sends them.
+func NotificationRoute(r *mux.Router, db *gorm.DB, bus *events.Bus, cfg *config.Config) {
+	repo := NewNotificationRepository(db)
+	handler := NewNotificationHandler(repo)
+
+	if sender := mailer.FromConfig(cfg); sender != nil {
+		notifier := NewNotifier(repo, sender, OptionsFromConfig(cfg))
+		notifier.Subscribe(bus)
+		go notifier.Run(tenant.WithSystem(context.Background()))
+	} else {
+		logrus.Info("Email notifications disabled: set SMTP_HOST or EMAIL_DEV_DIR to enable them")
+	}
+
+	r.HandleFunc("/notifications", handler.List).Methods("GET")
+	r.HandleFunc("/users/{id}/notification-preferences", handler.GetPreference).Methods("GET")
+	r.HandleFunc("/users/{id}/notification-preferences", handler.UpdatePreference).Methods("PUT")
+}
@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/penjelasan"
+)
+
+// TemplateData fills the notification templates. Categories are given in
+// English and translated for Indonesian emails.
+type TemplateData struct {
+	RecipientName    string
+	StudentName      string
+	ForAdvisor       bool
+	Category         string
+	PreviousCategory string
+	Score            float64
+	PreviousScore    float64
+	Reason           string
+	Threshold        float64
+}
+
+type emailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+const footerID = `
+
+--
+Email ini dikirim otomatis oleh sistem evaluasi akademik Tsukamoto.
+Anda dapat berhenti menerima email ini melalui pengaturan notifikasi.`
+
+const footerEN = `
+
+--
+This email was sent automatically by the Tsukamoto academic evaluation system.
+You can stop receiving these emails in your notification settings.`
+
+var templates = map[string]map[string]emailTemplate{
+	models.NotificationCategoryChanged: {
+		models.LanguageIndonesian: parse(
+			`Kategori performa {{if .ForAdvisor}}{{.StudentName}}{{else}}Anda{{end}} berubah menjadi {{.Category}}`,
+			`Halo {{.RecipientName}},
+
+{{if .ForAdvisor}}Kategori performa akademik mahasiswa bimbingan Anda, {{.StudentName}},{{else}}Kategori performa akademik Anda{{end}} berubah dari {{.PreviousCategory}} (skor {{score .PreviousScore}}) menjadi {{.Category}} (skor {{score .Score}}).`+footerID),
+		models.LanguageEnglish: parse(
+			`{{if .ForAdvisor}}{{.StudentName}}'s{{else}}Your{{end}} performance category changed to {{.Category}}`,
+			`Hello {{.RecipientName}},
+
+{{if .ForAdvisor}}The academic performance category of your advisee {{.StudentName}}{{else}}Your academic performance category{{end}} changed from {{.PreviousCategory}} (score {{score .PreviousScore}}) to {{.Category}} (score {{score .Score}}).`+footerEN),
+	},
+	models.NotificationAlertOpened: {
+		models.LanguageIndonesian: parse(
+			`Peringatan dini untuk {{if .ForAdvisor}}{{.StudentName}}{{else}}Anda{{end}}`,
+			`Halo {{.RecipientName}},
+
+Sistem peringatan dini membuka alert untuk {{if .ForAdvisor}}mahasiswa bimbingan Anda, {{.StudentName}}{{else}}Anda{{end}}. {{if eq .Reason "score_drop"}}Skor turun dari {{score .PreviousScore}} menjadi {{score .Score}}, lebih dari batas {{score .Threshold}} poin.{{else}}Skor {{score .Score}} berada di bawah ambang {{score .Threshold}}.{{end}}
+
+{{if .ForAdvisor}}Mohon tindak lanjuti alert ini bersama mahasiswa yang bersangkutan.{{else}}Silakan hubungi dosen pembimbing Anda untuk mendiskusikan langkah perbaikan.{{end}}`+footerID),
+		models.LanguageEnglish: parse(
+			`Early warning for {{if .ForAdvisor}}{{.StudentName}}{{else}}you{{end}}`,
+			`Hello {{.RecipientName}},
+
+The early-warning system opened an alert for {{if .ForAdvisor}}your advisee {{.StudentName}}{{else}}you{{end}}. {{if eq .Reason "score_drop"}}The score fell from {{score .PreviousScore}} to {{score .Score}}, more than the limit of {{score .Threshold}} points.{{else}}The score of {{score .Score}} is below the threshold of {{score .Threshold}}.{{end}}
+
+{{if .ForAdvisor}}Please follow up on this alert with the student.{{else}}Please contact your academic advisor to discuss how to improve.{{end}}`+footerEN),
+	},
+}
+
+var funcs = template.FuncMap{
+	"score": func(value float64) string { return fmt.Sprintf("%.1f", value) },
+}
+
+func parse(subject, body string) emailTemplate {
+	return emailTemplate{
+		subject: template.Must(template.New("subject").Funcs(funcs).Parse(subject)),
+		body:    template.Must(template.New("body").Funcs(funcs).Parse(body)),
+	}
+}
+
+// Render produces the subject and body of a notification of kind in language,
+// falling back to Indonesian for unknown languages
+func Render(kind, language string, data TemplateData) (string, string, error) {
+	byLanguage, ok := templates[kind]
+	if !ok {
+		return "", "", fmt.Errorf("unknown notification kind %q", kind)
+	}
+	tmpl, ok := byLanguage[language]
+	if !ok {
+		language = models.LanguageIndonesian
+		tmpl = byLanguage[language]
+	}
+
+	if language == models.LanguageIndonesian {
+		data.Category = penjelasan.CategoryLabel(data.Category)
+		data.PreviousCategory = penjelasan.CategoryLabel(data.PreviousCategory)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
+
+// Kinds lists the notification kinds users can opt out of
+func Kinds() []string {
+	return []string{models.NotificationCategoryChanged, models.NotificationAlertOpened}
+}
@@ -0,0 +1,9 @@
+package terms
+
+// TermRequest creates or replaces a term. Dates use the YYYY-MM-DD format.
+type TermRequest struct {
+	AcademicYear string `json:"academic_year"`
+	Semester     string `json:"semester"`
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+}
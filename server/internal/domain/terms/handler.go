@@ -0,0 +1,186 @@
+package terms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+const dateLayout = "2006-01-02"
+
+type termHandler struct {
+	repo TermRepository
+}
+
+func NewTermHandler(repo TermRepository) TermHandler {
+	return &termHandler{repo: repo}
+}
+
+// parseTerm validates the request and converts it into a term. The academic
+// year must span two consecutive years, e.g. 2025/2026.
+func parseTerm(req TermRequest) (models.Term, []utils.ErrorDetail) {
+	var errs []utils.ErrorDetail
+	term := models.Term{AcademicYear: req.AcademicYear, Semester: req.Semester}
+
+	var first, second int
+	if n, err := fmt.Sscanf(req.AcademicYear, "%4d/%4d", &first, &second); err != nil || n != 2 || len(req.AcademicYear) != 9 || second != first+1 {
+		errs = append(errs, utils.ErrorDetail{Field: "academic_year", Message: "Tahun akademik harus berformat YYYY/YYYY, misalnya 2025/2026"})
+	}
+
+	switch req.Semester {
+	case models.SemesterOdd, models.SemesterEven, models.SemesterShort:
+	default:
+		errs = append(errs, utils.ErrorDetail{Field: "semester", Message: "Semester harus 'odd', 'even' atau 'short'"})
+	}
+
+	var err error
+	if term.StartDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+		errs = append(errs, utils.ErrorDetail{Field: "start_date", Message: "Tanggal mulai harus berformat YYYY-MM-DD"})
+	}
+	if term.EndDate, err = time.Parse(dateLayout, req.EndDate); err != nil {
+		errs = append(errs, utils.ErrorDetail{Field: "end_date", Message: "Tanggal selesai harus berformat YYYY-MM-DD"})
+	}
+	if len(errs) == 0 && !term.EndDate.After(term.StartDate) {
+		errs = append(errs, utils.ErrorDetail{Field: "end_date", Message: "Tanggal selesai harus setelah tanggal mulai"})
+	}
+	return term, errs
+}
+
+// checkPeriod rejects a period that already belongs to another term
+func (h *termHandler) checkPeriod(w http.ResponseWriter, r *http.Request, term models.Term) bool {
+	existing, err := h.repo.GetByPeriod(r.Context(), term.AcademicYear, term.Semester)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa data term"}}, nil)
+		return false
+	}
+	if existing != nil && existing.ID != term.ID {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Field: "semester", Message: "Term untuk tahun akademik dan semester ini sudah ada"}}, nil)
+		return false
+	}
+	return true
+}
+
+// Create handles POST /terms
+func (h *termHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req TermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	term, errs := parseTerm(req)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+	if !h.checkPeriod(w, r, term) {
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), &term); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan term"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, nil, term)
+}
+
+// GetAll handles GET /terms
+func (h *termHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	terms, err := h.repo.GetAll(r.Context())
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data term"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, terms)
+}
+
+// GetByID handles GET /terms/:id
+func (h *termHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	term, ok := h.loadTerm(w, r)
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, term)
+}
+
+// Update handles PUT /terms/:id
+func (h *termHandler) Update(w http.ResponseWriter, r *http.Request) {
+	existing, ok := h.loadTerm(w, r)
+	if !ok {
+		return
+	}
+
+	var req TermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	term, errs := parseTerm(req)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+	term.ID = existing.ID
+	term.CreatedAt = existing.CreatedAt
+	if !h.checkPeriod(w, r, term) {
+		return
+	}
+
+	if err := h.repo.Update(r.Context(), &term); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memperbarui term"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, term)
+}
+
+// Delete handles DELETE /terms/:id. Terms that still have academic records cannot be deleted.
+func (h *termHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	term, ok := h.loadTerm(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := h.repo.CountAcademics(r.Context(), term.ID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa data akademik term"}}, nil)
+		return
+	}
+	if count > 0 {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Message: fmt.Sprintf("Term masih dipakai oleh %d data akademik", count)}}, nil)
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), term.ID); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghapus term"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, map[string]string{"message": "Term berhasil dihapus"})
+}
+
+// loadTerm reads the term in the URL, writing the error response when it cannot be loaded
+func (h *termHandler) loadTerm(w http.ResponseWriter, r *http.Request) (*models.Term, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "id", Message: "ID term tidak valid"}}, nil)
+		return nil, false
+	}
+
+	term, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrTermNotFound) {
+			utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Term tidak ditemukan"}}, nil)
+			return nil, false
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data term"}}, nil)
+		return nil, false
+	}
+	return term, true
+}
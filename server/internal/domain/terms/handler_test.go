@@ -0,0 +1,176 @@
+package terms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const (
+	termsPath   = "/terms"
+	termsPathID = "/terms/1"
+)
+
+func withID(req *http.Request) *http.Request {
+	return mux.SetURLVars(req, map[string]string{"id": "1"})
+}
+
+func jsonBody(v interface{}) *bytes.Reader {
+	body, _ := json.Marshal(v)
+	return bytes.NewReader(body)
+}
+
+func validRequest() TermRequest {
+	return TermRequest{AcademicYear: "2025/2026", Semester: models.SemesterOdd, StartDate: "2025-08-25", EndDate: "2026-01-16"}
+}
+
+func TestTermHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockTermRepository(ctrl)
+	handler := NewTermHandler(mockRepo)
+
+	mockRepo.EXPECT().GetByPeriod(gomock.Any(), "2025/2026", models.SemesterOdd).Return(nil, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, term *models.Term) error {
+		if !term.StartDate.Equal(time.Date(2025, 8, 25, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected start date %v", term.StartDate)
+		}
+		term.ID = 1
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.Create(w, httptest.NewRequest("POST", termsPath, jsonBody(validRequest())))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTermHandler_Create_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*TermRequest)
+		field  string
+	}{
+		{"year format", func(r *TermRequest) { r.AcademicYear = "2025" }, "academic_year"},
+		{"non-consecutive years", func(r *TermRequest) { r.AcademicYear = "2025/2027" }, "academic_year"},
+		{"unknown semester", func(r *TermRequest) { r.Semester = "third" }, "semester"},
+		{"bad date", func(r *TermRequest) { r.StartDate = "25-08-2025" }, "start_date"},
+		{"end before start", func(r *TermRequest) { r.EndDate = "2025-08-01" }, "end_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			handler := NewTermHandler(NewMockTermRepository(ctrl))
+
+			req := validRequest()
+			tt.modify(&req)
+			w := httptest.NewRecorder()
+			handler.Create(w, httptest.NewRequest("POST", termsPath, jsonBody(req)))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", w.Code)
+			}
+
+			var response struct {
+				Errors []map[string]string `json:"errors"`
+			}
+			json.NewDecoder(w.Body).Decode(&response)
+			if len(response.Errors) != 1 || response.Errors[0]["field"] != tt.field {
+				t.Errorf("expected one %s error, got %v", tt.field, response.Errors)
+			}
+		})
+	}
+}
+
+func TestTermHandler_Create_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockTermRepository(ctrl)
+	handler := NewTermHandler(mockRepo)
+
+	mockRepo.EXPECT().GetByPeriod(gomock.Any(), "2025/2026", models.SemesterOdd).Return(&models.Term{ID: 4}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Create(w, httptest.NewRequest("POST", termsPath, jsonBody(validRequest())))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestTermHandler_Update_SamePeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockTermRepository(ctrl)
+	handler := NewTermHandler(mockRepo)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.Term{ID: 1, AcademicYear: "2025/2026", Semester: models.SemesterOdd}, nil)
+	// The term itself holds the period, which is not a conflict
+	mockRepo.EXPECT().GetByPeriod(gomock.Any(), "2025/2026", models.SemesterOdd).Return(&models.Term{ID: 1}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	req := validRequest()
+	req.EndDate = "2026-01-30"
+	w := httptest.NewRecorder()
+	handler.Update(w, withID(httptest.NewRequest("PUT", termsPathID, jsonBody(req))))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTermHandler_GetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockTermRepository(ctrl)
+	handler := NewTermHandler(mockRepo)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, ErrTermNotFound)
+
+	w := httptest.NewRecorder()
+	handler.GetByID(w, withID(httptest.NewRequest("GET", termsPathID, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestTermHandler_Delete_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockTermRepository(ctrl)
+	handler := NewTermHandler(mockRepo)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.Term{ID: 1}, nil)
+	mockRepo.EXPECT().CountAcademics(gomock.Any(), 1).Return(int64(12), nil)
+
+	w := httptest.NewRecorder()
+	handler.Delete(w, withID(httptest.NewRequest("DELETE", termsPathID, nil)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestTermHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockTermRepository(ctrl)
+	handler := NewTermHandler(mockRepo)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.Term{ID: 1}, nil)
+	mockRepo.EXPECT().CountAcademics(gomock.Any(), 1).Return(int64(0), nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+
+	w := httptest.NewRecorder()
+	handler.Delete(w, withID(httptest.NewRequest("DELETE", termsPathID, nil)))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,31 @@
+package terms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tsukamoto/internal/models"
+)
+
+// ErrTermNotFound is returned when the term does not exist
+var ErrTermNotFound = errors.New("term not found")
+
+type TermRepository interface {
+	Create(ctx context.Context, term *models.Term) error
+	// GetAll returns the terms from the most recent one
+	GetAll(ctx context.Context) ([]models.Term, error)
+	GetByID(ctx context.Context, id int) (*models.Term, error)
+	// GetByPeriod returns the term of an academic year and semester, or nil when there is none
+	GetByPeriod(ctx context.Context, academicYear, semester string) (*models.Term, error)
+	Update(ctx context.Context, term *models.Term) error
+	Delete(ctx context.Context, id int) error
+	CountAcademics(ctx context.Context, id int) (int64, error)
+}
+
+type TermHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,71 @@
+package terms
+
+import (
+	"context"
+	"errors"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type termRepository struct {
+	db *gorm.DB
+}
+
+func NewTermRepository(db *gorm.DB) TermRepository {
+	return &termRepository{db: db}
+}
+
+func (r *termRepository) Create(ctx context.Context, term *models.Term) error {
+	return r.db.WithContext(ctx).Create(term).Error
+}
+
+func (r *termRepository) GetAll(ctx context.Context) ([]models.Term, error) {
+	var terms []models.Term
+	err := r.db.WithContext(ctx).Order("start_date DESC").Find(&terms).Error
+	return terms, err
+}
+
+func (r *termRepository) GetByID(ctx context.Context, id int) (*models.Term, error) {
+	var term models.Term
+	if err := r.db.WithContext(ctx).First(&term, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTermNotFound
+		}
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *termRepository) GetByPeriod(ctx context.Context, academicYear, semester string) (*models.Term, error) {
+	var term models.Term
+	err := r.db.WithContext(ctx).Where("academic_year = ? AND semester = ?", academicYear, semester).First(&term).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *termRepository) Update(ctx context.Context, term *models.Term) error {
+	return r.db.WithContext(ctx).Save(term).Error
+}
+
+func (r *termRepository) Delete(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Delete(&models.Term{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTermNotFound
+	}
+	return nil
+}
+
+func (r *termRepository) CountAcademics(ctx context.Context, id int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Academic{}).Where("term_id = ?", id).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,17 @@
+package terms
+
+import (
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+func TermRoute(r *mux.Router, db *gorm.DB) {
+	repo := NewTermRepository(db)
+	handler := NewTermHandler(repo)
+
+	r.HandleFunc("/terms", handler.Create).Methods("POST")
+	r.HandleFunc("/terms", handler.GetAll).Methods("GET")
+	r.HandleFunc("/terms/{id}", handler.GetByID).Methods("GET")
+	r.HandleFunc("/terms/{id}", handler.Update).Methods("PUT")
+	r.HandleFunc("/terms/{id}", handler.Delete).Methods("DELETE")
+}
@@ -1,7 +1,39 @@
 package university
 
+// UniversityRequest creates or replaces a university. Zero scales fall back
+// to the defaults: GPA out of 4, attendance as a fraction and scores out of 100.
+type UniversityRequest struct {
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	GPAScale        float32 `json:"gpa_scale"`
+	AttendanceScale float32 `json:"attendance_scale"`
+	ScoreScale      float32 `json:"score_scale"`
+}
+
 type UniversityResponse struct {
-	ID      int    `json:"id"`
-	Name    string `json:"name"`
-	Address string `json:"address"`
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	GPAScale        float32 `json:"gpa_scale"`
+	AttendanceScale float32 `json:"attendance_scale"`
+	ScoreScale      float32 `json:"score_scale"`
+	StudentCount    int64   `json:"student_count"`
+}
+
+// MergeRequest names the duplicate universities folded into the one in the URL
+type MergeRequest struct {
+	SourceIDs []int `json:"source_ids"`
+}
+
+// MergeResult counts the records moved to the target university
+type MergeResult struct {
+	Academics int64 `json:"academics"`
+	Courses   int64 `json:"courses"`
+	Users     int64 `json:"users"`
+}
+
+type MergeResponse struct {
+	University UniversityResponse `json:"university"`
+	MergedIDs  []int              `json:"merged_ids"`
+	Moved      MergeResult        `json:"moved"`
 }
@@ -2,18 +2,25 @@ package university
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
 
 	"github.com/gorilla/mux"
 )
 
 type universityHandler struct {
-	repo UniversityRepository
+	repo  UniversityRepository
+	audit *audit.Log
 }
 
-func NewUniversityHandler(repo UniversityRepository) UniversityHandler {
-	return &universityHandler{repo: repo}
+func NewUniversityHandler(repo UniversityRepository, log *audit.Log) UniversityHandler {
+	return &universityHandler{repo: repo, audit: log}
 }
 
 func (h *universityHandler) GetAll(w http.ResponseWriter, r *http.Request) {
@@ -37,7 +44,7 @@ func (h *universityHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 	university, err := h.repo.GetByID(r.Context(), id)
 	if err != nil {
-		if err.Error() == "university not found" {
+		if errors.Is(err, ErrUniversityNotFound) {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
@@ -48,3 +55,290 @@ func (h *universityHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(university)
 }
+
+// parseUniversity validates the request and converts it into a university
+func parseUniversity(req UniversityRequest) (models.University, []utils.ErrorDetail) {
+	var errs []utils.ErrorDetail
+	university := models.University{
+		Name:            strings.TrimSpace(req.Name),
+		Address:         strings.TrimSpace(req.Address),
+		GPAScale:        req.GPAScale,
+		AttendanceScale: req.AttendanceScale,
+		ScoreScale:      req.ScoreScale,
+	}
+
+	if university.Name == "" {
+		errs = append(errs, utils.ErrorDetail{Field: "name", Message: "Nama universitas diperlukan"})
+	} else if len(university.Name) > 100 {
+		errs = append(errs, utils.ErrorDetail{Field: "name", Message: "Nama universitas maksimal 100 karakter"})
+	}
+	if len(university.Address) > 255 {
+		errs = append(errs, utils.ErrorDetail{Field: "address", Message: "Alamat maksimal 255 karakter"})
+	}
+
+	switch university.GPAScale {
+	case 0:
+		university.GPAScale = 4
+	case 4, 5:
+	default:
+		errs = append(errs, utils.ErrorDetail{Field: "gpa_scale", Message: "Skala GPA harus 4 atau 5"})
+	}
+	switch university.AttendanceScale {
+	case 0:
+		university.AttendanceScale = 1
+	case 1, 100:
+	default:
+		errs = append(errs, utils.ErrorDetail{Field: "attendance_scale", Message: "Skala kehadiran harus 1 (pecahan) atau 100 (persen)"})
+	}
+	if university.ScoreScale == 0 {
+		university.ScoreScale = 100
+	} else if university.ScoreScale < 0 {
+		errs = append(errs, utils.ErrorDetail{Field: "score_scale", Message: "Skala nilai harus lebih dari 0"})
+	}
+	return university, errs
+}
+
+// checkName rejects a name that already belongs to another university
+func (h *universityHandler) checkName(w http.ResponseWriter, r *http.Request, university models.University) bool {
+	existing, err := h.repo.GetByName(r.Context(), university.Name)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa data universitas"}}, nil)
+		return false
+	}
+	if existing != nil && existing.ID != university.ID {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Field: "name", Message: "Universitas dengan nama ini sudah ada"}}, nil)
+		return false
+	}
+	return true
+}
+
+func toResponse(university models.University, students int64) UniversityResponse {
+	return UniversityResponse{
+		ID:              university.ID,
+		Name:            university.Name,
+		Address:         university.Address,
+		GPAScale:        university.GPAScale,
+		AttendanceScale: university.AttendanceScale,
+		ScoreScale:      university.ScoreScale,
+		StudentCount:    students,
+	}
+}
+
+// withCounts adds the student counts, writing the error response when they cannot be loaded
+func (h *universityHandler) withCounts(w http.ResponseWriter, r *http.Request, universities []models.University) ([]UniversityResponse, bool) {
+	ids := make([]int, 0, len(universities))
+	for _, university := range universities {
+		ids = append(ids, university.ID)
+	}
+	counts, err := h.repo.CountStudents(r.Context(), ids)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghitung mahasiswa universitas"}}, nil)
+		return nil, false
+	}
+
+	responses := make([]UniversityResponse, 0, len(universities))
+	for _, university := range universities {
+		responses = append(responses, toResponse(university, counts[university.ID]))
+	}
+	return responses, true
+}
+
+// ListWithCounts handles GET /universities
+func (h *universityHandler) ListWithCounts(w http.ResponseWriter, r *http.Request) {
+	universities, err := h.repo.GetAll(r.Context())
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data universitas"}}, nil)
+		return
+	}
+	responses, ok := h.withCounts(w, r, universities)
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, responses)
+}
+
+// Create handles POST /university
+func (h *universityHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req UniversityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	university, errs := parseUniversity(req)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+	if !h.checkName(w, r, university) {
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), &university); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan universitas"}}, nil)
+		return
+	}
+	h.audit.Record(r, audit.Entry{Action: models.AuditCreate, EntityType: models.AuditEntityUniversity, EntityID: university.ID, After: university})
+	utils.WriteResponse(w, http.StatusCreated, nil, toResponse(university, 0))
+}
+
+// Update handles PUT /university/:id
+func (h *universityHandler) Update(w http.ResponseWriter, r *http.Request) {
+	existing, ok := h.loadUniversity(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req UniversityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	university, errs := parseUniversity(req)
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+	university.ID = existing.ID
+	university.CreatedAt = existing.CreatedAt
+	if !h.checkName(w, r, university) {
+		return
+	}
+
+	if err := h.repo.Update(r.Context(), &university); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memperbarui universitas"}}, nil)
+		return
+	}
+	h.audit.Record(r, audit.Entry{Action: models.AuditUpdate, EntityType: models.AuditEntityUniversity, EntityID: university.ID, Before: existing, After: university})
+
+	responses, ok := h.withCounts(w, r, []models.University{university})
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, responses[0])
+}
+
+// Delete handles DELETE /university/:id. Universities that still have users,
+// academic records or courses must be merged into another one instead.
+func (h *universityHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	university, ok := h.loadUniversity(w, r, "id")
+	if !ok {
+		return
+	}
+
+	count, err := h.repo.CountRecords(r.Context(), university.ID)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memeriksa data universitas"}}, nil)
+		return
+	}
+	if count > 0 {
+		utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{Message: fmt.Sprintf("Universitas masih dipakai oleh %d data; gabungkan ke universitas lain terlebih dahulu", count)}}, nil)
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), university.ID); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghapus universitas"}}, nil)
+		return
+	}
+	h.audit.Record(r, audit.Entry{Action: models.AuditDelete, EntityType: models.AuditEntityUniversity, EntityID: university.ID, Before: university})
+	utils.WriteResponse(w, http.StatusOK, nil, map[string]string{"message": "Universitas berhasil dihapus"})
+}
+
+// Merge handles POST /university/:id/merge. The academic records, courses and
+// users of every source university move to the one in the URL and the
+// sources are deleted.
+func (h *universityHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	target, ok := h.loadUniversity(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req MergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+	if len(req.SourceIDs) == 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "source_ids", Message: "Minimal satu universitas sumber diperlukan"}}, nil)
+		return
+	}
+
+	seen := map[int]bool{}
+	var sourceIDs []int
+	var errs []utils.ErrorDetail
+	for _, id := range req.SourceIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if id == target.ID {
+			errs = append(errs, utils.ErrorDetail{Field: "source_ids", Message: "Universitas tidak bisa digabungkan ke dirinya sendiri"})
+			continue
+		}
+		if _, err := h.repo.GetByID(r.Context(), id); err != nil {
+			if errors.Is(err, ErrUniversityNotFound) {
+				errs = append(errs, utils.ErrorDetail{Field: "source_ids", Message: fmt.Sprintf("Universitas %d tidak ditemukan", id)})
+				continue
+			}
+			utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data universitas"}}, nil)
+			return
+		}
+		sourceIDs = append(sourceIDs, id)
+	}
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	moved, err := h.repo.Merge(r.Context(), target.ID, sourceIDs)
+	if err != nil {
+		var conflict *CourseConflictError
+		if errors.As(err, &conflict) {
+			utils.WriteResponse(w, http.StatusConflict, []utils.ErrorDetail{{
+				Field:   "source_ids",
+				Message: "Kode mata kuliah dipakai di kedua universitas: " + strings.Join(conflict.Codes, ", "),
+			}}, nil)
+			return
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menggabungkan universitas"}}, nil)
+		return
+	}
+	h.audit.Record(r, audit.Entry{
+		Action:     models.AuditMerge,
+		EntityType: models.AuditEntityUniversity,
+		EntityID:   target.ID,
+		After: map[string]interface{}{
+			"merged_ids": sourceIDs,
+			"academics":  moved.Academics,
+			"courses":    moved.Courses,
+			"users":      moved.Users,
+		},
+	})
+
+	responses, ok := h.withCounts(w, r, []models.University{*target})
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, MergeResponse{University: responses[0], MergedIDs: sourceIDs, Moved: *moved})
+}
+
+// loadUniversity reads the university in the URL, writing the error response when it cannot be loaded
+func (h *universityHandler) loadUniversity(w http.ResponseWriter, r *http.Request, param string) (*models.University, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)[param])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: param, Message: "ID universitas tidak valid"}}, nil)
+		return nil, false
+	}
+
+	university, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrUniversityNotFound) {
+			utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Universitas tidak ditemukan"}}, nil)
+			return nil, false
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data universitas"}}, nil)
+		return nil, false
+	}
+	return university, true
+}
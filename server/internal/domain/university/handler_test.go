@@ -0,0 +1,244 @@
+package university
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const (
+	universityPath   = "/university"
+	universityPathID = "/university/1"
+)
+
+func withID(req *http.Request) *http.Request {
+	return mux.SetURLVars(req, map[string]string{"id": "1"})
+}
+
+func jsonRequest(method, path string, body interface{}) *http.Request {
+	data, _ := json.Marshal(body)
+	return httptest.NewRequest(method, path, bytes.NewReader(data))
+}
+
+func decodeErrors(w *httptest.ResponseRecorder) map[string]int {
+	var resp utils.Response
+	json.NewDecoder(w.Body).Decode(&resp)
+	fields := map[string]int{}
+	for _, e := range resp.Errors {
+		fields[e.Field]++
+	}
+	return fields
+}
+
+func TestUniversityHandler_Create_DefaultsScales(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	store := &audit.MemoryStore{}
+	handler := NewUniversityHandler(mockRepo, audit.NewLog(store))
+
+	mockRepo.EXPECT().GetByName(gomock.Any(), "Universitas Brawijaya").Return(nil, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *models.University) error {
+		if u.GPAScale != 4 || u.AttendanceScale != 1 || u.ScoreScale != 100 {
+			t.Errorf("expected default scales, got %+v", u)
+		}
+		u.ID = 3
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.Create(w, jsonRequest("POST", universityPath, UniversityRequest{Name: "  Universitas Brawijaya ", Address: "Malang"}))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(store.Entries) != 1 || store.Entries[0].EntityType != models.AuditEntityUniversity || store.Entries[0].EntityID != "3" {
+		t.Errorf("expected the creation to be audited, got %+v", store.Entries)
+	}
+}
+
+func TestUniversityHandler_Create_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	handler := NewUniversityHandler(NewMockUniversityRepository(ctrl), nil)
+
+	w := httptest.NewRecorder()
+	handler.Create(w, jsonRequest("POST", universityPath, UniversityRequest{GPAScale: 10, AttendanceScale: 50, ScoreScale: -1}))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	fields := decodeErrors(w)
+	for _, field := range []string{"name", "gpa_scale", "attendance_scale", "score_scale"} {
+		if fields[field] != 1 {
+			t.Errorf("expected an error for %s, got %v", field, fields)
+		}
+	}
+}
+
+func TestUniversityHandler_Create_DuplicateName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	handler := NewUniversityHandler(mockRepo, nil)
+
+	mockRepo.EXPECT().GetByName(gomock.Any(), "universitas brawijaya").Return(&models.University{ID: 1, Name: "Universitas Brawijaya"}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Create(w, jsonRequest("POST", universityPath, UniversityRequest{Name: "universitas brawijaya"}))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", w.Code)
+	}
+	if fields := decodeErrors(w); fields["name"] != 1 {
+		t.Errorf("expected a name error, got %v", fields)
+	}
+}
+
+func TestUniversityHandler_Update_KeepsOwnName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	handler := NewUniversityHandler(mockRepo, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.University{ID: 1, Name: "UB", GPAScale: 4, AttendanceScale: 1, ScoreScale: 100}, nil)
+	mockRepo.EXPECT().GetByName(gomock.Any(), "UB").Return(&models.University{ID: 1, Name: "UB"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CountStudents(gomock.Any(), []int{1}).Return(map[int]int64{1: 42}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Update(w, withID(jsonRequest("PUT", universityPathID, UniversityRequest{Name: "UB", AttendanceScale: 100})))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data UniversityResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Data.StudentCount != 42 || resp.Data.AttendanceScale != 100 {
+		t.Errorf("unexpected response %+v", resp.Data)
+	}
+}
+
+func TestUniversityHandler_ListWithCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	handler := NewUniversityHandler(mockRepo, nil)
+
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return([]models.University{{ID: 1, Name: "UB"}, {ID: 2, Name: "UM"}}, nil)
+	mockRepo.EXPECT().CountStudents(gomock.Any(), []int{1, 2}).Return(map[int]int64{1: 10}, nil)
+
+	w := httptest.NewRecorder()
+	handler.ListWithCounts(w, httptest.NewRequest("GET", "/universities", nil))
+	var resp struct {
+		Data []UniversityResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Data) != 2 || resp.Data[0].StudentCount != 10 || resp.Data[1].StudentCount != 0 {
+		t.Errorf("unexpected response %+v", resp.Data)
+	}
+}
+
+func TestUniversityHandler_Delete_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	handler := NewUniversityHandler(mockRepo, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.University{ID: 1}, nil)
+	mockRepo.EXPECT().CountRecords(gomock.Any(), 1).Return(int64(5), nil)
+
+	w := httptest.NewRecorder()
+	handler.Delete(w, withID(httptest.NewRequest("DELETE", universityPathID, nil)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestUniversityHandler_Delete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	handler := NewUniversityHandler(mockRepo, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, ErrUniversityNotFound)
+
+	w := httptest.NewRecorder()
+	handler.Delete(w, withID(httptest.NewRequest("DELETE", universityPathID, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestUniversityHandler_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	store := &audit.MemoryStore{}
+	handler := NewUniversityHandler(mockRepo, audit.NewLog(store))
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.University{ID: 1, Name: "UB"}, nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&models.University{ID: 2, Name: "U.B."}, nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 3).Return(&models.University{ID: 3, Name: "Brawijaya"}, nil)
+	mockRepo.EXPECT().Merge(gomock.Any(), 1, []int{2, 3}).Return(&MergeResult{Academics: 7, Users: 4}, nil)
+	mockRepo.EXPECT().CountStudents(gomock.Any(), []int{1}).Return(map[int]int64{1: 12}, nil)
+
+	w := httptest.NewRecorder()
+	handler.Merge(w, withID(jsonRequest("POST", "/university/1/merge", MergeRequest{SourceIDs: []int{2, 3, 2}})))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data MergeResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Data.University.StudentCount != 12 || resp.Data.Moved.Academics != 7 || len(resp.Data.MergedIDs) != 2 {
+		t.Errorf("unexpected response %+v", resp.Data)
+	}
+	if len(store.Entries) != 1 || store.Entries[0].Action != models.AuditMerge {
+		t.Errorf("expected the merge to be audited, got %+v", store.Entries)
+	}
+}
+
+func TestUniversityHandler_Merge_InvalidSources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	handler := NewUniversityHandler(mockRepo, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.University{ID: 1}, nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 9).Return(nil, ErrUniversityNotFound)
+
+	w := httptest.NewRecorder()
+	handler.Merge(w, withID(jsonRequest("POST", "/university/1/merge", MergeRequest{SourceIDs: []int{1, 9}})))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if fields := decodeErrors(w); fields["source_ids"] != 2 {
+		t.Errorf("expected self-merge and missing source errors, got %v", fields)
+	}
+}
+
+func TestUniversityHandler_Merge_CourseConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockUniversityRepository(ctrl)
+	handler := NewUniversityHandler(mockRepo, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.University{ID: 1}, nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&models.University{ID: 2}, nil)
+	mockRepo.EXPECT().Merge(gomock.Any(), 1, []int{2}).Return(nil, &CourseConflictError{Codes: []string{"IF101"}})
+
+	w := httptest.NewRecorder()
+	handler.Merge(w, withID(jsonRequest("POST", "/university/1/merge", MergeRequest{SourceIDs: []int{2}})))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
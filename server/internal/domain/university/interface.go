@@ -2,16 +2,46 @@ package university
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"tsukamoto/internal/models"
 )
 
+// ErrUniversityNotFound is returned when the university does not exist
+var ErrUniversityNotFound = errors.New("university not found")
+
+// CourseConflictError lists course codes that exist in both the target and a
+// merged university and would break the per-university code uniqueness
+type CourseConflictError struct {
+	Codes []string
+}
+
+func (e *CourseConflictError) Error() string {
+	return "course codes exist in both universities"
+}
+
 type UniversityRepository interface {
 	GetAll(ctx context.Context) ([]models.University, error)
 	GetByID(ctx context.Context, id int) (*models.University, error)
+	// GetByName returns the university with the name ignoring case, or nil when there is none
+	GetByName(ctx context.Context, name string) (*models.University, error)
+	Create(ctx context.Context, university *models.University) error
+	Update(ctx context.Context, university *models.University) error
+	Delete(ctx context.Context, id int) error
+	// CountStudents returns the number of students per university ID
+	CountStudents(ctx context.Context, ids []int) (map[int]int64, error)
+	// CountRecords returns how many users, academic records and courses belong to the university
+	CountRecords(ctx context.Context, id int) (int64, error)
+	// Merge moves the records of the sources to the target and deletes the sources
+	Merge(ctx context.Context, targetID int, sourceIDs []int) (*MergeResult, error)
 }
 
 type UniversityHandler interface {
 	GetAll(w http.ResponseWriter, r *http.Request)
 	GetByID(w http.ResponseWriter, r *http.Request)
+	ListWithCounts(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Merge(w http.ResponseWriter, r *http.Request)
 }
@@ -3,6 +3,7 @@ package university
 import (
 	"context"
 	"errors"
+	"strings"
 	"tsukamoto/internal/models"
 
 	"gorm.io/gorm"
@@ -18,15 +19,131 @@ func NewUniversityRepository(db *gorm.DB) UniversityRepository {
 
 func (r *universityRepository) GetAll(ctx context.Context) ([]models.University, error) {
 	var universities []models.University
-	err := r.db.WithContext(ctx).Find(&universities).Error
+	err := r.db.WithContext(ctx).Order("name").Find(&universities).Error
 	return universities, err
 }
 
 func (r *universityRepository) GetByID(ctx context.Context, id int) (*models.University, error) {
 	var university models.University
-	err := r.db.WithContext(ctx).First(&university, id).Error
+	if err := r.db.WithContext(ctx).First(&university, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUniversityNotFound
+		}
+		return nil, err
+	}
+	return &university, nil
+}
+
+func (r *universityRepository) GetByName(ctx context.Context, name string) (*models.University, error) {
+	var university models.University
+	err := r.db.WithContext(ctx).Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name))).First(&university).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, errors.New("university not found")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &university, nil
+}
+
+func (r *universityRepository) Create(ctx context.Context, university *models.University) error {
+	return r.db.WithContext(ctx).Create(university).Error
+}
+
+func (r *universityRepository) Update(ctx context.Context, university *models.University) error {
+	return r.db.WithContext(ctx).Save(university).Error
+}
+
+func (r *universityRepository) Delete(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Delete(&models.University{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUniversityNotFound
+	}
+	return nil
+}
+
+func (r *universityRepository) CountStudents(ctx context.Context, ids []int) (map[int]int64, error) {
+	counts := make(map[int]int64, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	// Akun lama hanya mencatat universitas pada data akademik
+	var rows []struct {
+		UniversityID int
+		Count        int64
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT university_id, COUNT(DISTINCT user_id) AS count FROM (
+			SELECT id AS user_id, university_id FROM users WHERE role = ? AND university_id IS NOT NULL
+			UNION
+			SELECT user_id, university_id FROM academics WHERE deleted_at IS NULL
+		) students
+		WHERE university_id IN ?
+		GROUP BY university_id`, models.RoleStudent, ids).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.UniversityID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *universityRepository) CountRecords(ctx context.Context, id int) (int64, error) {
+	var total int64
+	for _, model := range []interface{}{&models.User{}, &models.Academic{}, &models.Course{}} {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(model).Where("university_id = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (r *universityRepository) Merge(ctx context.Context, targetID int, sourceIDs []int) (*MergeResult, error) {
+	result := &MergeResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Kode mata kuliah unik per universitas, jadi kode yang sama harus diselesaikan dulu
+		var codes []string
+		err := tx.Model(&models.Course{}).
+			Where("university_id IN ? AND code IN (?)", sourceIDs,
+				tx.Model(&models.Course{}).Select("code").Where("university_id = ?", targetID)).
+			Distinct().Pluck("code", &codes).Error
+		if err != nil {
+			return err
+		}
+		if len(codes) > 0 {
+			return &CourseConflictError{Codes: codes}
+		}
+
+		// Data akademik yang sudah dihapus ikut dipindahkan agar tidak menunjuk universitas yang dihapus
+		moved := tx.Unscoped().Model(&models.Academic{}).Where("university_id IN ?", sourceIDs).Update("university_id", targetID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Academics = moved.RowsAffected
+
+		moved = tx.Model(&models.Course{}).Where("university_id IN ?", sourceIDs).Update("university_id", targetID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Courses = moved.RowsAffected
+
+		moved = tx.Model(&models.User{}).Where("university_id IN ?", sourceIDs).Update("university_id", targetID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Users = moved.RowsAffected
+
+		return tx.Delete(&models.University{}, sourceIDs).Error
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &university, err
+	return result, nil
 }
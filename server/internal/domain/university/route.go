@@ -1,14 +1,22 @@
 package university
 
 import (
+	"tsukamoto/internal/audit"
+
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
 )
 
-func UniversityRoute(r *mux.Router, db *gorm.DB) {
+func UniversityRoute(r *mux.Router, db *gorm.DB, log *audit.Log) {
 	repo := NewUniversityRepository(db)
-	handler := NewUniversityHandler(repo)
+	handler := NewUniversityHandler(repo, log)
 
 	r.HandleFunc("/university", handler.GetAll).Methods("GET")
+	r.HandleFunc("/university", handler.Create).Methods("POST")
 	r.HandleFunc("/university/{id}", handler.GetByID).Methods("GET")
+	r.HandleFunc("/university/{id}", handler.Update).Methods("PUT")
+	r.HandleFunc("/university/{id}", handler.Delete).Methods("DELETE")
+	r.HandleFunc("/university/{id}/merge", handler.Merge).Methods("POST")
+	// Daftar lengkap dengan jumlah mahasiswa untuk pengelolaan oleh admin
+	r.HandleFunc("/universities", handler.ListWithCounts).Methods("GET")
 }
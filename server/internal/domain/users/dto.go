@@ -1,21 +1,30 @@
 package users
 
 type CreateUserRequest struct {
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	Username     string `json:"username"`
+	Name         string `json:"name"`
+	Email        string `json:"email,omitempty"`
+	Password     string `json:"password"`
+	Role         string `json:"role"`
+	UniversityID *uint  `json:"university_id,omitempty"`
+	AdvisorID    *uint  `json:"advisor_id,omitempty"`
 }
 
 type UpdateUserRequest struct {
-	Name     string `json:"name"`
-	Password string `json:"password,omitempty"`
-	Role     string `json:"role"`
+	Name         string `json:"name"`
+	Email        string `json:"email,omitempty"`
+	Password     string `json:"password,omitempty"`
+	Role         string `json:"role"`
+	UniversityID *uint  `json:"university_id,omitempty"`
+	AdvisorID    *uint  `json:"advisor_id,omitempty"`
 }
 
 type UserResponse struct {
-	ID       int    `json:"id"`
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	Role     string `json:"role"`
+	ID           int    `json:"id"`
+	Username     string `json:"username"`
+	Name         string `json:"name"`
+	Email        string `json:"email,omitempty"`
+	Role         string `json:"role"`
+	UniversityID *uint  `json:"university_id,omitempty"`
+	AdvisorID    *uint  `json:"advisor_id,omitempty"`
 }
@@ -2,20 +2,85 @@ package users
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"tsukamoto/internal/audit"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/tenant"
 	"tsukamoto/internal/utils"
 
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 )
 
 type userHandler struct {
-	repo UserRepository
+	repo     UserRepository
+	sessions SessionRevoker
+	policy   utils.PasswordPolicy
+	audit    *audit.Log
 }
 
-func NewUserHandler(repo UserRepository) UserHandler {
-	return &userHandler{repo: repo}
+func NewUserHandler(repo UserRepository, sessions SessionRevoker, policy utils.PasswordPolicy, log *audit.Log) UserHandler {
+	return &userHandler{repo: repo, sessions: sessions, policy: policy, audit: log}
+}
+
+func (h *userHandler) validatePassword(w http.ResponseWriter, password, username string) bool {
+	if problems := h.policy.Validate(password, username); len(problems) > 0 {
+		http.Error(w, strings.Join(problems, "; "), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// validateAssignment checks the role and, for students, that the advisor
+// exists and actually holds the advisor role
+func (h *userHandler) validateAssignment(r *http.Request, role string, advisorID *uint) (int, string) {
+	if role != "" && !models.ValidRole(role) {
+		return http.StatusBadRequest, "Role must be one of super_admin, admin, lecturer, advisor or student"
+	}
+	if role == models.RoleSuperAdmin && !tenant.FromContext(r.Context()).All {
+		return http.StatusForbidden, "Only super admins can assign the super_admin role"
+	}
+	if advisorID == nil {
+		return 0, ""
+	}
+	if role != "" && role != models.RoleStudent {
+		return http.StatusBadRequest, "Only students can have an advisor"
+	}
+	advisor, err := h.repo.GetByID(r.Context(), int(*advisorID))
+	if err != nil || advisor.Role != models.RoleAdvisor {
+		return http.StatusBadRequest, "Advisor not found"
+	}
+	return 0, ""
+}
+
+// loadUser fetches the user a change applies to, writing the error response
+// when it cannot
+func (h *userHandler) loadUser(w http.ResponseWriter, r *http.Request, id int) (*models.User, bool) {
+	user, err := h.repo.GetByID(r.Context(), id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return user, true
+}
+
+func toUserResponse(user models.User) UserResponse {
+	return UserResponse{
+		ID:           user.ID,
+		Username:     user.Username,
+		Name:         user.Name,
+		Email:        user.Email,
+		Role:         user.Role,
+		UniversityID: user.UniversityID,
+		AdvisorID:    user.AdvisorID,
+	}
 }
 
 func (h *userHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -24,15 +89,48 @@ func (h *userHandler) Create(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if req.Role == "" {
+		http.Error(w, "Role is required", http.StatusBadRequest)
+		return
+	}
+	scope := tenant.FromContext(r.Context())
+	if req.Role == models.RoleSuperAdmin && req.UniversityID != nil {
+		http.Error(w, "Super admins cannot belong to a university", http.StatusBadRequest)
+		return
+	}
+	// Admin universitas membuat user di universitasnya sendiri
+	if req.UniversityID == nil && !scope.All {
+		universityID := scope.UniversityID
+		req.UniversityID = &universityID
+	}
+	if status, message := h.validateAssignment(r, req.Role, req.AdvisorID); status != 0 {
+		http.Error(w, message, status)
+		return
+	}
+	if !h.validatePassword(w, req.Password, req.Username) {
+		return
+	}
 
+	// Password dari admin hanya sementara, pemilik akun wajib menggantinya
 	user := models.User{
-		Username: req.Username,
-		Name:     req.Name,
-		Password: utils.HashPassword(req.Password),
-		Role:     req.Role,
+		Username:           req.Username,
+		Name:               req.Name,
+		Email:              req.Email,
+		Password:           utils.HashPassword(req.Password),
+		Role:               req.Role,
+		UniversityID:       req.UniversityID,
+		AdvisorID:          req.AdvisorID,
+		MustChangePassword: true,
 	}
 
-	if err := h.repo.Create(r.Context(), user); err != nil {
+	record := h.audit.Recorder(r, func() audit.Entry {
+		return audit.Entry{Action: models.AuditCreate, EntityType: models.AuditEntityUser, EntityID: user.ID, After: user}
+	})
+	if err := h.repo.Create(r.Context(), &user, record); err != nil {
+		if errors.Is(err, tenant.ErrOutsideScope) {
+			http.Error(w, "University is outside your scope", http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -53,21 +151,56 @@ func (h *userHandler) Update(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if status, message := h.validateAssignment(r, req.Role, req.AdvisorID); status != 0 {
+		http.Error(w, message, status)
+		return
+	}
+
+	existing, ok := h.loadUser(w, r, id)
+	if !ok {
+		return
+	}
+	if req.Role == models.RoleSuperAdmin && (req.UniversityID != nil || existing.UniversityID != nil) {
+		http.Error(w, "Super admins cannot belong to a university", http.StatusBadRequest)
+		return
+	}
 
 	user := models.User{
-		Name: req.Name,
-		Role: req.Role,
+		Name:         req.Name,
+		Email:        req.Email,
+		Role:         req.Role,
+		UniversityID: req.UniversityID,
+		AdvisorID:    req.AdvisorID,
 	}
 
 	if req.Password != "" {
+		if !h.validatePassword(w, req.Password, "") {
+			return
+		}
 		user.Password = utils.HashPassword(req.Password)
+		user.MustChangePassword = true
 	}
 
-	if err := h.repo.Update(r.Context(), id, user); err != nil {
+	record := h.audit.Recorder(r, func() audit.Entry {
+		return audit.Entry{Action: models.AuditUpdate, EntityType: models.AuditEntityUser, EntityID: id, Before: existing, After: user, Partial: true}
+	})
+	if err := h.repo.Update(r.Context(), id, user, record); err != nil {
+		if errors.Is(err, tenant.ErrOutsideScope) {
+			http.Error(w, "University is outside your scope", http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// Password yang direset admin mengakhiri semua sesi lama
+	if req.Password != "" {
+		if err := h.sessions.RevokeAll(r.Context(), uint(id)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -79,7 +212,20 @@ func (h *userHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.repo.Delete(r.Context(), id); err != nil {
+	existing, ok := h.loadUser(w, r, id)
+	if !ok {
+		return
+	}
+
+	record := h.audit.Recorder(r, func() audit.Entry {
+		return audit.Entry{Action: models.AuditDelete, EntityType: models.AuditEntityUser, EntityID: id, Before: existing}
+	})
+	if err := h.repo.Delete(r.Context(), id, record); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.sessions.RevokeAll(r.Context(), uint(id)); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -87,6 +233,23 @@ func (h *userHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// RevokeSessions logs the user out of every device
+func (h *userHandler) RevokeSessions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.sessions.RevokeAll(r.Context(), uint(id)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *userHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	users, err := h.repo.GetAll(r.Context())
 	if err != nil {
@@ -96,12 +259,7 @@ func (h *userHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 	var response []UserResponse
 	for _, user := range users {
-		response = append(response, UserResponse{
-			ID:       user.ID,
-			Username: user.Username,
-			Name:     user.Name,
-			Role:     user.Role,
-		})
+		response = append(response, toUserResponse(user))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -122,12 +280,7 @@ func (h *userHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := UserResponse{
-		ID:       user.ID,
-		Username: user.Username,
-		Name:     user.Name,
-		Role:     user.Role,
-	}
+	response := toUserResponse(*user)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
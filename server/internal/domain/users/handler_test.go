@@ -2,15 +2,20 @@ package users
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/middleware"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 )
 
 const (
@@ -23,16 +28,16 @@ func TestUserHandler_Create_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	mockRepo.EXPECT().
-		Create(gomock.Any(), gomock.Any()).
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil)
 
 	reqBody := CreateUserRequest{
 		Username: "user1",
 		Name:     "User One",
-		Password: "pass",
+		Password: "secret123",
 		Role:     "student",
 	}
 	body, _ := json.Marshal(reqBody)
@@ -50,7 +55,7 @@ func TestUserHandler_Create_BadRequest(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	req := httptest.NewRequest("POST", usersPath, bytes.NewReader([]byte("invalid")))
 	w := httptest.NewRecorder()
@@ -66,16 +71,16 @@ func TestUserHandler_Create_InternalError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	mockRepo.EXPECT().
-		Create(gomock.Any(), gomock.Any()).
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(errors.New("db error"))
 
 	reqBody := CreateUserRequest{
 		Username: "user1",
 		Name:     "User One",
-		Password: "pass",
+		Password: "secret123",
 		Role:     "student",
 	}
 	body, _ := json.Marshal(reqBody)
@@ -93,7 +98,7 @@ func TestUserHandler_GetAll_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	mockRepo.EXPECT().
 		GetAll(gomock.Any()).
@@ -113,7 +118,7 @@ func TestUserHandler_GetAll_Error(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	mockRepo.EXPECT().
 		GetAll(gomock.Any()).
@@ -133,7 +138,7 @@ func TestUserHandler_GetByID_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	mockRepo.EXPECT().
 		GetByID(gomock.Any(), 1).
@@ -154,7 +159,7 @@ func TestUserHandler_GetByID_BadID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	req := httptest.NewRequest("GET", "/users/abc", nil)
 	req = mux.SetURLVars(req, map[string]string{"id": "abc"})
@@ -171,7 +176,7 @@ func TestUserHandler_GetByID_Error(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := NewMockUserRepository(ctrl)
-	handler := NewUserHandler(mockRepo)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
 
 	mockRepo.EXPECT().
 		GetByID(gomock.Any(), 1).
@@ -186,3 +191,247 @@ func TestUserHandler_GetByID_Error(t *testing.T) {
 		t.Errorf("expected 500, got %d", w.Code)
 	}
 }
+
+func TestUserHandler_Create_InvalidRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
+
+	for _, role := range []string{"", "superuser"} {
+		body, _ := json.Marshal(CreateUserRequest{Username: "user1", Password: "secret123", Role: role})
+		w := httptest.NewRecorder()
+		handler.Create(w, httptest.NewRequest("POST", usersPath, bytes.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", role, w.Code)
+		}
+	}
+}
+
+func TestUserHandler_Create_WeakPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
+
+	for _, password := range []string{"pass", "longpassword", "user1pass99"} {
+		body, _ := json.Marshal(CreateUserRequest{Username: "user1", Password: password, Role: "student"})
+		w := httptest.NewRecorder()
+		handler.Create(w, httptest.NewRequest("POST", usersPath, bytes.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", password, w.Code)
+		}
+	}
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, user *models.User, _ audit.Recorder) error {
+		if !user.MustChangePassword {
+			t.Error("accounts created by an admin must change their password")
+		}
+		return nil
+	})
+	body, _ := json.Marshal(CreateUserRequest{Username: "user1", Password: "secret123", Role: "student"})
+	w := httptest.NewRecorder()
+	handler.Create(w, httptest.NewRequest("POST", usersPath, bytes.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+}
+
+func TestUserHandler_Create_AdvisorMustHoldAdvisorRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
+
+	advisorID := uint(5)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&models.User{ID: 5, Role: "lecturer"}, nil)
+
+	body, _ := json.Marshal(CreateUserRequest{Username: "user1", Password: "secret123", Role: "student", AdvisorID: &advisorID})
+	w := httptest.NewRecorder()
+	handler.Create(w, httptest.NewRequest("POST", usersPath, bytes.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&models.User{ID: 5, Role: "advisor"}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, user *models.User, _ audit.Recorder) error {
+		if user.AdvisorID == nil || *user.AdvisorID != 5 {
+			t.Errorf("advisor not assigned: %+v", user)
+		}
+		return nil
+	})
+	w = httptest.NewRecorder()
+	handler.Create(w, httptest.NewRequest("POST", usersPath, bytes.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+}
+
+func TestUserHandler_RevokesSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	mockSessions := NewMockSessionRevoker(ctrl)
+	handler := NewUserHandler(mockRepo, mockSessions, utils.DefaultPasswordPolicy(), nil)
+
+	newRequest := func(method, body string) *http.Request {
+		req := httptest.NewRequest(method, usersPathID, bytes.NewReader([]byte(body)))
+		return mux.SetURLVars(req, map[string]string{"id": "1"})
+	}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.User{ID: 1, Username: "budi"}, nil).AnyTimes()
+
+	// Mengganti nama saja tidak memutus sesi
+	mockRepo.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(nil)
+	w := httptest.NewRecorder()
+	handler.Update(w, newRequest("PUT", `{"name":"Budi"}`))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(nil)
+	mockSessions.EXPECT().RevokeAll(gomock.Any(), uint(1)).Return(nil)
+	w = httptest.NewRecorder()
+	handler.Update(w, newRequest("PUT", `{"password":"new-password1"}`))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, gomock.Any()).Return(nil)
+	mockSessions.EXPECT().RevokeAll(gomock.Any(), uint(1)).Return(nil)
+	w = httptest.NewRecorder()
+	handler.Delete(w, newRequest("DELETE", ""))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	mockSessions.EXPECT().RevokeAll(gomock.Any(), uint(1)).Return(nil)
+	w = httptest.NewRecorder()
+	handler.RevokeSessions(w, newRequest("DELETE", ""))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", w.Code)
+	}
+}
+
+func TestUserHandler_RecordsAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	mockSessions := NewMockSessionRevoker(ctrl)
+	store := &audit.MemoryStore{}
+	handler := NewUserHandler(mockRepo, mockSessions, utils.DefaultPasswordPolicy(), audit.NewLog(store))
+
+	newRequest := func(method, body string) *http.Request {
+		req := httptest.NewRequest(method, usersPathID, bytes.NewReader([]byte(body)))
+		req = req.WithContext(middleware.WithUser(req.Context(), middleware.AuthUser{ID: 9, Role: "admin"}))
+		return mux.SetURLVars(req, map[string]string{"id": "1"})
+	}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 2).Return(nil, gorm.ErrRecordNotFound)
+	w := httptest.NewRecorder()
+	req := newRequest("DELETE", "")
+	handler.Delete(w, mux.SetURLVars(req, map[string]string{"id": "2"}))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown user: expected 404, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.User{ID: 1, Username: "budi", Name: "Budi", Role: "student"}, nil).Times(2)
+	mockRepo.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, _ int, _ models.User, record audit.Recorder) error {
+		return record.Run(nil)
+	})
+	w = httptest.NewRecorder()
+	handler.Update(w, newRequest("PUT", `{"name":"Budi Santoso"}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(_ interface{}, _ int, record audit.Recorder) error {
+		return record.Run(nil)
+	})
+	mockSessions.EXPECT().RevokeAll(gomock.Any(), uint(1)).Return(nil)
+	w = httptest.NewRecorder()
+	handler.Delete(w, newRequest("DELETE", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if len(store.Entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(store.Entries))
+	}
+	update := store.Entries[0]
+	if update.Action != models.AuditUpdate || update.EntityID != "1" || update.ActorID == nil || *update.ActorID != 9 {
+		t.Errorf("unexpected update entry %+v", update)
+	}
+	var changes map[string]audit.Change
+	update.Changes.Decode(&changes)
+	if len(changes) != 1 || changes["name"].From != "Budi" || changes["name"].To != "Budi Santoso" {
+		t.Errorf("expected only the name change, got %+v", changes)
+	}
+	if store.Entries[1].Action != models.AuditDelete || store.Entries[1].PrevHash != update.Hash {
+		t.Errorf("delete should be chained to the update: %+v", store.Entries[1])
+	}
+}
+
+// failingStore rejects every audit entry
+type failingStore struct{}
+
+func (failingStore) Append(context.Context, *gorm.DB, *models.AuditLog) error {
+	return errors.New("audit store down")
+}
+
+func TestUserHandler_Delete_FailsWithoutAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	// Sesi tidak dicabut karena penghapusan dibatalkan
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), audit.NewLog(failingStore{}))
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.User{ID: 1, Role: "student"}, nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(_ interface{}, _ int, record audit.Recorder) error {
+		return record.Run(nil)
+	})
+	req := mux.SetURLVars(httptest.NewRequest("DELETE", usersPathID, nil), map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+	handler.Delete(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the audit entry cannot be written, got %d", w.Code)
+	}
+}
+
+func TestUserHandler_Create_UniversityAdminScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	handler := NewUserHandler(mockRepo, NewMockSessionRevoker(ctrl), utils.DefaultPasswordPolicy(), nil)
+	caller := middleware.AuthUser{ID: 2, Role: models.RoleAdmin, UniversityID: 4}
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, user *models.User, _ audit.Recorder) error {
+		if user.UniversityID == nil || *user.UniversityID != 4 {
+			t.Errorf("expected the user in the admin's university, got %v", user.UniversityID)
+		}
+		return nil
+	})
+
+	body, _ := json.Marshal(CreateUserRequest{Username: "dosen1", Password: "secret123", Role: models.RoleLecturer})
+	req := httptest.NewRequest("POST", usersPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.Create(w, req.WithContext(middleware.WithUser(req.Context(), caller)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	body, _ = json.Marshal(CreateUserRequest{Username: "root2", Password: "secret123", Role: models.RoleSuperAdmin})
+	req = httptest.NewRequest("POST", usersPath, bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	handler.Create(w, req.WithContext(middleware.WithUser(req.Context(), caller)))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 when a university admin creates a super admin, got %d", w.Code)
+	}
+}
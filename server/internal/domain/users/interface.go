@@ -3,21 +3,30 @@ package users
 import (
 	"context"
 	"net/http"
+	"tsukamoto/internal/audit"
 	"tsukamoto/internal/models"
 )
 
+// UserRepository writes changes together with their audit entry, so a change
+// is never committed without it. record may be nil.
 type UserRepository interface {
-	Create(ctx context.Context, user models.User) error
-	Update(ctx context.Context, id int, user models.User) error
-	Delete(ctx context.Context, id int) error
+	Create(ctx context.Context, user *models.User, record audit.Recorder) error
+	Update(ctx context.Context, id int, user models.User, record audit.Recorder) error
+	Delete(ctx context.Context, id int, record audit.Recorder) error
 	GetAll(ctx context.Context) ([]models.User, error)
 	GetByID(ctx context.Context, id int) (*models.User, error)
 }
 
+// SessionRevoker ends every login session of a user
+type SessionRevoker interface {
+	RevokeAll(ctx context.Context, userID uint) error
+}
+
 type UserHandler interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 	GetAll(w http.ResponseWriter, r *http.Request)
 	GetByID(w http.ResponseWriter, r *http.Request)
+	RevokeSessions(w http.ResponseWriter, r *http.Request)
 }
@@ -2,7 +2,9 @@ package users
 
 import (
 	"context"
+	"tsukamoto/internal/audit"
 	"tsukamoto/internal/models"
+	"tsukamoto/internal/tenant"
 
 	"gorm.io/gorm"
 )
@@ -15,26 +17,58 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) Create(ctx context.Context, user models.User) error {
-	return r.db.WithContext(ctx).Create(&user).Error
+// allows reports whether a user in the university, nil for super admins, is
+// inside the scope
+func allows(scope tenant.Scope, universityID *uint) bool {
+	if universityID == nil {
+		return scope.All
+	}
+	return scope.Allows(*universityID)
 }
 
-func (r *userRepository) Update(ctx context.Context, id int, user models.User) error {
-	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(user).Error
+func (r *userRepository) Create(ctx context.Context, user *models.User, record audit.Recorder) error {
+	if !allows(tenant.FromContext(ctx), user.UniversityID) {
+		return tenant.ErrOutsideScope
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return record.Run(tx)
+	})
 }
 
-func (r *userRepository) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&models.User{}, id).Error
+func (r *userRepository) Update(ctx context.Context, id int, user models.User, record audit.Recorder) error {
+	scope := tenant.FromContext(ctx)
+	// UniversityID kosong berarti tidak diubah
+	if user.UniversityID != nil && !scope.Allows(*user.UniversityID) {
+		return tenant.ErrOutsideScope
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Scopes(scope.Filter("university_id")).Where("id = ?", id).Updates(user).Error; err != nil {
+			return err
+		}
+		return record.Run(tx)
+	})
+}
+
+func (r *userRepository) Delete(ctx context.Context, id int, record audit.Recorder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(tenant.Scoped(ctx, "university_id")).Delete(&models.User{}, id).Error; err != nil {
+			return err
+		}
+		return record.Run(tx)
+	})
 }
 
 func (r *userRepository) GetAll(ctx context.Context) ([]models.User, error) {
 	var users []models.User
-	err := r.db.WithContext(ctx).Find(&users).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scoped(ctx, "university_id")).Find(&users).Error
 	return users, err
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).First(&user, id).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scoped(ctx, "university_id")).First(&user, id).Error
 	return &user, err
 }
@@ -1,17 +1,21 @@
 package users
 
 import (
+	"tsukamoto/internal/audit"
+	"tsukamoto/internal/utils"
+
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
 )
 
-func UserRoute(r *mux.Router, db *gorm.DB) {
+func UserRoute(r *mux.Router, db *gorm.DB, sessions SessionRevoker, policy utils.PasswordPolicy, log *audit.Log) {
 	repo := NewUserRepository(db)
-	handler := NewUserHandler(repo)
+	handler := NewUserHandler(repo, sessions, policy, log)
 
 	r.HandleFunc("/users", handler.Create).Methods("POST")
 	r.HandleFunc("/users/{id}", handler.Update).Methods("PUT")
 	r.HandleFunc("/users/{id}", handler.Delete).Methods("DELETE")
 	r.HandleFunc("/users", handler.GetAll).Methods("GET")
 	r.HandleFunc("/users/{id}", handler.GetByID).Methods("GET")
+	r.HandleFunc("/users/{id}/sessions", handler.RevokeSessions).Methods("DELETE")
 }
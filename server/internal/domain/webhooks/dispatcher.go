@@ -0,0 +1,329 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Headers sent with every delivery. The signature is
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)), so receivers
+// can reject replays by checking the timestamp as well.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// TestEvent is the event type sent by the test-fire endpoint
+const TestEvent = "webhook.test"
+
+// Envelope is the JSON body of a delivery
+type Envelope struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Sign computes the signature header value for a body sent at timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header in constant time
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// claimLease is how long a delivery being attempted stays hidden from the
+// retry sweep; it must exceed Timeout
+const claimLease = 5 * time.Minute
+
+// Options controls delivery. A failed attempt is retried after Backoff,
+// doubling the wait after every further failure, until MaxAttempts is reached.
+// Retries are stored with the delivery and picked up by Run every
+// PollInterval, so they survive a restart.
+type Options struct {
+	MaxAttempts  int
+	Backoff      time.Duration
+	Timeout      time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// DefaultOptions tries five times over roughly half a minute.
+func DefaultOptions() Options {
+	return Options{MaxAttempts: 5, Backoff: 2 * time.Second, Timeout: 10 * time.Second, PollInterval: 5 * time.Second, BatchSize: 20}
+}
+
+// OptionsFromConfig reads the webhook settings, keeping the default for any
+// value that is missing or invalid.
+func OptionsFromConfig(cfg *config.Config) Options {
+	options := DefaultOptions()
+	if value, err := strconv.Atoi(cfg.WebhookMaxAttempts); err == nil && value > 0 {
+		options.MaxAttempts = value
+	}
+	if value, err := time.ParseDuration(cfg.WebhookBackoff); err == nil && value > 0 {
+		options.Backoff = value
+	}
+	if value, err := time.ParseDuration(cfg.WebhookTimeout); err == nil && value > 0 {
+		options.Timeout = value
+	}
+	if value, err := time.ParseDuration(cfg.WebhookPollInterval); err == nil && value > 0 {
+		options.PollInterval = value
+	}
+	return options
+}
+
+// Dispatcher delivers events to the subscriptions registered for them and
+// records every delivery. The first attempt of an event delivery runs in the
+// background so that publishers are never blocked by slow receivers; later
+// attempts are left to Run.
+type Dispatcher struct {
+	repo    WebhookRepository
+	client  *http.Client
+	options Options
+	now     func() time.Time
+	wg      sync.WaitGroup
+}
+
+// NewDispatcher creates a Dispatcher; a nil client uses one with options.Timeout
+func NewDispatcher(repo WebhookRepository, client *http.Client, options Options) *Dispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: options.Timeout}
+	}
+	return &Dispatcher{repo: repo, client: client, options: options, now: time.Now}
+}
+
+// Subscribe registers the dispatcher for every public event type
+func (d *Dispatcher) Subscribe(bus *events.Bus) {
+	for _, eventType := range events.Public() {
+		bus.Subscribe(eventType, d.HandleEvent)
+	}
+}
+
+// HandleEvent queues a delivery of the event to each matching subscription
+func (d *Dispatcher) HandleEvent(ctx context.Context, event events.Event) {
+	subscriptions, err := d.repo.GetActiveByEventType(ctx, event.Type)
+	if err != nil {
+		logrus.WithError(err).WithField("event", event.Type).Warn("Failed to load webhook subscriptions")
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	eventID, body, err := newEnvelope(event.Type, event.Payload)
+	if err != nil {
+		logrus.WithError(err).WithField("event", event.Type).Warn("Failed to encode webhook payload")
+		return
+	}
+
+	// Pengiriman dicatat sudah diklaim, sehingga sweep baru mengambilnya bila
+	// percobaan pertama tidak sempat berjalan (misalnya server berhenti)
+	claimed := d.now().Add(claimLease)
+	for _, subscription := range subscriptions {
+		delivery, err := d.newDelivery(ctx, subscription, eventID, event.Type, body, &claimed)
+		if err != nil {
+			logrus.WithError(err).WithField("subscription_id", subscription.ID).Warn("Failed to record webhook delivery")
+			continue
+		}
+
+		d.wg.Add(1)
+		go func(subscription models.WebhookSubscription) {
+			defer d.wg.Done()
+			d.Deliver(context.Background(), subscription, delivery, body, d.options.MaxAttempts)
+		}(subscription)
+	}
+}
+
+// Fire sends one event to a single subscription synchronously, with a single
+// attempt, and returns the recorded delivery
+func (d *Dispatcher) Fire(ctx context.Context, subscription models.WebhookSubscription, eventType string, payload interface{}) (*models.WebhookDelivery, error) {
+	eventID, body, err := newEnvelope(eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := d.newDelivery(ctx, subscription, eventID, eventType, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	d.Deliver(ctx, subscription, delivery, body, 1)
+	return delivery, nil
+}
+
+// Wait blocks until every background delivery has finished
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Deliver makes one attempt to post body and updates the delivery log. A
+// failed attempt below maxAttempts stays pending with the time of the next
+// retry, which ProcessDue picks up.
+func (d *Dispatcher) Deliver(ctx context.Context, subscription models.WebhookSubscription, delivery *models.WebhookDelivery, body []byte, maxAttempts int) {
+	delivery.Attempts++
+	code, err := d.send(ctx, subscription, delivery, body)
+	delivery.ResponseCode = code
+	delivery.NextAttemptAt = nil
+
+	now := d.now()
+	switch {
+	case err == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	case delivery.Attempts >= maxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		next := now.Add(d.options.Backoff << (delivery.Attempts - 1))
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = &next
+	}
+	d.saveDelivery(ctx, delivery)
+}
+
+// ProcessDue retries the pending deliveries whose next attempt is due and
+// returns how many of them succeeded
+func (d *Dispatcher) ProcessDue(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.ClaimDue(ctx, d.now(), claimLease, d.options.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		subscription, err := d.repo.GetByID(ctx, delivery.SubscriptionID)
+		if err == nil && !subscription.Active {
+			err = errors.New("webhook subscription is inactive")
+		}
+		if err != nil {
+			// Subscription yang dihapus atau dinonaktifkan tidak dicoba lagi
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = nil
+			d.saveDelivery(ctx, delivery)
+			continue
+		}
+
+		d.Deliver(ctx, *subscription, delivery, []byte(delivery.Payload), d.options.MaxAttempts)
+		if delivery.Status == models.WebhookDeliverySucceeded {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// Run retries due deliveries every PollInterval until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.ProcessDue(ctx); err != nil {
+			logrus.WithError(err).Warn("Failed to process webhook retries")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, subscription models.WebhookSubscription, delivery *models.WebhookDelivery, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Tsukamoto-Webhook/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) newDelivery(ctx context.Context, subscription models.WebhookSubscription, eventID, eventType string, body []byte, nextAttemptAt *time.Time) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        models.JSON(body),
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  nextAttemptAt,
+	}
+	if err := d.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (d *Dispatcher) saveDelivery(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		logrus.WithError(err).WithField("delivery_id", delivery.ID).Warn("Failed to update webhook delivery")
+	}
+}
+
+// newEnvelope wraps payload in an Envelope with a fresh event ID
+func newEnvelope(eventType string, payload interface{}) (string, []byte, error) {
+	eventID, err := randomHex(16)
+	if err != nil {
+		return "", nil, err
+	}
+	body, err := json.Marshal(Envelope{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      payload,
+	})
+	return eventID, body, err
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,220 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// receiver is a local HTTP stand-in that fails the first `failures` requests
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	if len(rc.requests) <= rc.failures {
+		http.Error(w, "temporarily unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func fastOptions() Options {
+	return Options{MaxAttempts: 3, Backoff: time.Millisecond, Timeout: time.Second, PollInterval: time.Millisecond, BatchSize: 20}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := Sign(testSecret, "1700000000", body)
+
+	if !Verify(testSecret, "1700000000", body, signature) {
+		t.Error("expected signature to verify")
+	}
+	if Verify(testSecret, "1700000001", body, signature) {
+		t.Error("signature must depend on the timestamp")
+	}
+	if Verify("another-secret-value", "1700000000", body, signature) {
+		t.Error("signature must depend on the secret")
+	}
+}
+
+func TestOptionsFromConfig(t *testing.T) {
+	options := OptionsFromConfig(&config.Config{WebhookMaxAttempts: "7", WebhookBackoff: "500ms", WebhookTimeout: "bad"})
+	if options.MaxAttempts != 7 || options.Backoff != 500*time.Millisecond || options.Timeout != DefaultOptions().Timeout {
+		t.Errorf("unexpected options %+v", options)
+	}
+}
+
+func TestDispatcher_DeliversSignedPayloadWithRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stand := &receiver{failures: 2}
+	server := httptest.NewServer(stand)
+	defer server.Close()
+
+	mockRepo := NewMockWebhookRepository(ctrl)
+	dispatcher := NewDispatcher(mockRepo, server.Client(), fastOptions())
+	bus := events.NewBus()
+	dispatcher.Subscribe(bus)
+
+	subscription := models.WebhookSubscription{ID: 5, URL: server.URL, Secret: testSecret, EventTypes: models.StringList{events.AlertOpened}, Active: true}
+	mockRepo.EXPECT().GetActiveByEventType(gomock.Any(), events.AlertOpened).Return([]models.WebhookSubscription{subscription}, nil)
+
+	var delivery models.WebhookDelivery
+	mockRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+		if d.NextAttemptAt == nil {
+			t.Error("expected the first attempt to be claimed in the log")
+		}
+		d.ID = 77
+		return nil
+	})
+	mockRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+		delivery = *d
+		return nil
+	}).Times(3)
+
+	bus.Publish(context.Background(), events.AlertOpened, &models.Alert{ID: 1, UserID: 9, Reason: models.AlertReasonBelowThreshold})
+	dispatcher.Wait()
+
+	// Percobaan pertama gagal; retry menunggu di log, bukan di goroutine
+	if len(stand.requests) != 1 || delivery.Status != models.WebhookDeliveryPending || delivery.NextAttemptAt == nil {
+		t.Fatalf("expected a pending retry after the first attempt, got %+v", delivery)
+	}
+
+	// Retry diambil dari log seperti setelah server dijalankan ulang
+	for attempt := 2; attempt <= 3; attempt++ {
+		mockRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), claimLease, 20).Return([]models.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&subscription, nil)
+		if _, err := dispatcher.ProcessDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(stand.requests) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(stand.requests))
+	}
+	if delivery.Status != models.WebhookDeliverySucceeded || delivery.Attempts != 3 || delivery.ResponseCode != http.StatusNoContent || delivery.DeliveredAt == nil {
+		t.Errorf("unexpected delivery log %+v", delivery)
+	}
+
+	last := stand.requests[2]
+	body := stand.bodies[2]
+	if !Verify(testSecret, last.Header.Get(HeaderTimestamp), body, last.Header.Get(HeaderSignature)) {
+		t.Error("signature does not verify")
+	}
+	if last.Header.Get(HeaderEvent) != events.AlertOpened || last.Header.Get(HeaderDelivery) != "77" {
+		t.Errorf("unexpected headers %v", last.Header)
+	}
+	if string(stand.bodies[0]) != string(body) {
+		t.Error("retries must resend the stored payload")
+	}
+
+	var envelope struct {
+		ID   string                 `json:"id"`
+		Type string                 `json:"type"`
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.ID != delivery.EventID || envelope.Type != events.AlertOpened || envelope.Data["user_id"] != float64(9) {
+		t.Errorf("unexpected envelope %s", body)
+	}
+}
+
+func TestDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stand := &receiver{failures: 10}
+	server := httptest.NewServer(stand)
+	defer server.Close()
+
+	mockRepo := NewMockWebhookRepository(ctrl)
+	dispatcher := NewDispatcher(mockRepo, server.Client(), fastOptions())
+
+	subscription := models.WebhookSubscription{ID: 5, URL: server.URL, Secret: testSecret}
+	var saved []models.WebhookDelivery
+	mockRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+		saved = append(saved, *d)
+		return nil
+	}).Times(3)
+
+	delivery := &models.WebhookDelivery{ID: 1, EventType: events.DatasetImported, Status: models.WebhookDeliveryPending}
+	for i := 0; i < 3; i++ {
+		dispatcher.Deliver(context.Background(), subscription, delivery, []byte(`{}`), 3)
+	}
+
+	if len(stand.requests) != 3 {
+		t.Errorf("expected 3 attempts, got %d", len(stand.requests))
+	}
+	if delivery.Status != models.WebhookDeliveryFailed || delivery.ResponseCode != http.StatusServiceUnavailable || delivery.LastError == "" {
+		t.Errorf("unexpected delivery log %+v", delivery)
+	}
+	// Attempts in between are logged with the time of the next retry
+	if saved[0].NextAttemptAt == nil || saved[0].Status != models.WebhookDeliveryPending || saved[2].NextAttemptAt != nil {
+		t.Errorf("unexpected intermediate log %+v", saved)
+	}
+	if !saved[1].NextAttemptAt.After(*saved[0].NextAttemptAt) {
+		t.Error("expected the backoff to grow between retries")
+	}
+}
+
+func TestDispatcher_ProcessDue_FailsInactiveSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockWebhookRepository(ctrl)
+	dispatcher := NewDispatcher(mockRepo, nil, fastOptions())
+
+	next := time.Now()
+	due := models.WebhookDelivery{ID: 3, SubscriptionID: 5, Status: models.WebhookDeliveryPending, Attempts: 1, NextAttemptAt: &next}
+	mockRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), claimLease, 20).Return([]models.WebhookDelivery{due}, nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&models.WebhookSubscription{ID: 5, Active: false}, nil)
+	mockRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+		if d.Status != models.WebhookDeliveryFailed || d.NextAttemptAt != nil || d.Attempts != 1 {
+			t.Errorf("unexpected delivery log %+v", d)
+		}
+		return nil
+	})
+
+	delivered, err := dispatcher.ProcessDue(context.Background())
+	if err != nil || delivered != 0 {
+		t.Errorf("expected no delivery, got %d, %v", delivered, err)
+	}
+}
+
+func TestDispatcher_IgnoresEventsWithoutSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockWebhookRepository(ctrl)
+	dispatcher := NewDispatcher(mockRepo, nil, fastOptions())
+
+	mockRepo.EXPECT().GetActiveByEventType(gomock.Any(), events.AssessmentCreated).Return(nil, nil)
+
+	dispatcher.HandleEvent(context.Background(), events.Event{Type: events.AssessmentCreated, Payload: &models.Assessment{}})
+	dispatcher.Wait()
+}
@@ -0,0 +1,28 @@
+package webhooks
+
+// CreateWebhookRequest mendaftarkan subscription baru; secret dibuat otomatis bila kosong
+type CreateWebhookRequest struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	EventTypes  []string `json:"event_types"`
+	Description string   `json:"description"`
+}
+
+// UpdateWebhookRequest mengubah subscription; field nil tidak diubah
+type UpdateWebhookRequest struct {
+	URL         *string   `json:"url"`
+	Secret      *string   `json:"secret"`
+	EventTypes  *[]string `json:"event_types"`
+	Description *string   `json:"description"`
+	Active      *bool     `json:"active"`
+}
+
+// WebhookResponse menampilkan secret hanya saat subscription dibuat atau secret diganti
+type WebhookResponse struct {
+	ID          int      `json:"id"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"`
+	EventTypes  []string `json:"event_types"`
+	Description string   `json:"description"`
+	Active      bool     `json:"active"`
+}
@@ -0,0 +1,288 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// Batas jumlah riwayat pengiriman yang dikembalikan
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+	minSecretLength      = 16
+)
+
+type webhookHandler struct {
+	repo       WebhookRepository
+	dispatcher *Dispatcher
+}
+
+func NewWebhookHandler(repo WebhookRepository, dispatcher *Dispatcher) WebhookHandler {
+	return &webhookHandler{repo: repo, dispatcher: dispatcher}
+}
+
+func toResponse(subscription *models.WebhookSubscription, withSecret bool) WebhookResponse {
+	response := WebhookResponse{
+		ID:          subscription.ID,
+		URL:         subscription.URL,
+		EventTypes:  subscription.EventTypes,
+		Description: subscription.Description,
+		Active:      subscription.Active,
+	}
+	if withSecret {
+		response.Secret = subscription.Secret
+	}
+	return response
+}
+
+// validateURL accepts absolute http and https URLs only
+func validateURL(raw string) *utils.ErrorDetail {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &utils.ErrorDetail{Field: "url", Message: "URL harus berupa alamat http atau https yang lengkap"}
+	}
+	return nil
+}
+
+func validateEventTypes(eventTypes []string) *utils.ErrorDetail {
+	if len(eventTypes) == 0 {
+		return &utils.ErrorDetail{Field: "event_types", Message: "Minimal satu event type diperlukan"}
+	}
+	public := models.StringList(events.Public())
+	for _, eventType := range eventTypes {
+		if !public.Contains(eventType) {
+			return &utils.ErrorDetail{Field: "event_types", Message: "Event type tidak dikenal: " + eventType}
+		}
+	}
+	return nil
+}
+
+func validateSecret(secret string) *utils.ErrorDetail {
+	if len(secret) < minSecretLength {
+		return &utils.ErrorDetail{Field: "secret", Message: "Secret minimal 16 karakter"}
+	}
+	return nil
+}
+
+// Create handles POST /webhooks
+func (h *webhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	var errs []utils.ErrorDetail
+	if e := validateURL(req.URL); e != nil {
+		errs = append(errs, *e)
+	}
+	if e := validateEventTypes(req.EventTypes); e != nil {
+		errs = append(errs, *e)
+	}
+	if req.Secret != "" {
+		if e := validateSecret(req.Secret); e != nil {
+			errs = append(errs, *e)
+		}
+	}
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = randomHex(32); err != nil {
+			utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal membuat secret"}}, nil)
+			return
+		}
+	}
+
+	subscription := &models.WebhookSubscription{
+		URL:         req.URL,
+		Secret:      secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		Active:      true,
+	}
+	if err := h.repo.Create(r.Context(), subscription); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menyimpan webhook"}}, nil)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusCreated, nil, toResponse(subscription, true))
+}
+
+// GetAll handles GET /webhooks
+func (h *webhookHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.repo.GetAll(r.Context())
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data webhook"}}, nil)
+		return
+	}
+
+	responses := make([]WebhookResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		responses = append(responses, toResponse(&subscriptions[i], false))
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, responses)
+}
+
+// GetByID handles GET /webhooks/:id
+func (h *webhookHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.loadSubscription(w, r)
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, toResponse(subscription, false))
+}
+
+// Update handles PUT /webhooks/:id
+func (h *webhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "Format JSON tidak valid"}}, nil)
+		return
+	}
+
+	var errs []utils.ErrorDetail
+	if req.URL != nil {
+		if e := validateURL(*req.URL); e != nil {
+			errs = append(errs, *e)
+		}
+	}
+	if req.EventTypes != nil {
+		if e := validateEventTypes(*req.EventTypes); e != nil {
+			errs = append(errs, *e)
+		}
+	}
+	if req.Secret != nil {
+		if e := validateSecret(*req.Secret); e != nil {
+			errs = append(errs, *e)
+		}
+	}
+	if len(errs) > 0 {
+		utils.WriteResponse(w, http.StatusBadRequest, errs, nil)
+		return
+	}
+
+	subscription, ok := h.loadSubscription(w, r)
+	if !ok {
+		return
+	}
+	if req.URL != nil {
+		subscription.URL = *req.URL
+	}
+	if req.Secret != nil {
+		subscription.Secret = *req.Secret
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = *req.EventTypes
+	}
+	if req.Description != nil {
+		subscription.Description = *req.Description
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := h.repo.Update(r.Context(), subscription); err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal memperbarui webhook"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, toResponse(subscription, req.Secret != nil))
+}
+
+// Delete handles DELETE /webhooks/:id together with its delivery log
+func (h *webhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "ID webhook tidak valid"}}, nil)
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Webhook tidak ditemukan"}}, nil)
+			return
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal menghapus webhook"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, map[string]string{"message": "Webhook berhasil dihapus"})
+}
+
+// GetDeliveries handles GET /webhooks/:id/deliveries?limit=
+func (h *webhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	limit := defaultDeliveryLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Field: "limit", Message: "Limit tidak valid"}}, nil)
+			return
+		}
+		limit = parsed
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+
+	subscription, ok := h.loadSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.repo.GetDeliveries(r.Context(), subscription.ID, limit)
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil riwayat pengiriman"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, deliveries)
+}
+
+// Test handles POST /webhooks/:id/test by sending a webhook.test event once
+// and returning the recorded delivery
+func (h *webhookHandler) Test(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.loadSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.dispatcher.Fire(r.Context(), *subscription, TestEvent, map[string]interface{}{
+		"subscription_id": subscription.ID,
+		"message":         "Webhook test event",
+	})
+	if err != nil {
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengirim event uji"}}, nil)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, nil, delivery)
+}
+
+// loadSubscription reads the subscription in the URL, writing the error response itself
+func (h *webhookHandler) loadSubscription(w http.ResponseWriter, r *http.Request) (*models.WebhookSubscription, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteResponse(w, http.StatusBadRequest, []utils.ErrorDetail{{Message: "ID webhook tidak valid"}}, nil)
+		return nil, false
+	}
+
+	subscription, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			utils.WriteResponse(w, http.StatusNotFound, []utils.ErrorDetail{{Message: "Webhook tidak ditemukan"}}, nil)
+			return nil, false
+		}
+		utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Gagal mengambil data webhook"}}, nil)
+		return nil, false
+	}
+	return subscription, true
+}
@@ -0,0 +1,188 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tsukamoto/internal/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const (
+	webhooksPath   = "/webhooks"
+	webhooksPathID = "/webhooks/1"
+)
+
+func newWebhookHandler(ctrl *gomock.Controller, client *http.Client) (*MockWebhookRepository, WebhookHandler) {
+	mockRepo := NewMockWebhookRepository(ctrl)
+	return mockRepo, NewWebhookHandler(mockRepo, NewDispatcher(mockRepo, client, fastOptions()))
+}
+
+func withID(req *http.Request) *http.Request {
+	return mux.SetURLVars(req, map[string]string{"id": "1"})
+}
+
+func TestWebhookHandler_Create_GeneratesSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newWebhookHandler(ctrl, nil)
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *models.WebhookSubscription) error {
+		s.ID = 1
+		return nil
+	})
+
+	body := `{"url":"https://lms.example.ac.id/hooks","event_types":["alert.opened","assessment.created"]}`
+	w := httptest.NewRecorder()
+	handler.Create(w, httptest.NewRequest("POST", webhooksPath, bytes.NewReader([]byte(body))))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Data WebhookResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	if len(response.Data.Secret) != 64 || !response.Data.Active || len(response.Data.EventTypes) != 2 {
+		t.Errorf("unexpected response %+v", response.Data)
+	}
+}
+
+func TestWebhookHandler_Create_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, handler := newWebhookHandler(ctrl, nil)
+
+	body := `{"url":"ftp://example","secret":"short","event_types":["user.deleted"]}`
+	w := httptest.NewRecorder()
+	handler.Create(w, httptest.NewRequest("POST", webhooksPath, bytes.NewReader([]byte(body))))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var response struct {
+		Errors []map[string]string `json:"errors"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	if len(response.Errors) != 3 {
+		t.Errorf("expected url, event_types and secret errors, got %v", response.Errors)
+	}
+}
+
+func TestWebhookHandler_GetAll_HidesSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newWebhookHandler(ctrl, nil)
+
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return([]models.WebhookSubscription{{ID: 1, URL: "https://a.example", Secret: testSecret}}, nil)
+
+	w := httptest.NewRecorder()
+	handler.GetAll(w, httptest.NewRequest("GET", webhooksPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte(testSecret)) {
+		t.Error("secret must not be listed")
+	}
+}
+
+func TestWebhookHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newWebhookHandler(ctrl, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.WebhookSubscription{ID: 1, URL: "https://a.example", Active: true}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *models.WebhookSubscription) error {
+		if s.Active || !s.EventTypes.Contains("dataset.imported") {
+			t.Errorf("unexpected update %+v", s)
+		}
+		return nil
+	})
+
+	body := `{"active":false,"event_types":["dataset.imported"]}`
+	w := httptest.NewRecorder()
+	handler.Update(w, withID(httptest.NewRequest("PUT", webhooksPathID, bytes.NewReader([]byte(body)))))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestWebhookHandler_Delete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newWebhookHandler(ctrl, nil)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 1).Return(ErrSubscriptionNotFound)
+
+	w := httptest.NewRecorder()
+	handler.Delete(w, withID(httptest.NewRequest("DELETE", webhooksPathID, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newWebhookHandler(ctrl, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.WebhookSubscription{ID: 1}, nil)
+	mockRepo.EXPECT().GetDeliveries(gomock.Any(), 1, maxDeliveryLimit).Return([]models.WebhookDelivery{{ID: 3}}, nil)
+
+	w := httptest.NewRecorder()
+	handler.GetDeliveries(w, withID(httptest.NewRequest("GET", webhooksPathID+"/deliveries?limit=1000", nil)))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestWebhookHandler_GetDeliveries_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo, handler := newWebhookHandler(ctrl, nil)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, errors.New("db error"))
+
+	w := httptest.NewRecorder()
+	handler.GetDeliveries(w, withID(httptest.NewRequest("GET", webhooksPathID+"/deliveries", nil)))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestWebhookHandler_Test_FiresAgainstStandIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stand := &receiver{}
+	server := httptest.NewServer(stand)
+	defer server.Close()
+	mockRepo, handler := newWebhookHandler(ctrl, server.Client())
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&models.WebhookSubscription{ID: 1, URL: server.URL, Secret: testSecret}, nil)
+	mockRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Return(nil)
+
+	w := httptest.NewRecorder()
+	handler.Test(w, withID(httptest.NewRequest("POST", webhooksPathID+"/test", nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data models.WebhookDelivery `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	if response.Data.Status != models.WebhookDeliverySucceeded || response.Data.EventType != TestEvent {
+		t.Errorf("unexpected delivery %+v", response.Data)
+	}
+	if len(stand.requests) != 1 || stand.requests[0].Header.Get(HeaderEvent) != TestEvent {
+		t.Errorf("expected one test request at the stand-in")
+	}
+}
@@ -0,0 +1,33 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"time"
+	"tsukamoto/internal/models"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetAll(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetByID(ctx context.Context, id int) (*models.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *models.WebhookSubscription) error
+	Delete(ctx context.Context, id int) error
+	GetActiveByEventType(ctx context.Context, eventType string) ([]models.WebhookSubscription, error)
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, subscriptionID int, limit int) ([]models.WebhookDelivery, error)
+	// ClaimDue returns up to limit pending deliveries due at now and postpones
+	// them by lease, so that no other worker retries them at the same time
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+}
+
+type WebhookHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	GetDeliveries(w http.ResponseWriter, r *http.Request)
+	Test(w http.ResponseWriter, r *http.Request)
+}
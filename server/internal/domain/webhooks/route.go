@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"context"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/tenant"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// WebhookRoute registers the webhook subscription routes, forwards public
+// events to the subscribed URLs and starts the worker that retries failed
+// deliveries
+func WebhookRoute(r *mux.Router, db *gorm.DB, bus *events.Bus, cfg *config.Config) {
+	repo := NewWebhookRepository(db)
+	dispatcher := NewDispatcher(repo, nil, OptionsFromConfig(cfg))
+	handler := NewWebhookHandler(repo, dispatcher)
+
+	dispatcher.Subscribe(bus)
+	// Tanpa database (misalnya saat route hanya didaftarkan untuk pengujian) tidak ada antrean retry
+	if db != nil {
+		go dispatcher.Run(tenant.WithSystem(context.Background()))
+	}
+
+	r.HandleFunc("/webhooks", handler.Create).Methods("POST")
+	r.HandleFunc("/webhooks", handler.GetAll).Methods("GET")
+	r.HandleFunc("/webhooks/{id}", handler.GetByID).Methods("GET")
+	r.HandleFunc("/webhooks/{id}", handler.Update).Methods("PUT")
+	r.HandleFunc("/webhooks/{id}", handler.Delete).Methods("DELETE")
+	r.HandleFunc("/webhooks/{id}/deliveries", handler.GetDeliveries).Methods("GET")
+	r.HandleFunc("/webhooks/{id}/test", handler.Test).Methods("POST")
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Event types published inside the application
+const (
+	// AcademicChanged is published after academic records are created, updated
+	// or imported; the payload is AcademicChangedPayload
+	AcademicChanged = "academic.changed"
+	// AssessmentCreated is published after an assessment is stored; the payload is *models.Assessment
+	AssessmentCreated = "assessment.created"
+	// AlertOpened is published after an early-warning alert is opened; the payload is *models.Alert
+	AlertOpened = "alert.opened"
+	// DatasetImported is published after a CSV import is stored; the payload is DatasetImportedPayload
+	DatasetImported = "dataset.imported"
+)
+
+// Public lists the event types other systems may subscribe to through webhooks
+func Public() []string {
+	return []string{AssessmentCreated, AlertOpened, DatasetImported}
+}
+
+// AcademicChangedPayload lists the students whose academic data changed
+type AcademicChangedPayload struct {
+	UserIDs []uint `json:"user_ids"`
+}
+
+// DatasetImportedPayload summarises a completed dataset import
+type DatasetImportedPayload struct {
+	Count        int    `json:"count"`
+	UniversityID uint   `json:"university_id,omitempty"`
+	UserIDs      []uint `json:"user_ids"`
+}
+
+// Event is a single occurrence delivered to subscribers
+type Event struct {
+	Type    string
+	Payload interface{}
+}
+
+// Handler reacts to an event. Handlers run synchronously in the publisher's
+// goroutine, so slow work should be handed off by the handler itself.
+type Handler func(ctx context.Context, event Event)
+
+// Bus dispatches events to the handlers subscribed to their type. A nil *Bus
+// is valid and drops every event, which keeps handlers usable in tests.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates an empty Bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for events of the given type
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish delivers the event to every handler subscribed to its type
+func (b *Bus) Publish(ctx context.Context, eventType string, payload interface{}) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := append([]Handler(nil), b.handlers[eventType]...)
+	b.mu.RUnlock()
+
+	event := Event{Type: eventType, Payload: payload}
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
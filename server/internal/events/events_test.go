@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBus_PublishDeliversToSubscribers(t *testing.T) {
+	bus := NewBus()
+
+	var received []Event
+	bus.Subscribe(AlertOpened, func(_ context.Context, event Event) {
+		received = append(received, event)
+	})
+	bus.Subscribe(AlertOpened, func(_ context.Context, event Event) {
+		received = append(received, event)
+	})
+
+	bus.Publish(context.Background(), AlertOpened, 42)
+	bus.Publish(context.Background(), AssessmentCreated, 7)
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 deliveries, got %d", len(received))
+	}
+	for _, event := range received {
+		if event.Type != AlertOpened || event.Payload != 42 {
+			t.Errorf("unexpected event %+v", event)
+		}
+	}
+}
+
+func TestBus_NilBusDropsEvents(t *testing.T) {
+	var bus *Bus
+	bus.Publish(context.Background(), AcademicChanged, AcademicChangedPayload{UserIDs: []uint{1}})
+}
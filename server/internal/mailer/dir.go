@@ -0,0 +1,23 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirSender writes every message to an .eml file in Dir instead of sending
+// it, so emails can be inspected during development.
+type DirSender struct {
+	Dir string
+}
+
+// Send writes msg to a new file named after the current time
+func (s *DirSender) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + randomHex(4) + ".eml"
+	return os.WriteFile(filepath.Join(s.Dir, name), msg.Bytes(), 0o644)
+}
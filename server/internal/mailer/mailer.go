@@ -0,0 +1,103 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"strconv"
+	"strings"
+	"time"
+	"tsukamoto/config"
+)
+
+// DefaultFrom is the sender address used when SMTP_FROM is not set
+const DefaultFrom = "no-reply@tsukamoto.local"
+
+// Message is a plain-text email
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	Date    time.Time
+}
+
+// Sender delivers messages
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Bytes renders the message in RFC 5322 format with a quoted-printable UTF-8 body
+func (m Message) Bytes() []byte {
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@%s>\r\n", randomHex(12), domain(m.From))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n")
+	writer := quotedprintable.NewWriter(&b)
+	writer.Write([]byte(body))
+	writer.Close()
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
+
+// FromConfig returns the sender configured by cfg: a DirSender in development
+// mode (EMAIL_DEV_DIR), otherwise an SMTPSender when SMTP_HOST is set. It
+// returns nil when email is not configured.
+func FromConfig(cfg *config.Config) Sender {
+	if cfg.EmailDevDir != "" {
+		return &DirSender{Dir: cfg.EmailDevDir}
+	}
+	if cfg.SMTPHost == "" {
+		return nil
+	}
+
+	port := cfg.SMTPPort
+	if _, err := strconv.Atoi(port); err != nil {
+		port = "587"
+	}
+	return &SMTPSender{
+		Host:     cfg.SMTPHost,
+		Port:     port,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+	}
+}
+
+// FromAddress returns the configured sender address
+func FromAddress(cfg *config.Config) string {
+	if cfg.SMTPFrom != "" {
+		return cfg.SMTPFrom
+	}
+	return DefaultFrom
+}
+
+func domain(address string) string {
+	address = strings.TrimSuffix(address, ">")
+	if i := strings.LastIndex(address, "@"); i >= 0 && i < len(address)-1 {
+		return address[i+1:]
+	}
+	return "localhost"
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
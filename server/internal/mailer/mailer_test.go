@@ -0,0 +1,218 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"tsukamoto/config"
+)
+
+// fakeSMTPServer is a minimal SMTP server on the loopback interface that
+// records the transactions it accepts
+type fakeSMTPServer struct {
+	listener   net.Listener
+	rejectRcpt bool
+
+	mu         sync.Mutex
+	auth       string
+	from       string
+	recipients []string
+	data       string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeSMTPServer{listener: listener}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeSMTPServer) sender(username, password string) *SMTPSender {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return &SMTPSender{Host: host, Port: port, Username: username, Password: password}
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 fake.smtp ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		s.mu.Lock()
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250-fake.smtp")
+			reply("250-AUTH PLAIN")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(command, "AUTH PLAIN "):
+			s.auth = line[len("AUTH PLAIN "):]
+			reply("235 2.7.0 Authentication successful")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.from = strings.Trim(strings.Fields(line[len("MAIL FROM:"):])[0], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			if s.rejectRcpt {
+				reply("550 mailbox unavailable")
+				break
+			}
+			s.recipients = append(s.recipients, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					s.mu.Unlock()
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.data = data.String()
+			reply("250 OK queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("250 OK")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func sampleMessage() Message {
+	return Message{
+		From:    "no-reply@kampus.ac.id",
+		To:      []string{"budi@kampus.ac.id"},
+		Subject: "Peringatan dini: skor Anda turun",
+		Body:    "Halo Budi,\nskor Anda sekarang 55,0 — di bawah ambang 60.",
+	}
+}
+
+func readBody(t *testing.T, raw string) (*mail.Message, string) {
+	parsed, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed, string(body)
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	server := newFakeSMTPServer(t)
+
+	if err := server.sender("mailer", "secret").Send(context.Background(), sampleMessage()); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	credentials, _ := base64.StdEncoding.DecodeString(server.auth)
+	if string(credentials) != "\x00mailer\x00secret" {
+		t.Errorf("unexpected credentials %q", credentials)
+	}
+	if server.from != "no-reply@kampus.ac.id" || len(server.recipients) != 1 || server.recipients[0] != "budi@kampus.ac.id" {
+		t.Errorf("unexpected envelope from %q to %v", server.from, server.recipients)
+	}
+
+	parsed, body := readBody(t, server.data)
+	subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if subject != "Peringatan dini: skor Anda turun" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+	if !strings.Contains(body, "55,0 — di bawah ambang 60") {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestSMTPSender_RejectedRecipient(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.rejectRcpt = true
+
+	err := server.sender("", "").Send(context.Background(), sampleMessage())
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("expected rejection error, got %v", err)
+	}
+}
+
+func TestSMTPSender_NoRecipients(t *testing.T) {
+	sender := &SMTPSender{Host: "127.0.0.1", Port: "25"}
+	if err := sender.Send(context.Background(), Message{From: "a@b.c"}); err == nil {
+		t.Error("expected error for message without recipients")
+	}
+}
+
+func TestDirSender_WritesMessage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	sender := &DirSender{Dir: dir}
+
+	if err := sender.Send(context.Background(), sampleMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v", files)
+	}
+	raw, _ := os.ReadFile(files[0])
+	parsed, body := readBody(t, string(raw))
+	if parsed.Header.Get("To") != "budi@kampus.ac.id" || !strings.Contains(body, "Halo Budi") {
+		t.Errorf("unexpected message %s", raw)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	if sender := FromConfig(&config.Config{}); sender != nil {
+		t.Errorf("expected no sender without configuration, got %T", sender)
+	}
+	if _, ok := FromConfig(&config.Config{SMTPHost: "smtp.example", EmailDevDir: "/tmp/mail"}).(*DirSender); !ok {
+		t.Error("development directory must take precedence over SMTP")
+	}
+	sender, ok := FromConfig(&config.Config{SMTPHost: "smtp.example", SMTPPort: "x"}).(*SMTPSender)
+	if !ok || sender.Port != "587" {
+		t.Errorf("unexpected sender %+v", sender)
+	}
+	if FromAddress(&config.Config{}) != DefaultFrom {
+		t.Error("expected default from address")
+	}
+}
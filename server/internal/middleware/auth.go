@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"tsukamoto/internal/utils"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+)
+
+var (
+	// ErrTokenExpired is returned by a TokenValidator for an expired token
+	ErrTokenExpired = errors.New("token expired")
+	// ErrInvalidToken is returned by a TokenValidator for a malformed or forged token
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrSessionRevoked is returned by a TokenValidator for a token of a logged out session
+	ErrSessionRevoked = errors.New("session revoked")
+)
+
+// AuthUser is the caller identified by the bearer token
+type AuthUser struct {
+	ID                 uint
+	Username           string
+	Role               string
+	UniversityID       uint
+	MustChangePassword bool
+}
+
+type contextKey int
+
+const userKey contextKey = iota
+
+// WithUser stores the authenticated user in the context
+func WithUser(ctx context.Context, user AuthUser) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
+
+// UserFromContext returns the user stored by Authenticate
+func UserFromContext(ctx context.Context) (AuthUser, bool) {
+	user, ok := ctx.Value(userKey).(AuthUser)
+	return user, ok
+}
+
+// ClientIP returns the address the request came from, without the port
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// TokenValidator turns a bearer token into the authenticated user
+type TokenValidator func(token string) (AuthUser, error)
+
+// SessionChecker reports whether the session an access token was issued
+// for is still active
+type SessionChecker interface {
+	Active(sessionID string) (bool, error)
+}
+
+// JWTValidator reads the claims of access tokens issued by the token
+// service. Tokens without a user or role are rejected, as are tokens of a
+// revoked session when sessions is not nil.
+func JWTValidator(tokens *utils.JWTUtil, sessions SessionChecker) TokenValidator {
+	return func(tokenString string) (AuthUser, error) {
+		claims, err := tokens.ValidateJWT(tokenString)
+		if err != nil {
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				return AuthUser{}, ErrTokenExpired
+			}
+			return AuthUser{}, ErrInvalidToken
+		}
+		if claims.UserID <= 0 || claims.Role == "" || claims.UniversityID < 0 {
+			return AuthUser{}, ErrInvalidToken
+		}
+		if sessions != nil {
+			if claims.SessionID == "" {
+				return AuthUser{}, ErrInvalidToken
+			}
+			active, err := sessions.Active(claims.SessionID)
+			if err != nil {
+				return AuthUser{}, err
+			}
+			if !active {
+				return AuthUser{}, ErrSessionRevoked
+			}
+		}
+		return AuthUser{
+			ID:                 uint(claims.UserID),
+			Username:           claims.Username,
+			Role:               claims.Role,
+			UniversityID:       uint(claims.UniversityID),
+			MustChangePassword: claims.MustChangePassword,
+		}, nil
+	}
+}
+
+// PublicRoute is a route reachable without a token, identified by its
+// method and mux path template
+type PublicRoute struct {
+	Method string
+	Path   string
+}
+
+func isPublic(r *http.Request, public []PublicRoute) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+	for _, p := range public {
+		if p.Path == template && (p.Method == "" || p.Method == r.Method) {
+			return true
+		}
+	}
+	return false
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	utils.WriteResponse(w, http.StatusUnauthorized, []utils.ErrorDetail{{Field: "authorization", Message: message}}, nil)
+}
+
+// Authenticate requires a valid "Authorization: Bearer <token>" header on
+// every route except the public ones and stores the caller in the request
+// context.
+func Authenticate(validate TokenValidator, public ...PublicRoute) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isPublic(r, public) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := r.Header.Get("Authorization")
+			scheme, token, found := strings.Cut(header, " ")
+			token = strings.TrimSpace(token)
+			if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				unauthorized(w, "Missing bearer token")
+				return
+			}
+
+			user, err := validate(token)
+			if errors.Is(err, ErrTokenExpired) {
+				unauthorized(w, "Token has expired")
+				return
+			}
+			if errors.Is(err, ErrSessionRevoked) {
+				unauthorized(w, "Session has been revoked")
+				return
+			}
+			if errors.Is(err, ErrInvalidToken) {
+				unauthorized(w, "Invalid token")
+				return
+			}
+			if err != nil {
+				utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Failed to verify session"}}, nil)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+		})
+	}
+}
@@ -10,14 +10,34 @@ type Academic struct {
     ID                int            `json:"id" gorm:"primaryKey;autoIncrement;not null"`
     UniversityID      uint           `json:"university_id" gorm:"column:university_id;not null"`
     University        University     `json:"university,omitempty" gorm:"foreignKey:UniversityID;references:ID"`
-    UserID            uint           `json:"user_id" gorm:"column:user_id;not null"`
+    UserID            uint           `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_academics_user_term,where:deleted_at IS NULL"`
     User              User           `json:"user,omitempty" gorm:"foreignKey:UserID;references:ID"`
+    // Term tempat nilai berlaku; satu record per mahasiswa per term
+    TermID            *uint          `json:"term_id" gorm:"column:term_id;index;uniqueIndex:idx_academics_user_term,where:deleted_at IS NULL"`
+    Term              *Term          `json:"term,omitempty" gorm:"foreignKey:TermID;references:ID"`
     CoreCourseAverage float32        `json:"core_course_average" gorm:"column:core_course_average;type:float"`
     AttendanceRate    float32        `json:"attendance_rate" gorm:"column:attendance_rate;type:float"`
     FinalExamScore    float32        `json:"final_exam_score" gorm:"column:final_exam_score;type:float"`
     GPA               float32        `json:"gpa" gorm:"column:gpa;type:float"`
     MidtermExamScore  float32        `json:"midterm_exam_score" gorm:"column:midterm_exam_score;type:float"`
+    // Asal tiap nilai per nama field JSON: manual atau computed dari nilai mata kuliah
+    Provenance        StringMap      `json:"provenance" gorm:"type:jsonb"`
     CreatedAt         time.Time      `json:"created_at" gorm:"autoCreateTime"`
     UpdatedAt         time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
     DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
-}
\ No newline at end of file
+}
+
+// Asal nilai pada data akademik
+const (
+	ProvenanceManual   = "manual"
+	ProvenanceComputed = "computed"
+)
+
+// Source returns the provenance of a field. Values without a recorded
+// provenance were typed in before course grades existed and count as manual.
+func (a Academic) Source(field string) string {
+	if source, ok := a.Provenance[field]; ok {
+		return source
+	}
+	return ProvenanceManual
+}
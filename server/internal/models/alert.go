@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Status alert early-warning
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+)
+
+// Alasan alert dibuka
+const (
+	AlertReasonBelowThreshold = "below_threshold"
+	AlertReasonScoreDrop      = "score_drop"
+)
+
+// Alert menandai mahasiswa berisiko berdasarkan hasil assessment
+type Alert struct {
+	ID            int        `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	UserID        uint       `json:"user_id" gorm:"column:user_id;not null;index"`
+	User          *User      `json:"user,omitempty" gorm:"foreignKey:UserID;references:ID"`
+	AssessmentID  int        `json:"assessment_id" gorm:"column:assessment_id;not null"`
+	Reason        string     `json:"reason" gorm:"size:30;not null"`
+	CrispOutput   float64    `json:"crisp_output" gorm:"column:crisp_output;not null"`
+	PreviousScore *float64   `json:"previous_score,omitempty" gorm:"column:previous_score"`
+	Threshold     float64    `json:"threshold" gorm:"column:threshold;not null"`
+	Status        string     `json:"status" gorm:"size:20;not null;default:open;index"`
+	AdvisorID     *uint      `json:"advisor_id" gorm:"column:advisor_id;index"`
+	Advisor       *User      `json:"advisor,omitempty" gorm:"foreignKey:AdvisorID;references:ID"`
+	Note          string     `json:"note" gorm:"type:text"`
+	ResolvedAt    *time.Time `json:"resolved_at"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
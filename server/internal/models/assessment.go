@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Assessment menyimpan hasil evaluasi fuzzy seorang mahasiswa. Indeks
+// (academic_id, method, id) melayani pencarian asesmen terakhir per record pada analitik.
+type Assessment struct {
+	ID          int       `json:"id" gorm:"primaryKey;autoIncrement;not null;index:idx_assessments_academic_method,priority:3"`
+	UserID      uint      `json:"user_id" gorm:"column:user_id;not null;index"`
+	User        *User     `json:"user,omitempty" gorm:"foreignKey:UserID;references:ID"`
+	AcademicID  int       `json:"academic_id" gorm:"column:academic_id;not null;index:idx_assessments_academic_method,priority:1"`
+	TermID      *uint     `json:"term_id" gorm:"column:term_id;index"`
+	Method      string    `json:"method" gorm:"size:20;not null;index:idx_assessments_academic_method,priority:2"`
+	Category    string    `json:"category" gorm:"size:30;not null"`
+	CrispOutput float64   `json:"crisp_output" gorm:"column:crisp_output;not null"`
+	Inputs      JSON      `json:"inputs" gorm:"type:jsonb"`
+	Explanation JSON      `json:"explanation" gorm:"type:jsonb"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Status kehadiran mahasiswa pada satu pertemuan
+const (
+	AttendancePresent = "present"
+	AttendanceLate    = "late"
+	AttendanceExcused = "excused"
+	AttendanceAbsent  = "absent"
+)
+
+// AttendanceStatuses lists every attendance status
+func AttendanceStatuses() []string {
+	return []string{AttendancePresent, AttendanceLate, AttendanceExcused, AttendanceAbsent}
+}
+
+// AttendanceSession adalah satu pertemuan mata kuliah dalam sebuah term
+type AttendanceSession struct {
+	ID            int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	CourseID      int       `json:"course_id" gorm:"column:course_id;not null;uniqueIndex:idx_attendance_sessions_number"`
+	Course        *Course   `json:"course,omitempty" gorm:"foreignKey:CourseID;references:ID"`
+	TermID        *uint     `json:"term_id" gorm:"column:term_id;index;uniqueIndex:idx_attendance_sessions_number"`
+	SessionNumber int       `json:"session_number" gorm:"not null;uniqueIndex:idx_attendance_sessions_number"`
+	Date          time.Time `json:"date" gorm:"type:date;not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// AttendanceRecord adalah status kehadiran seorang mahasiswa pada satu pertemuan
+type AttendanceRecord struct {
+	ID        int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	SessionID int       `json:"session_id" gorm:"column:session_id;not null;uniqueIndex:idx_attendance_records_session_user"`
+	UserID    uint      `json:"user_id" gorm:"column:user_id;not null;index;uniqueIndex:idx_attendance_records_session_user"`
+	Status    string    `json:"status" gorm:"size:10;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Aksi yang dicatat di audit log
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+	AuditImport = "import"
+	AuditMerge  = "merge"
+)
+
+// Jenis entitas di audit log
+const (
+	AuditEntityUser        = "user"
+	AuditEntityAcademic    = "academic"
+	AuditEntityDataset     = "dataset"
+	AuditEntityGrade       = "grade"
+	AuditEntityUniversity  = "university"
+	AuditEntityFuzzyConfig = "fuzzy_config"
+)
+
+// AuditLog adalah satu catatan perubahan data. Setiap entri menyimpan hash
+// entri sebelumnya sehingga mengubah atau menghapus entri lama memutus rantai
+// dan terdeteksi saat verifikasi.
+type AuditLog struct {
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	ActorID    *uint     `json:"actor_id" gorm:"index"` // Kosong untuk aksi sistem
+	ActorRole  string    `json:"actor_role,omitempty" gorm:"size:20"`
+	Action     string    `json:"action" gorm:"size:20;not null;index"`
+	EntityType string    `json:"entity_type" gorm:"size:50;not null;index:idx_audit_logs_entity"`
+	EntityID   string    `json:"entity_id,omitempty" gorm:"size:64;index:idx_audit_logs_entity"`
+	Changes    JSON      `json:"changes" gorm:"type:jsonb"` // Field yang berubah: {"field": {"from": ..., "to": ...}}
+	IP         string    `json:"ip,omitempty" gorm:"size:45"`
+	CreatedAt  time.Time `json:"created_at" gorm:"not null;index"`
+	PrevHash   string    `json:"prev_hash" gorm:"size:64;not null"`
+	Hash       string    `json:"hash" gorm:"size:64;not null;uniqueIndex"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Komponen nilai sebuah mata kuliah
+const (
+	GradeMidterm    = "midterm"
+	GradeFinalExam  = "final_exam"
+	GradeCoursework = "coursework"
+)
+
+// GradeComponents lists every grade component in display order
+func GradeComponents() []string {
+	return []string{GradeMidterm, GradeFinalExam, GradeCoursework}
+}
+
+// Course adalah mata kuliah sebuah universitas. Mata kuliah inti (Core)
+// membentuk core course average.
+type Course struct {
+	ID           int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	UniversityID uint      `json:"university_id" gorm:"column:university_id;not null;uniqueIndex:idx_courses_university_code"`
+	Code         string    `json:"code" gorm:"size:20;not null;uniqueIndex:idx_courses_university_code"`
+	Name         string    `json:"name" gorm:"size:100;not null"`
+	Credits      int       `json:"credits" gorm:"not null"`
+	Core         bool      `json:"core" gorm:"not null;default:false"`
+	LecturerID   *uint     `json:"lecturer_id" gorm:"column:lecturer_id;index"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Enrollment adalah keikutsertaan seorang mahasiswa pada mata kuliah di satu term
+type Enrollment struct {
+	ID        int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	CourseID  int       `json:"course_id" gorm:"column:course_id;not null;uniqueIndex:idx_enrollments_course_user_term"`
+	Course    *Course   `json:"course,omitempty" gorm:"foreignKey:CourseID;references:ID"`
+	UserID    uint      `json:"user_id" gorm:"column:user_id;not null;index;uniqueIndex:idx_enrollments_course_user_term"`
+	TermID    *uint     `json:"term_id" gorm:"column:term_id;index;uniqueIndex:idx_enrollments_course_user_term"`
+	Grades    []Grade   `json:"grades,omitempty" gorm:"foreignKey:EnrollmentID;references:ID"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Grade adalah nilai satu komponen penilaian (0-100) pada sebuah enrollment
+type Grade struct {
+	ID           int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	EnrollmentID int       `json:"enrollment_id" gorm:"column:enrollment_id;not null;uniqueIndex:idx_grades_enrollment_component"`
+	Component    string    `json:"component" gorm:"size:20;not null;uniqueIndex:idx_grades_enrollment_component"`
+	Score        float64   `json:"score" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
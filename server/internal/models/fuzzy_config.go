@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// FuzzyConfig overrides the fuzzy model of one university. Config holds the
+// variables, rules and category thresholds as validated by asesmen.Config.
+type FuzzyConfig struct {
+	ID           int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	UniversityID uint      `json:"university_id" gorm:"column:university_id;not null;uniqueIndex"`
+	Config       JSON      `json:"config" gorm:"type:jsonb;not null"`
+	UpdatedBy    *uint     `json:"updated_by" gorm:"column:updated_by"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
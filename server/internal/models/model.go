@@ -3,7 +3,23 @@ package models
 func GetModelsToMigrate() []interface{} {
 	return []interface{}{
 		&User{},
+		&RefreshToken{},
+		&PasswordReset{},
 		&Academic{},
 		&University{},
+		&Term{},
+		&Course{},
+		&Enrollment{},
+		&Grade{},
+		&AttendanceSession{},
+		&AttendanceRecord{},
+		&Assessment{},
+		&Alert{},
+		&WebhookSubscription{},
+		&WebhookDelivery{},
+		&NotificationPreference{},
+		&Notification{},
+		&AuditLog{},
+		&FuzzyConfig{},
 	}
 }
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Jenis notifikasi email
+const (
+	NotificationCategoryChanged = "category_changed"
+	NotificationAlertOpened     = "alert_opened"
+	// Email reset password; isinya memuat token sehingga tidak ditampilkan di daftar antrean
+	NotificationPasswordReset = "password_reset"
+)
+
+// Status antrean notifikasi
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// Bahasa email yang didukung
+const (
+	LanguageIndonesian = "id"
+	LanguageEnglish    = "en"
+)
+
+// NotificationPreference menyimpan pilihan notifikasi email seorang user.
+// User tanpa baris preferensi menerima semua notifikasi dalam bahasa Indonesia.
+type NotificationPreference struct {
+	UserID       uint       `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	Language     string     `json:"language" gorm:"size:2;not null;default:id"`
+	Unsubscribed bool       `json:"unsubscribed" gorm:"not null;default:false"`
+	OptOut       StringList `json:"opt_out" gorm:"type:jsonb;not null"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Allows reports whether the user wants to receive notifications of kind
+func (p NotificationPreference) Allows(kind string) bool {
+	return !p.Unsubscribed && !p.OptOut.Contains(kind)
+}
+
+// Notification adalah satu email di antrean pengiriman
+type Notification struct {
+	ID            int        `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	UserID        uint       `json:"user_id" gorm:"column:user_id;not null;index"`
+	Recipient     string     `json:"recipient" gorm:"size:100;not null"`
+	Kind          string     `json:"kind" gorm:"size:40;not null"`
+	Language      string     `json:"language" gorm:"size:2;not null"`
+	Subject       string     `json:"subject" gorm:"size:255;not null"`
+	Body          string     `json:"body" gorm:"type:text;not null"`
+	Status        string     `json:"status" gorm:"size:20;not null;default:pending;index"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
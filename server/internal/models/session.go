@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// RefreshToken adalah refresh token yang disimpan sebagai hash SHA-256.
+// Setiap refresh merotasi token menjadi token baru dalam family yang sama;
+// token yang sudah dirotasi lalu dipakai lagi dianggap bocor dan seluruh
+// family-nya dicabut.
+type RefreshToken struct {
+	ID        int        `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	UserID    uint       `json:"user_id" gorm:"column:user_id;not null;index"`
+	FamilyID  string     `json:"family_id" gorm:"size:64;not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"` // Diganti oleh token berikutnya
+	RevokedAt *time.Time `json:"revoked_at,omitempty"` // Logout, revoke-all atau reuse
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Usable reports whether the token can still be exchanged at now
+func (t RefreshToken) Usable(now time.Time) bool {
+	return t.RotatedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// PasswordReset adalah token reset password sekali pakai. Yang disimpan hanya
+// hash SHA-256-nya; token aslinya hanya dikirim ke email pemilik akun.
+type PasswordReset struct {
+	ID        int        `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	UserID    uint       `json:"user_id" gorm:"column:user_id;not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // Dipakai atau digantikan token yang lebih baru
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Usable reports whether the token can still reset the password at now
+func (t PasswordReset) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Semester dalam satu tahun akademik
+const (
+	SemesterOdd   = "odd"
+	SemesterEven  = "even"
+	SemesterShort = "short"
+)
+
+// Term adalah satu semester dalam tahun akademik, misalnya 2025/2026 odd
+type Term struct {
+	ID           int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	AcademicYear string    `json:"academic_year" gorm:"size:9;not null;uniqueIndex:idx_terms_period"`
+	Semester     string    `json:"semester" gorm:"size:10;not null;uniqueIndex:idx_terms_period"`
+	StartDate    time.Time `json:"start_date" gorm:"type:date;not null;index"`
+	EndDate      time.Time `json:"end_date" gorm:"type:date;not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Label names the term, e.g. "2025/2026 odd"
+func (t Term) Label() string {
+	return t.AcademicYear + " " + t.Semester
+}
+
+// LatestTermFirst orders academic records from the most recent term. Records
+// without a term predate terms and come last.
+func LatestTermFirst(db *gorm.DB) *gorm.DB {
+	return db.Joins("LEFT JOIN terms ON terms.id = academics.term_id").
+		Order("terms.start_date DESC NULLS LAST").
+		Order("academics.id DESC")
+}
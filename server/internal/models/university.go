@@ -7,10 +7,15 @@ import (
 )
 
 type University struct {
-	ID        int            `json:"id" gorm:"primaryKey;autoIncrement;not null"`
-	Name      string         `json:"name" gorm:"size:100;not null"`
-	Address   string         `json:"address" gorm:"size:255;not null"`
-	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID      int    `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	Name    string `json:"name" gorm:"size:100;not null"`
+	Address string `json:"address" gorm:"size:255;not null"`
+	// Skala penilaian universitas: GPA maksimum (4 atau 5), attendance maksimum
+	// (1 untuk pecahan, 100 untuk persen) dan nilai ujian/CCA maksimum
+	GPAScale        float32        `json:"gpa_scale" gorm:"column:gpa_scale;type:float;not null;default:4"`
+	AttendanceScale float32        `json:"attendance_scale" gorm:"column:attendance_scale;type:float;not null;default:1"`
+	ScoreScale      float32        `json:"score_scale" gorm:"column:score_scale;type:float;not null;default:100"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
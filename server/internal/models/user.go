@@ -1,34 +1,89 @@
 package models
 
 import (
+	"errors"
 	"time"
 	password "tsukamoto/internal/utils"
 
 	"gorm.io/gorm"
 )
 
+// Peran user yang dikenali oleh lapisan otorisasi
+const (
+	RoleAdmin    = "admin"
+	RoleLecturer = "lecturer"
+	RoleAdvisor  = "advisor"
+	RoleStudent  = "student"
+	// RoleSuperAdmin adalah admin tanpa universitas yang boleh mengakses semua universitas
+	RoleSuperAdmin = "super_admin"
+)
+
+// Roles returns every role a user may hold
+func Roles() []string {
+	return []string{RoleSuperAdmin, RoleAdmin, RoleLecturer, RoleAdvisor, RoleStudent}
+}
+
+// ValidRole reports whether role is one of Roles
+func ValidRole(role string) bool {
+	for _, r := range Roles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 type User struct {
-	ID        int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
-	Username  string    `json:"username" gorm:"size:50;unique;not null"`
-	Name      string    `json:"name" gorm:"size:50"`
-	Password  string    `json:"-" gorm:"size:255;not null"`
-	Role      string    `json:"role" gorm:"size:20;not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                 int       `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	Username           string    `json:"username" gorm:"size:50;unique;not null"`
+	Name               string    `json:"name" gorm:"size:50"`
+	Email              string    `json:"email,omitempty" gorm:"size:100"`
+	Password           string    `json:"-" gorm:"size:255;not null"`
+	Role               string    `json:"role" gorm:"size:20;not null"`
+	UniversityID       *uint     `json:"university_id,omitempty" gorm:"column:university_id;index"` // Kosong untuk super admin
+	AdvisorID          *uint     `json:"advisor_id,omitempty" gorm:"column:advisor_id;index"`       // Dosen wali mahasiswa
+	MustChangePassword bool      `json:"must_change_password" gorm:"not null;default:false"`        // Akun seed, impor atau buatan admin
+	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
-// SeederAdminUser membuat user admin jika belum ada
+// SeederAdminUser membuat super admin jika belum ada. Admin lama yang masih
+// memakai password default ditandai agar wajib mengganti password.
 func SeederAdminUser(db *gorm.DB) error {
-	var count int64
-	db.Model(&User{}).Where("username = ?", "admin").Count(&count)
-	if count == 0 {
-		admin := User{
+	var admin User
+	err := db.Where("username = ?", "admin").First(&admin).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		admin = User{
 			Username: "admin",
 			Name:     "Administrator",
-			Password: password.HashPassword("admin123"),
-			Role:     "admin",
+			Password: password.HashPassword(password.DefaultAdminPassword),
+			Role:     RoleSuperAdmin,
+			// Password default harus diganti saat login pertama
+			MustChangePassword: true,
 		}
 		return db.Create(&admin).Error
 	}
+	if err != nil {
+		return err
+	}
+	if !admin.MustChangePassword && password.CheckPassword(password.DefaultAdminPassword, admin.Password) {
+		return db.Model(&admin).Update("must_change_password", true).Error
+	}
 	return nil
 }
+
+// MigrateTenancy memindahkan data lama ke model multi-universitas: admin tanpa
+// universitas menjadi super admin dan mahasiswa yang universitasnya hanya
+// tercatat di data akademik mendapat university_id
+func MigrateTenancy(db *gorm.DB) error {
+	if err := db.Model(&User{}).
+		Where("role = ? AND university_id IS NULL", RoleAdmin).
+		Update("role", RoleSuperAdmin).Error; err != nil {
+		return err
+	}
+	return db.Exec(`UPDATE users SET university_id = (
+		SELECT a.university_id FROM academics a
+		WHERE a.user_id = users.id AND a.deleted_at IS NULL
+		ORDER BY a.id DESC LIMIT 1
+	) WHERE role = ? AND university_id IS NULL`, RoleStudent).Error
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Status pengiriman webhook
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookSubscription mendaftarkan URL sistem lain yang menerima event aplikasi
+type WebhookSubscription struct {
+	ID          int        `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	URL         string     `json:"url" gorm:"size:500;not null"`
+	Secret      string     `json:"-" gorm:"size:128;not null"`
+	EventTypes  StringList `json:"event_types" gorm:"type:jsonb;not null"`
+	Description string     `json:"description" gorm:"size:255"`
+	Active      bool       `json:"active" gorm:"not null;default:true"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// WebhookDelivery mencatat pengiriman satu event ke satu subscription
+type WebhookDelivery struct {
+	ID             int        `json:"id" gorm:"primaryKey;autoIncrement;not null"`
+	SubscriptionID int        `json:"subscription_id" gorm:"column:subscription_id;not null;index"`
+	EventID        string     `json:"event_id" gorm:"size:64;not null;index"`
+	EventType      string     `json:"event_type" gorm:"size:50;not null"`
+	Payload        JSON       `json:"payload" gorm:"type:jsonb"`
+	Status         string     `json:"status" gorm:"size:20;not null;default:pending"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	ResponseCode   int        `json:"response_code"`
+	LastError      string     `json:"last_error" gorm:"type:text"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at" gorm:"index"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,152 @@
+package agregasi
+
+import "tsukamoto/internal/models"
+
+// Weights is the share of each grade component in a course score. Components
+// missing from a course are left out and the remaining weights rescaled.
+type Weights map[string]float64
+
+// DefaultWeights weighs the midterm 30%, the final exam 40% and coursework 30%
+func DefaultWeights() Weights {
+	return Weights{
+		models.GradeMidterm:    0.3,
+		models.GradeFinalExam:  0.4,
+		models.GradeCoursework: 0.3,
+	}
+}
+
+// Course is the grading input of one enrollment
+type Course struct {
+	Credits int
+	Core    bool
+	// Scores maps a grade component to its 0-100 score
+	Scores map[string]float64
+}
+
+// Result holds the derived academic values. A nil value means no graded
+// course contributes to it.
+type Result struct {
+	GPA               *float64 `json:"gpa"`
+	CoreCourseAverage *float64 `json:"core_course_average"`
+	MidtermExamScore  *float64 `json:"midterm_exam_score"`
+	FinalExamScore    *float64 `json:"final_exam_score"`
+	// AttendanceRate is filled from attendance records rather than by Compute
+	AttendanceRate *float64 `json:"attendance_rate"`
+	// GradedCourses is the number of courses with at least one weighted grade
+	GradedCourses int `json:"graded_courses"`
+}
+
+// Fields returns the derived values keyed by academic JSON field name
+func (r Result) Fields() map[string]*float64 {
+	return map[string]*float64{
+		"gpa":                 r.GPA,
+		"core_course_average": r.CoreCourseAverage,
+		"midterm_exam_score":  r.MidtermExamScore,
+		"final_exam_score":    r.FinalExamScore,
+		"attendance_rate":     r.AttendanceRate,
+	}
+}
+
+// Complete reports whether every derived value is known
+func (r Result) Complete() bool {
+	for _, value := range r.Fields() {
+		if value == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Score returns the weighted course score, or false when no weighted component is graded
+func (w Weights) Score(scores map[string]float64) (float64, bool) {
+	var sum, total float64
+	for component, score := range scores {
+		weight := w[component]
+		if weight <= 0 {
+			continue
+		}
+		sum += weight * score
+		total += weight
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return sum / total, true
+}
+
+// GradePoint converts a 0-100 course score to the 4.0 scale using the usual
+// letter grade bands (A >= 85, A- >= 80, B+ >= 75, B >= 70, B- >= 65,
+// C+ >= 60, C >= 55, D >= 45, otherwise E)
+func GradePoint(score float64) float64 {
+	switch {
+	case score >= 85:
+		return 4.0
+	case score >= 80:
+		return 3.7
+	case score >= 75:
+		return 3.3
+	case score >= 70:
+		return 3.0
+	case score >= 65:
+		return 2.7
+	case score >= 60:
+		return 2.3
+	case score >= 55:
+		return 2.0
+	case score >= 45:
+		return 1.0
+	}
+	return 0
+}
+
+// creditMean accumulates a credit-weighted mean
+type creditMean struct {
+	sum     float64
+	credits float64
+}
+
+func (m *creditMean) add(value float64, credits int) {
+	m.sum += value * float64(credits)
+	m.credits += float64(credits)
+}
+
+func (m creditMean) value() *float64 {
+	if m.credits == 0 {
+		return nil
+	}
+	v := m.sum / m.credits
+	return &v
+}
+
+// Compute derives the academic values of one term from its courses. GPA is
+// the credit-weighted grade point, the core course average is the
+// credit-weighted score of core courses and the exam scores are the
+// credit-weighted midterm and final exam grades.
+func Compute(courses []Course, weights Weights) Result {
+	var gpa, core, midterm, final creditMean
+	var result Result
+	for _, course := range courses {
+		if course.Credits <= 0 {
+			continue
+		}
+		if score, ok := weights.Score(course.Scores); ok {
+			result.GradedCourses++
+			gpa.add(GradePoint(score), course.Credits)
+			if course.Core {
+				core.add(score, course.Credits)
+			}
+		}
+		if score, ok := course.Scores[models.GradeMidterm]; ok {
+			midterm.add(score, course.Credits)
+		}
+		if score, ok := course.Scores[models.GradeFinalExam]; ok {
+			final.add(score, course.Credits)
+		}
+	}
+
+	result.GPA = gpa.value()
+	result.CoreCourseAverage = core.value()
+	result.MidtermExamScore = midterm.value()
+	result.FinalExamScore = final.value()
+	return result
+}
@@ -0,0 +1,107 @@
+package agregasi
+
+import (
+	"math"
+	"testing"
+	"tsukamoto/internal/models"
+)
+
+func approx(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("%s = nil, want %v", name, want)
+	}
+	if math.Abs(*got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, *got, want)
+	}
+}
+
+func TestGradePoint(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  float64
+	}{
+		{100, 4.0}, {85, 4.0}, {84.9, 3.7}, {75, 3.3}, {70, 3.0}, {65, 2.7}, {60, 2.3}, {55, 2.0}, {45, 1.0}, {44.9, 0},
+	}
+	for _, tt := range tests {
+		if got := GradePoint(tt.score); got != tt.want {
+			t.Errorf("GradePoint(%v) = %v, want %v", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestWeights_ScoreRescalesMissingComponents(t *testing.T) {
+	weights := DefaultWeights()
+	score, ok := weights.Score(map[string]float64{models.GradeMidterm: 60, models.GradeFinalExam: 80})
+	if !ok {
+		t.Fatal("expected a score")
+	}
+	// (0.3*60 + 0.4*80) / 0.7
+	if want := 50.0 / 0.7; math.Abs(score-want) > 1e-9 {
+		t.Errorf("score = %v, want %v", score, want)
+	}
+	if _, ok := weights.Score(map[string]float64{"quiz": 90}); ok {
+		t.Error("unweighted components should not produce a score")
+	}
+}
+
+func TestCompute(t *testing.T) {
+	courses := []Course{
+		{Credits: 3, Core: true, Scores: map[string]float64{models.GradeMidterm: 80, models.GradeFinalExam: 90, models.GradeCoursework: 85}},
+		{Credits: 2, Core: false, Scores: map[string]float64{models.GradeMidterm: 60, models.GradeFinalExam: 70, models.GradeCoursework: 50}},
+		// Belum dinilai: tidak memengaruhi rata-rata
+		{Credits: 4, Core: true, Scores: map[string]float64{}},
+	}
+	result := Compute(courses, DefaultWeights())
+
+	if result.GradedCourses != 2 {
+		t.Errorf("GradedCourses = %d, want 2", result.GradedCourses)
+	}
+	// Skor: 85.5 (A, 4.0) dan 61 (C+, 2.3)
+	approx(t, "GPA", result.GPA, (3*4.0+2*2.3)/5)
+	approx(t, "CoreCourseAverage", result.CoreCourseAverage, 85.5)
+	approx(t, "MidtermExamScore", result.MidtermExamScore, (3*80.0+2*60)/5)
+	approx(t, "FinalExamScore", result.FinalExamScore, (3*90.0+2*70)/5)
+}
+
+func TestCompute_NoGrades(t *testing.T) {
+	result := Compute([]Course{{Credits: 3, Core: true}}, DefaultWeights())
+	for field, value := range result.Fields() {
+		if value != nil {
+			t.Errorf("%s = %v, want nil", field, *value)
+		}
+	}
+}
+
+func TestResult_Complete(t *testing.T) {
+	result := Compute([]Course{{Credits: 3, Core: true, Scores: map[string]float64{"midterm": 80, "final_exam": 90}}}, DefaultWeights())
+	// Presensi tidak dihitung oleh Compute, sehingga hasilnya belum lengkap
+	if result.Complete() {
+		t.Error("result without attendance should be incomplete")
+	}
+	rate := 0.9
+	result.AttendanceRate = &rate
+	if !result.Complete() {
+		t.Errorf("expected a complete result, got %+v", result)
+	}
+}
+
+func TestAttendanceWeights_Rate(t *testing.T) {
+	weights := DefaultAttendanceWeights()
+	rate, ok := weights.Rate(map[string]int{
+		models.AttendancePresent: 10,
+		models.AttendanceLate:    4,
+		models.AttendanceExcused: 2,
+		models.AttendanceAbsent:  4,
+	})
+	if !ok {
+		t.Fatal("expected a rate")
+	}
+	// (10 + 4*0.75 + 2) / 20
+	if want := 0.75; math.Abs(rate-want) > 1e-9 {
+		t.Errorf("rate = %v, want %v", rate, want)
+	}
+	if _, ok := weights.Rate(map[string]int{}); ok {
+		t.Error("no sessions should not produce a rate")
+	}
+}
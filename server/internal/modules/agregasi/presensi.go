@@ -0,0 +1,32 @@
+package agregasi
+
+import "tsukamoto/internal/models"
+
+// AttendanceWeights is the share of a session credited for each attendance status
+type AttendanceWeights map[string]float64
+
+// DefaultAttendanceWeights credits present and excused sessions fully, late
+// arrivals for three quarters and absences not at all
+func DefaultAttendanceWeights() AttendanceWeights {
+	return AttendanceWeights{
+		models.AttendancePresent: 1,
+		models.AttendanceLate:    0.75,
+		models.AttendanceExcused: 1,
+		models.AttendanceAbsent:  0,
+	}
+}
+
+// Rate returns the weighted share of recorded sessions attended (0-1), or
+// false when no session is recorded
+func (w AttendanceWeights) Rate(counts map[string]int) (float64, bool) {
+	var credited float64
+	var sessions int
+	for status, count := range counts {
+		credited += w[status] * float64(count)
+		sessions += count
+	}
+	if sessions == 0 {
+		return 0, false
+	}
+	return credited / float64(sessions), true
+}
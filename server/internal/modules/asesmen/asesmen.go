@@ -0,0 +1,48 @@
+package asesmen
+
+import (
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/inferensi"
+	"tsukamoto/internal/modules/normalisasi"
+	"tsukamoto/internal/modules/penjelasan"
+)
+
+// Evaluate scores an academic record with the model's flat rule base and
+// returns the unsaved assessment, including its explanation, together with the
+// inference trace. The record's values must already be validated. When no rule
+// fires it returns inferensi.ErrNoRuleActivated and no assessment, so callers
+// never store a score of 0 that the model did not produce.
+func Evaluate(model Model, academic models.Academic, method inferensi.Method) (*models.Assessment, inferensi.StageResult, error) {
+	in := normalisasi.FromAcademic(academic)
+	system := model.System
+	inputs := inferensi.DefaultInputs(in.GPA, in.CoreCourseAverage, in.AttendanceRate, in.MidtermExamScore, in.FinalExamScore)
+
+	result, err := system.InferWith(method, inputs)
+	if err != nil {
+		return nil, result, err
+	}
+	if result.TotalWeight == 0 {
+		return nil, result, inferensi.ErrNoRuleActivated
+	}
+	category := model.Categorize(result.CrispOutput)
+
+	inputsJSON, err := models.NewJSON(inputs)
+	if err != nil {
+		return nil, result, err
+	}
+	explanationJSON, err := models.NewJSON(penjelasan.Explain(system, method, result, category))
+	if err != nil {
+		return nil, result, err
+	}
+
+	return &models.Assessment{
+		UserID:      academic.UserID,
+		AcademicID:  academic.ID,
+		TermID:      academic.TermID,
+		Method:      string(method),
+		Category:    category,
+		CrispOutput: result.CrispOutput,
+		Inputs:      inputsJSON,
+		Explanation: explanationJSON,
+	}, result, nil
+}
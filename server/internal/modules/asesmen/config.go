@@ -0,0 +1,179 @@
+package asesmen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/modules/deffuzifikasi"
+	"tsukamoto/internal/modules/fuzzifikasi"
+	"tsukamoto/internal/modules/inferensi"
+)
+
+// TermConfig is a trapezoidal term. A null A and B make the term open to the
+// left and a null C and D open to the right.
+type TermConfig struct {
+	Name string   `json:"name"`
+	A    *float64 `json:"a"`
+	B    *float64 `json:"b"`
+	C    *float64 `json:"c"`
+	D    *float64 `json:"d"`
+}
+
+// VariableConfig replaces the terms of one input variable of the default model
+type VariableConfig struct {
+	Name  string       `json:"name"`
+	Terms []TermConfig `json:"terms"`
+}
+
+// RuleConfig is a rule over the input variables; conditions map a variable
+// name to one of its terms and output is a performance category
+type RuleConfig struct {
+	Conditions map[string]string `json:"conditions"`
+	Output     string            `json:"output"`
+}
+
+// Config customizes the default model for one university. Every part is
+// optional: listed variables replace their default terms, a non-empty rule
+// list replaces the whole rule base and thresholds replace the category
+// boundaries.
+type Config struct {
+	Variables  []VariableConfig          `json:"variables,omitempty"`
+	Rules      []RuleConfig              `json:"rules,omitempty"`
+	Thresholds *deffuzifikasi.Thresholds `json:"thresholds,omitempty"`
+}
+
+// Model is the rule base and category thresholds used to assess students
+type Model struct {
+	System     inferensi.System
+	Thresholds deffuzifikasi.Thresholds
+	// Custom is set when the configuration replaces default terms or rules
+	Custom bool
+}
+
+// DefaultModel is the model of universities without a configuration
+func DefaultModel() Model {
+	return Model{System: inferensi.DefaultSystem(), Thresholds: deffuzifikasi.DefaultThresholds()}
+}
+
+// Categorize maps a crisp score to its category with the model's thresholds
+func (m Model) Categorize(crispOutput float64) string {
+	return m.Thresholds.Categorize(crispOutput)
+}
+
+// Build applies the configuration to the default model and checks that the
+// result can be evaluated
+func (c Config) Build() (Model, error) {
+	model := DefaultModel()
+	model.Custom = len(c.Variables) > 0 || len(c.Rules) > 0
+
+	seen := make(map[string]bool, len(c.Variables))
+	for _, variable := range c.Variables {
+		index := -1
+		for i, input := range model.System.Inputs {
+			if input.Name == variable.Name {
+				index = i
+			}
+		}
+		if index < 0 {
+			return model, fmt.Errorf("unknown variable %q", variable.Name)
+		}
+		if seen[variable.Name] {
+			return model, fmt.Errorf("variable %q is configured twice", variable.Name)
+		}
+		seen[variable.Name] = true
+
+		terms, err := buildTerms(variable)
+		if err != nil {
+			return model, err
+		}
+		model.System.Inputs[index].Terms = terms
+	}
+
+	if len(c.Rules) > 0 {
+		model.System.Rules = make([]inferensi.SystemRule, 0, len(c.Rules))
+		for i, rule := range c.Rules {
+			if len(rule.Conditions) == 0 {
+				return model, fmt.Errorf("rule %d has no conditions", i+1)
+			}
+			for name := range rule.Conditions {
+				if !hasInput(model.System, name) {
+					return model, fmt.Errorf("rule %d refers to unknown variable %q", i+1, name)
+				}
+			}
+			model.System.Rules = append(model.System.Rules, inferensi.SystemRule{Conditions: rule.Conditions, Output: rule.Output})
+		}
+	}
+
+	if c.Thresholds != nil {
+		if err := c.Thresholds.Validate(); err != nil {
+			return model, err
+		}
+		model.Thresholds = *c.Thresholds
+	}
+
+	// Compile memeriksa term dan output yang dirujuk setiap rule
+	if _, err := inferensi.Compile(model.System, inferensi.MethodTsukamoto); err != nil {
+		return model, err
+	}
+	return model, nil
+}
+
+func buildTerms(variable VariableConfig) ([]fuzzifikasi.Term, error) {
+	if len(variable.Terms) == 0 {
+		return nil, fmt.Errorf("variable %q needs at least one term", variable.Name)
+	}
+	terms := make([]fuzzifikasi.Term, 0, len(variable.Terms))
+	names := make(map[string]bool, len(variable.Terms))
+	for _, term := range variable.Terms {
+		if term.Name == "" || names[term.Name] {
+			return nil, fmt.Errorf("terms of %q need unique names", variable.Name)
+		}
+		names[term.Name] = true
+
+		// Titik kosong berarti term terbuka ke arah tersebut
+		value := func(point *float64, open float64) float64 {
+			if point == nil {
+				return open
+			}
+			return *point
+		}
+		built := fuzzifikasi.Term{
+			Name: term.Name,
+			A:    value(term.A, math.Inf(-1)),
+			B:    value(term.B, math.Inf(-1)),
+			C:    value(term.C, math.Inf(1)),
+			D:    value(term.D, math.Inf(1)),
+		}
+		if (term.A == nil) != (term.B == nil) || (term.C == nil) != (term.D == nil) {
+			return nil, fmt.Errorf("term %q of %q must leave both a and b or both c and d empty", term.Name, variable.Name)
+		}
+		if !(built.A <= built.B && built.B <= built.C && built.C <= built.D) {
+			return nil, fmt.Errorf("points of term %q of %q must satisfy a <= b <= c <= d", term.Name, variable.Name)
+		}
+		terms = append(terms, built)
+	}
+	return terms, nil
+}
+
+func hasInput(system inferensi.System, name string) bool {
+	for _, variable := range system.Inputs {
+		if variable.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ModelFrom builds the model of a stored configuration; nil selects the
+// default model
+func ModelFrom(stored *models.FuzzyConfig) (Model, error) {
+	if stored == nil || len(stored.Config) == 0 {
+		return DefaultModel(), nil
+	}
+	var config Config
+	if err := json.Unmarshal(stored.Config, &config); err != nil {
+		return DefaultModel(), err
+	}
+	return config.Build()
+}
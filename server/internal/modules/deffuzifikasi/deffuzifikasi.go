@@ -16,20 +16,72 @@ func TsukamotoDefuzzify(gpa, cca, attendance, midterm, finalExam float64) (strin
 
 	crispOutput := result.CrispOutput
 
-	var category string
-	if crispOutput <= 40 {
-		category = "Poor"
-	} else if crispOutput <= 60 {
-		category = "Needs Improvement"
-	} else if crispOutput <= 80 {
-		category = "Satisfactory"
-	} else if crispOutput <= 95 {
-		category = "Good"
-	} else {
-		category = "Excellent"
+	return Categorize(crispOutput), crispOutput, nil
+}
+
+// Categorize maps a crisp performance score to its category label using the
+// default thresholds
+func Categorize(crispOutput float64) string {
+	return DefaultThresholds().Categorize(crispOutput)
+}
+
+// Thresholds are the highest scores of every category but the last, in the
+// order of Categories. A score above the last threshold is Excellent.
+type Thresholds [4]float64
+
+// DefaultThresholds returns the category boundaries of the original model
+func DefaultThresholds() Thresholds {
+	return Thresholds{40, 60, 80, 95}
+}
+
+// Categorize maps a crisp performance score to its category label
+func (t Thresholds) Categorize(crispOutput float64) string {
+	categories := Categories()
+	for i, limit := range t {
+		if crispOutput <= limit {
+			return categories[i]
+		}
+	}
+	return categories[len(categories)-1]
+}
+
+// Validate checks that the thresholds rise strictly inside the 0-100 output range
+func (t Thresholds) Validate() error {
+	previous := 0.0
+	for i, limit := range t {
+		if limit <= previous || limit >= 100 {
+			return fmt.Errorf("threshold %d must be above %g and below 100", i+1, previous)
+		}
+		previous = limit
+	}
+	return nil
+}
+
+// Categories lists the performance categories from worst to best
+func Categories() []string {
+	return []string{"Poor", "Needs Improvement", "Satisfactory", "Good", "Excellent"}
+}
+
+// CategoryRank returns the position of a category in Categories, or -1 when unknown
+func CategoryRank(category string) int {
+	for i, c := range Categories() {
+		if c == category {
+			return i
+		}
+	}
+	return -1
+}
+
+// HierarchicalDefuzzify evaluates the default hierarchy of fuzzy subsystems with
+// the selected inference method and returns the category together with the
+// per-stage trace
+func HierarchicalDefuzzify(method inferensi.Method, gpa, cca, attendance, midterm, finalExam float64) (string, inferensi.HierarchyResult, error) {
+	result, err := inferensi.DefaultHierarchy().InferWith(method, inferensi.DefaultInputs(gpa, cca, attendance, midterm, finalExam))
+	if err != nil {
+		return "", result, err
 	}
 
-	return category, crispOutput, nil
+	return Categorize(result.CrispOutput), result, nil
 }
 
 // Backward compatibility with old Defuzzify function
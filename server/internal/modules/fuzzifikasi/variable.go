@@ -0,0 +1,121 @@
+package fuzzifikasi
+
+import "math"
+
+// Term is a linguistic term described by a trapezoidal membership function.
+// A and D are the feet, B and C the shoulders. Open-ended (shoulder) terms use
+// math.Inf for the side that never falls back to zero.
+type Term struct {
+	Name string
+	A    float64
+	B    float64
+	C    float64
+	D    float64
+}
+
+// Membership returns the degree to which x belongs to the term.
+func (t Term) Membership(x float64) float64 {
+	switch {
+	case x < t.A || x > t.D:
+		return 0
+	case x >= t.B && x <= t.C:
+		return 1
+	case x < t.B:
+		return (x - t.A) / (t.B - t.A)
+	default:
+		return (t.D - x) / (t.D - t.C)
+	}
+}
+
+// Variable is a named linguistic variable with its terms. Min and Max bound
+// the universe of discourse.
+type Variable struct {
+	Name  string
+	Min   float64
+	Max   float64
+	Terms []Term
+}
+
+// Fuzzify returns the membership of x in every term of the variable, keyed by term name.
+func (v Variable) Fuzzify(x float64) map[string]float64 {
+	memberships := make(map[string]float64, len(v.Terms))
+	for _, term := range v.Terms {
+		memberships[term.Name] = term.Membership(x)
+	}
+	return memberships
+}
+
+// Normalize scales x from the universe of discourse to [0, 1].
+func (v Variable) Normalize(x float64) float64 {
+	if v.Max == v.Min {
+		return 0
+	}
+	return (x - v.Min) / (v.Max - v.Min)
+}
+
+// TermIndex returns the position of the named term, or -1 if it does not exist.
+func (v Variable) TermIndex(name string) int {
+	for i, term := range v.Terms {
+		if term.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+var inf = math.Inf(1)
+
+// GPAVariable describes GPA with the same breakpoints as FuzzifyGPA.
+func GPAVariable() Variable {
+	return Variable{Name: "gpa", Min: 0, Max: 4, Terms: []Term{
+		{Name: "Low", A: -inf, B: -inf, C: 1.8, D: 2.2},
+		{Name: "Medium", A: 1.8, B: 2.5, C: 2.5, D: 3.2},
+		{Name: "High", A: 2.8, B: 3.2, C: inf, D: inf},
+	}}
+}
+
+// CCAVariable describes the core course average with the same breakpoints as FuzzifyCCA.
+func CCAVariable() Variable {
+	return Variable{Name: "cca", Min: 0, Max: 100, Terms: []Term{
+		{Name: "Low", A: -inf, B: -inf, C: 50, D: 55},
+		{Name: "Medium", A: 50, B: 65, C: 65, D: 75},
+		{Name: "High", A: 70, B: 80, C: inf, D: inf},
+	}}
+}
+
+// AttendanceVariable describes the attendance rate with the same breakpoints as FuzzifyAttendance.
+func AttendanceVariable() Variable {
+	return Variable{Name: "attendance", Min: 0, Max: 1, Terms: []Term{
+		{Name: "Low", A: -inf, B: -inf, C: 0.60, D: 0.65},
+		{Name: "Medium", A: 0.60, B: 0.75, C: 0.75, D: 0.85},
+		{Name: "High", A: 0.80, B: 0.90, C: inf, D: inf},
+	}}
+}
+
+// MidtermVariable describes the midterm exam score with the same breakpoints as FuzzifyMES.
+func MidtermVariable() Variable {
+	return Variable{Name: "midterm", Min: 0, Max: 100, Terms: []Term{
+		{Name: "Low", A: -inf, B: -inf, C: 55, D: 60},
+		{Name: "Medium", A: 55, B: 65, C: 65, D: 75},
+		{Name: "High", A: 70, B: 80, C: inf, D: inf},
+	}}
+}
+
+// FinalExamVariable describes the final exam score with the same breakpoints as FuzzifyFinalExam.
+func FinalExamVariable() Variable {
+	return Variable{Name: "final_exam", Min: 0, Max: 100, Terms: []Term{
+		{Name: "Low", A: -inf, B: -inf, C: 52, D: 54},
+		{Name: "Medium", A: 52, B: 70, C: 70, D: 82},
+		{Name: "High", A: 78, B: 82, C: inf, D: inf},
+	}}
+}
+
+// ScoreVariable describes an intermediate 0-100 score produced by a fuzzy
+// subsystem so that it can be fed into the next stage of a hierarchy.
+func ScoreVariable(name string) Variable {
+	return Variable{Name: name, Min: 0, Max: 100, Terms: []Term{
+		{Name: "Low", A: -inf, B: -inf, C: 40, D: 55},
+		{Name: "Medium", A: 40, B: 60, C: 60, D: 80},
+		{Name: "High", A: 65, B: 80, C: inf, D: inf},
+	}}
+}
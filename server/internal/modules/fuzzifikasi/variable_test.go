@@ -0,0 +1,56 @@
+package fuzzifikasi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVariable_MatchesBaselineFuzzify(t *testing.T) {
+	tests := []struct {
+		variable Variable
+		baseline func(float64) (float64, float64, float64)
+	}{
+		{GPAVariable(), FuzzifyGPA},
+		{CCAVariable(), FuzzifyCCA},
+		{AttendanceVariable(), FuzzifyAttendance},
+		{MidtermVariable(), FuzzifyMES},
+		{FinalExamVariable(), FuzzifyFinalExam},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable.Name, func(t *testing.T) {
+			// Langkah kecil melewati setiap titik patah pada semesta pembicaraan
+			steps := 4000
+			for i := 0; i <= steps; i++ {
+				x := tt.variable.Min + (tt.variable.Max-tt.variable.Min)*float64(i)/float64(steps)
+				low, medium, high := tt.baseline(x)
+				memberships := tt.variable.Fuzzify(x)
+				for name, expected := range map[string]float64{"Low": low, "Medium": medium, "High": high} {
+					if math.Abs(memberships[name]-expected) > 1e-9 {
+						t.Fatalf("x=%v: expected %s membership %v, got %v", x, name, expected, memberships[name])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTerm_Membership(t *testing.T) {
+	term := Term{Name: "Medium", A: 10, B: 20, C: 30, D: 50}
+	tests := []struct {
+		x, expected float64
+	}{
+		{5, 0}, {10, 0}, {15, 0.5}, {20, 1}, {25, 1}, {30, 1}, {40, 0.5}, {50, 0}, {60, 0},
+	}
+	for _, tt := range tests {
+		if got := term.Membership(tt.x); math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("x=%v: expected %v, got %v", tt.x, tt.expected, got)
+		}
+	}
+
+	// Term bahu terbuka tidak pernah turun ke nol pada sisi tak hingga
+	open := Term{Name: "High", A: 70, B: 80, C: math.Inf(1), D: math.Inf(1)}
+	if got := open.Membership(1e6); got != 1 {
+		t.Errorf("expected open shoulder to stay at 1, got %v", got)
+	}
+}
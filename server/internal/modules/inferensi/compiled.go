@@ -0,0 +1,259 @@
+package inferensi
+
+import (
+	"fmt"
+	"math"
+	"tsukamoto/internal/modules/fuzzifikasi"
+)
+
+// CompiledSystem is a System prepared for repeated evaluation: term names are
+// resolved to indices once, rules become index arrays and the consequents are
+// flattened for the selected method. A CompiledSystem is immutable and safe
+// to share; per-call scratch space lives in an Evaluator.
+type CompiledSystem struct {
+	method Method
+
+	inputNames []string
+	inputMin   []float64
+	inputSpan  []float64
+
+	// terms holds the terms of every input back to back; termOffset[i] is the
+	// index of the first term of input i
+	terms      []fuzzifikasi.Term
+	termOffset []int
+
+	// ruleTerms holds, for rule r and input i, the index into terms at
+	// r*len(inputNames)+i, or -1 when the rule has no condition on that input
+	ruleTerms  []int
+	ruleOutput []int
+
+	outputValues   []float64
+	outputSets     []fuzzifikasi.Term
+	sugenoConstant []float64
+	// sugenoCoefficients holds, for output o and input i, the coefficient at o*len(inputNames)+i
+	sugenoCoefficients []float64
+
+	outputMin float64
+	outputMax float64
+}
+
+// Compile resolves the system's rules against its variables for the given method.
+func Compile(s System, method Method) (*CompiledSystem, error) {
+	if _, err := ParseMethod(string(method)); err != nil {
+		return nil, err
+	}
+
+	numInputs := len(s.Inputs)
+	c := &CompiledSystem{
+		method:     method,
+		inputNames: make([]string, numInputs),
+		inputMin:   make([]float64, numInputs),
+		inputSpan:  make([]float64, numInputs),
+		termOffset: make([]int, numInputs),
+		outputMin:  s.OutputMin,
+		outputMax:  s.OutputMax,
+	}
+
+	for i, variable := range s.Inputs {
+		c.inputNames[i] = variable.Name
+		c.inputMin[i] = variable.Min
+		c.inputSpan[i] = variable.Max - variable.Min
+		c.termOffset[i] = len(c.terms)
+		c.terms = append(c.terms, variable.Terms...)
+	}
+
+	outputIndex := make(map[string]int, len(s.Outputs))
+	c.outputValues = make([]float64, len(s.Outputs))
+	c.outputSets = make([]fuzzifikasi.Term, len(s.Outputs))
+	c.sugenoConstant = make([]float64, len(s.Outputs))
+	c.sugenoCoefficients = make([]float64, len(s.Outputs)*numInputs)
+	for o, output := range s.Outputs {
+		outputIndex[output.Name] = o
+		c.outputValues[o] = output.Value
+		c.outputSets[o] = output.Set
+		c.sugenoConstant[o] = output.Sugeno.Constant
+		for i, variable := range s.Inputs {
+			c.sugenoCoefficients[o*numInputs+i] = output.Sugeno.Coefficients[variable.Name]
+		}
+	}
+
+	c.ruleTerms = make([]int, len(s.Rules)*numInputs)
+	c.ruleOutput = make([]int, len(s.Rules))
+	for r, rule := range s.Rules {
+		for i, variable := range s.Inputs {
+			termName, ok := rule.Conditions[variable.Name]
+			if !ok {
+				c.ruleTerms[r*numInputs+i] = -1
+				continue
+			}
+			t := variable.TermIndex(termName)
+			if t < 0 {
+				return nil, fmt.Errorf("stage %s: rule %d refers to unknown term %q of %s", s.Name, r, termName, variable.Name)
+			}
+			c.ruleTerms[r*numInputs+i] = c.termOffset[i] + t
+		}
+
+		o, ok := outputIndex[rule.Output]
+		if !ok {
+			return nil, fmt.Errorf("stage %s: rule %d has unknown output %q", s.Name, r, rule.Output)
+		}
+		c.ruleOutput[r] = o
+	}
+
+	return c, nil
+}
+
+// MustCompile is like Compile but panics on an invalid system. It is meant
+// for the built-in systems, which are known to be valid.
+func MustCompile(s System, method Method) *CompiledSystem {
+	c, err := Compile(s, method)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Method returns the inference method the system was compiled for.
+func (c *CompiledSystem) Method() Method {
+	return c.method
+}
+
+// InputNames returns the input variable names in the order Evaluate expects them.
+func (c *CompiledSystem) InputNames() []string {
+	return c.inputNames
+}
+
+// NumRules returns the number of rules in the system.
+func (c *CompiledSystem) NumRules() int {
+	return len(c.ruleOutput)
+}
+
+// NewEvaluator allocates the scratch buffers needed to evaluate the system.
+func (c *CompiledSystem) NewEvaluator() *Evaluator {
+	return &Evaluator{
+		system:       c,
+		memberships:  make([]float64, len(c.terms)),
+		strengths:    make([]float64, len(c.ruleOutput)),
+		sugenoValues: make([]float64, len(c.outputValues)),
+	}
+}
+
+// Evaluator evaluates a CompiledSystem without heap allocations. It reuses its
+// buffers between calls and must not be shared between goroutines.
+type Evaluator struct {
+	system       *CompiledSystem
+	memberships  []float64
+	strengths    []float64
+	sugenoValues []float64
+}
+
+// Evaluate computes the crisp output for inputs given in InputNames order.
+// It returns the crisp output and the total firing strength; a total of zero
+// means that no rule fired and the crisp output is meaningless. It fails when
+// inputs does not hold exactly one value per input variable.
+func (e *Evaluator) Evaluate(inputs []float64) (crispOutput, totalWeight float64, err error) {
+	c := e.system
+	numInputs := len(c.inputNames)
+	if len(inputs) != numInputs {
+		return 0, 0, fmt.Errorf("expected %d inputs, got %d", numInputs, len(inputs))
+	}
+
+	for i := 0; i < numInputs; i++ {
+		end := len(c.terms)
+		if i+1 < numInputs {
+			end = c.termOffset[i+1]
+		}
+		for t := c.termOffset[i]; t < end; t++ {
+			e.memberships[t] = c.terms[t].Membership(inputs[i])
+		}
+	}
+
+	if c.method == MethodSugeno {
+		for o := range e.sugenoValues {
+			value := c.sugenoConstant[o]
+			for i := 0; i < numInputs; i++ {
+				var normalized float64
+				if c.inputSpan[i] != 0 {
+					normalized = (inputs[i] - c.inputMin[i]) / c.inputSpan[i]
+				}
+				value += c.sugenoCoefficients[o*numInputs+i] * normalized
+			}
+			e.sugenoValues[o] = value
+		}
+	}
+
+	var weightedSum float64
+	for r, output := range c.ruleOutput {
+		// Firing strength is the minimum (AND) over the rule's conditions
+		firingStrength := 1.0
+		for _, t := range c.ruleTerms[r*numInputs : (r+1)*numInputs] {
+			if t >= 0 {
+				firingStrength = math.Min(firingStrength, e.memberships[t])
+			}
+		}
+
+		if firingStrength <= 0 {
+			e.strengths[r] = 0
+			continue
+		}
+		e.strengths[r] = firingStrength
+		totalWeight += firingStrength
+
+		switch c.method {
+		case MethodTsukamoto:
+			weightedSum += firingStrength * c.outputValues[output]
+		case MethodSugeno:
+			weightedSum += firingStrength * e.sugenoValues[output]
+		}
+	}
+
+	if totalWeight == 0 {
+		return 0, 0, nil
+	}
+
+	switch c.method {
+	case MethodMamdani, MethodMamdaniProduct:
+		return e.centroid(), totalWeight, nil
+	default:
+		return weightedSum / totalWeight, totalWeight, nil
+	}
+}
+
+// Strengths returns the firing strength of every rule from the last call to
+// Evaluate. The slice is owned by the Evaluator and overwritten on each call.
+func (e *Evaluator) Strengths() []float64 {
+	return e.strengths
+}
+
+// centroid mirrors System.centroid over the strengths of the last evaluation
+func (e *Evaluator) centroid() float64 {
+	c := e.system
+	step := (c.outputMax - c.outputMin) / float64(mamdaniSamples-1)
+
+	var moment, area float64
+	for i := 0; i < mamdaniSamples; i++ {
+		z := c.outputMin + float64(i)*step
+
+		var mu float64
+		for r, strength := range e.strengths {
+			if strength <= 0 {
+				continue
+			}
+			degree := c.outputSets[c.ruleOutput[r]].Membership(z)
+			if c.method == MethodMamdaniProduct {
+				degree *= strength
+			} else {
+				degree = math.Min(degree, strength)
+			}
+			mu = math.Max(mu, degree)
+		}
+
+		moment += z * mu
+		area += mu
+	}
+
+	if area == 0 {
+		return 0
+	}
+	return moment / area
+}
@@ -0,0 +1,220 @@
+package inferensi
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const equivalenceTolerance = 1e-9
+
+// sampleInputs returns a deterministic spread of inputs covering every term
+// boundary plus random points inside the universes of discourse
+func sampleInputs() [][5]float64 {
+	var samples [][5]float64
+	for _, gpa := range []float64{0, 1.8, 2.0, 2.5, 2.9, 3.2, 4} {
+		for _, cca := range []float64{40, 52, 65, 72, 85} {
+			for _, attendance := range []float64{0.5, 0.62, 0.75, 0.82, 0.95} {
+				for _, midterm := range []float64{50, 57, 65, 72, 90} {
+					for _, finalExam := range []float64{45, 53, 70, 80, 90} {
+						samples = append(samples, [5]float64{gpa, cca, attendance, midterm, finalExam})
+					}
+				}
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 2000; i++ {
+		samples = append(samples, [5]float64{
+			rng.Float64() * 4,
+			rng.Float64() * 100,
+			rng.Float64(),
+			rng.Float64() * 100,
+			rng.Float64() * 100,
+		})
+	}
+	return samples
+}
+
+func TestCompiledSystem_MatchesTsukamotoInference(t *testing.T) {
+	evaluator := MustCompile(DefaultSystem(), MethodTsukamoto).NewEvaluator()
+
+	for _, in := range sampleInputs() {
+		expected := TsukamotoInference(in[0], in[1], in[2], in[3], in[4])
+		crisp, total, err := evaluator.Evaluate(in[:])
+		if err != nil {
+			t.Fatalf("inputs %v: unexpected error: %v", in, err)
+		}
+
+		if math.Abs(crisp-expected.CrispOutput) > equivalenceTolerance {
+			t.Fatalf("inputs %v: expected crisp %v, got %v", in, expected.CrispOutput, crisp)
+		}
+		if math.Abs(total-expected.TotalWeight) > equivalenceTolerance {
+			t.Fatalf("inputs %v: expected total weight %v, got %v", in, expected.TotalWeight, total)
+		}
+
+		fired := 0
+		for _, strength := range evaluator.Strengths() {
+			if strength > 0 {
+				fired++
+			}
+		}
+		if fired != len(expected.RuleOutputs) {
+			t.Fatalf("inputs %v: expected %d fired rules, got %d", in, len(expected.RuleOutputs), fired)
+		}
+	}
+}
+
+func TestCompiledSystem_MatchesSystemForEveryMethod(t *testing.T) {
+	system := DefaultSystem()
+
+	for _, method := range Methods() {
+		evaluator := MustCompile(system, method).NewEvaluator()
+
+		for i, in := range sampleInputs() {
+			// Mamdani is comparatively slow, a subset is enough to prove equivalence
+			if (method == MethodMamdani || method == MethodMamdaniProduct) && i%10 != 0 {
+				continue
+			}
+
+			expected, err := system.InferWith(method, DefaultInputs(in[0], in[1], in[2], in[3], in[4]))
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", method, err)
+			}
+			crisp, total, err := evaluator.Evaluate(in[:])
+			if err != nil {
+				t.Fatalf("inputs %v: unexpected error: %v", in, err)
+			}
+
+			if math.Abs(crisp-expected.CrispOutput) > equivalenceTolerance {
+				t.Fatalf("%s inputs %v: expected crisp %v, got %v", method, in, expected.CrispOutput, crisp)
+			}
+			if math.Abs(total-expected.TotalWeight) > equivalenceTolerance {
+				t.Fatalf("%s inputs %v: expected total weight %v, got %v", method, in, expected.TotalWeight, total)
+			}
+		}
+	}
+}
+
+func TestCompiledSystem_HandComputedOutputs(t *testing.T) {
+	// Sama dengan TestSystem_InferWithHandComputedOutputs untuk x = 0.25
+	expected := map[Method]float64{
+		MethodTsukamoto:      25,
+		MethodMamdani:        25,
+		MethodMamdaniProduct: 25,
+		MethodSugeno:         28.75,
+	}
+
+	for method, want := range expected {
+		crisp, total, err := MustCompile(twoRuleSystem(), method).NewEvaluator().Evaluate([]float64{0.25})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", method, err)
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("%s: expected total weight 1, got %v", method, total)
+		}
+		if math.Abs(crisp-want) > 1e-6 {
+			t.Errorf("%s: expected crisp output %v, got %v", method, want, crisp)
+		}
+	}
+}
+
+func TestCompiledSystem_InputOrder(t *testing.T) {
+	compiled := MustCompile(DefaultSystem(), MethodTsukamoto)
+
+	expected := []string{"gpa", "cca", "attendance", "midterm", "final_exam"}
+	names := compiled.InputNames()
+	if len(names) != len(expected) {
+		t.Fatalf("expected %d inputs, got %d", len(expected), len(names))
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("input %d: expected %s, got %s", i, expected[i], names[i])
+		}
+	}
+	if compiled.NumRules() != len(Rules()) {
+		t.Errorf("expected %d rules, got %d", len(Rules()), compiled.NumRules())
+	}
+}
+
+func TestEvaluator_RejectsWrongInputCount(t *testing.T) {
+	evaluator := MustCompile(DefaultSystem(), MethodTsukamoto).NewEvaluator()
+	for _, inputs := range [][]float64{nil, {3.2, 75}, {3.2, 75, 0.9, 80, 78, 85}} {
+		if _, _, err := evaluator.Evaluate(inputs); err == nil {
+			t.Errorf("expected error for %d inputs", len(inputs))
+		}
+	}
+}
+
+func TestCompile_InvalidSystem(t *testing.T) {
+	system := DefaultSystem()
+	system.Rules = append(system.Rules, SystemRule{Conditions: map[string]string{"gpa": "Very High"}, Output: "Good"})
+	if _, err := Compile(system, MethodTsukamoto); err == nil {
+		t.Errorf("expected error for unknown term")
+	}
+
+	system = DefaultSystem()
+	system.Rules = append(system.Rules, SystemRule{Conditions: map[string]string{"gpa": "High"}, Output: "Outstanding"})
+	if _, err := Compile(system, MethodTsukamoto); err == nil {
+		t.Errorf("expected error for unknown output")
+	}
+
+	if _, err := Compile(DefaultSystem(), Method("fuzzy-logic")); err == nil {
+		t.Errorf("expected error for unknown method")
+	}
+}
+
+func TestEvaluator_DoesNotAllocate(t *testing.T) {
+	inputs := []float64{3.0, 72, 0.82, 72, 80}
+
+	for _, method := range Methods() {
+		evaluator := MustCompile(DefaultSystem(), method).NewEvaluator()
+		allocs := testing.AllocsPerRun(100, func() {
+			evaluator.Evaluate(inputs)
+		})
+		if allocs != 0 {
+			t.Errorf("%s: expected 0 allocations per evaluation, got %v", method, allocs)
+		}
+	}
+}
+
+func BenchmarkTsukamotoInference(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		TsukamotoInference(3.0, 72, 0.82, 72, 80)
+	}
+}
+
+func BenchmarkSystemInfer(b *testing.B) {
+	system := DefaultSystem()
+	inputs := DefaultInputs(3.0, 72, 0.82, 72, 80)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		system.Infer(inputs)
+	}
+}
+
+func BenchmarkCompiledEvaluate(b *testing.B) {
+	evaluator := MustCompile(DefaultSystem(), MethodTsukamoto).NewEvaluator()
+	inputs := []float64{3.0, 72, 0.82, 72, 80}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		evaluator.Evaluate(inputs)
+	}
+}
+
+func BenchmarkCompiledEvaluateSugeno(b *testing.B) {
+	evaluator := MustCompile(DefaultSystem(), MethodSugeno).NewEvaluator()
+	inputs := []float64{3.0, 72, 0.82, 72, 80}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		evaluator.Evaluate(inputs)
+	}
+}
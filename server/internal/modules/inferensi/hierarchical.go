@@ -0,0 +1,178 @@
+package inferensi
+
+import (
+	"errors"
+	"fmt"
+	"tsukamoto/internal/modules/fuzzifikasi"
+)
+
+// Hierarchy chains several Systems. Stages are evaluated in order and the
+// crisp output of each stage becomes an input, named after the stage, for
+// every later stage. The last stage produces the final result.
+type Hierarchy struct {
+	Stages []System
+}
+
+// HierarchyResult holds the trace of every stage and the final crisp output.
+type HierarchyResult struct {
+	Stages      []StageResult `json:"stages"`
+	CrispOutput float64       `json:"crisp_output"`
+}
+
+// Infer evaluates all stages with the Tsukamoto method.
+func (h Hierarchy) Infer(inputs map[string]float64) (HierarchyResult, error) {
+	return h.InferWith(MethodTsukamoto, inputs)
+}
+
+// InferWith evaluates all stages with the selected method and returns the per-stage trace.
+func (h Hierarchy) InferWith(method Method, inputs map[string]float64) (HierarchyResult, error) {
+	var result HierarchyResult
+	if len(h.Stages) == 0 {
+		return result, errors.New("hierarchy has no stages")
+	}
+
+	values := make(map[string]float64, len(inputs)+len(h.Stages))
+	for name, value := range inputs {
+		values[name] = value
+	}
+
+	for _, stage := range h.Stages {
+		stageResult, err := stage.InferWith(method, values)
+		if err != nil {
+			return result, err
+		}
+		if stageResult.TotalWeight == 0 {
+			return result, fmt.Errorf("stage %s: %w", stage.Name, ErrNoRuleActivated)
+		}
+
+		result.Stages = append(result.Stages, stageResult)
+		values[stage.Name] = stageResult.CrispOutput
+	}
+
+	result.CrispOutput = result.Stages[len(result.Stages)-1].CrispOutput
+	return result, nil
+}
+
+// levelOutputs returns the intermediate output terms of a DefaultHierarchy
+// subsystem; the Sugeno slope is spread evenly over the subsystem's inputs
+func levelOutputs(inputs ...string) []OutputTerm {
+	coefficients := make(map[string]float64, len(inputs))
+	for _, input := range inputs {
+		coefficients[input] = 20.0 / float64(len(inputs))
+	}
+
+	return []OutputTerm{
+		{
+			Name:   "Low",
+			Value:  30.0,
+			Set:    fuzzifikasi.Term{Name: "Low", A: 0, B: 0, C: 30, D: 50},
+			Sugeno: SugenoConsequent{Constant: 20, Coefficients: coefficients},
+		},
+		{
+			Name:   "Medium",
+			Value:  60.0,
+			Set:    fuzzifikasi.Term{Name: "Medium", A: 40, B: 55, C: 65, D: 80},
+			Sugeno: SugenoConsequent{Constant: 50, Coefficients: coefficients},
+		},
+		{
+			Name:   "High",
+			Value:  90.0,
+			Set:    fuzzifikasi.Term{Name: "High", A: 70, B: 85, C: 100, D: 100},
+			Sugeno: SugenoConsequent{Constant: 80, Coefficients: coefficients},
+		},
+	}
+}
+
+// DefaultHierarchy splits the five inputs into an exam performance subsystem
+// (midterm, final exam) and an engagement subsystem (attendance, CCA) that
+// feed a top-level system together with GPA. This needs 9+9+27 rules instead
+// of the 3^5 combinations a complete flat rule base would require.
+func DefaultHierarchy() Hierarchy {
+	exam := System{
+		Name:      "exam_performance",
+		Inputs:    []fuzzifikasi.Variable{fuzzifikasi.MidtermVariable(), fuzzifikasi.FinalExamVariable()},
+		Outputs:   levelOutputs("midterm", "final_exam"),
+		OutputMin: 0,
+		OutputMax: 100,
+		Rules: []SystemRule{
+			{Conditions: map[string]string{"midterm": "Low", "final_exam": "Low"}, Output: "Low"},
+			{Conditions: map[string]string{"midterm": "Low", "final_exam": "Medium"}, Output: "Low"},
+			{Conditions: map[string]string{"midterm": "Low", "final_exam": "High"}, Output: "Medium"},
+			{Conditions: map[string]string{"midterm": "Medium", "final_exam": "Low"}, Output: "Low"},
+			{Conditions: map[string]string{"midterm": "Medium", "final_exam": "Medium"}, Output: "Medium"},
+			{Conditions: map[string]string{"midterm": "Medium", "final_exam": "High"}, Output: "High"},
+			{Conditions: map[string]string{"midterm": "High", "final_exam": "Low"}, Output: "Medium"},
+			{Conditions: map[string]string{"midterm": "High", "final_exam": "Medium"}, Output: "High"},
+			{Conditions: map[string]string{"midterm": "High", "final_exam": "High"}, Output: "High"},
+		},
+	}
+
+	engagement := System{
+		Name:      "engagement",
+		Inputs:    []fuzzifikasi.Variable{fuzzifikasi.AttendanceVariable(), fuzzifikasi.CCAVariable()},
+		Outputs:   levelOutputs("attendance", "cca"),
+		OutputMin: 0,
+		OutputMax: 100,
+		Rules: []SystemRule{
+			{Conditions: map[string]string{"attendance": "Low", "cca": "Low"}, Output: "Low"},
+			{Conditions: map[string]string{"attendance": "Low", "cca": "Medium"}, Output: "Low"},
+			{Conditions: map[string]string{"attendance": "Low", "cca": "High"}, Output: "Medium"},
+			{Conditions: map[string]string{"attendance": "Medium", "cca": "Low"}, Output: "Low"},
+			{Conditions: map[string]string{"attendance": "Medium", "cca": "Medium"}, Output: "Medium"},
+			{Conditions: map[string]string{"attendance": "Medium", "cca": "High"}, Output: "High"},
+			{Conditions: map[string]string{"attendance": "High", "cca": "Low"}, Output: "Medium"},
+			{Conditions: map[string]string{"attendance": "High", "cca": "Medium"}, Output: "High"},
+			{Conditions: map[string]string{"attendance": "High", "cca": "High"}, Output: "High"},
+		},
+	}
+
+	performance := System{
+		Name: "performance",
+		Inputs: []fuzzifikasi.Variable{
+			fuzzifikasi.GPAVariable(),
+			fuzzifikasi.ScoreVariable("exam_performance"),
+			fuzzifikasi.ScoreVariable("engagement"),
+		},
+		// Bobot Sugeno subsistem adalah jumlah bobot input asalnya pada sistem datar
+		Outputs: performanceOutputs(map[string]float64{
+			"gpa":              0.30,
+			"exam_performance": 0.35,
+			"engagement":       0.35,
+		}),
+		OutputMin: 0,
+		OutputMax: 100,
+		Rules: []SystemRule{
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "Low", "engagement": "Low"}, Output: "Poor"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "Low", "engagement": "Medium"}, Output: "Poor"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "Low", "engagement": "High"}, Output: "Needs Improvement"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "Medium", "engagement": "Low"}, Output: "Poor"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "Medium", "engagement": "Medium"}, Output: "Needs Improvement"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "Medium", "engagement": "High"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "High", "engagement": "Low"}, Output: "Needs Improvement"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "High", "engagement": "Medium"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "Low", "exam_performance": "High", "engagement": "High"}, Output: "Satisfactory"},
+
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "Low", "engagement": "Low"}, Output: "Poor"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "Low", "engagement": "Medium"}, Output: "Needs Improvement"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "Low", "engagement": "High"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "Medium", "engagement": "Low"}, Output: "Needs Improvement"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "Medium", "engagement": "Medium"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "Medium", "engagement": "High"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "High", "engagement": "Low"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "High", "engagement": "Medium"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "Medium", "exam_performance": "High", "engagement": "High"}, Output: "Good"},
+
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "Low", "engagement": "Low"}, Output: "Needs Improvement"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "Low", "engagement": "Medium"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "Low", "engagement": "High"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "Medium", "engagement": "Low"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "Medium", "engagement": "Medium"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "Medium", "engagement": "High"}, Output: "Good"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "High", "engagement": "Low"}, Output: "Satisfactory"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "High", "engagement": "Medium"}, Output: "Good"},
+			{Conditions: map[string]string{"gpa": "High", "exam_performance": "High", "engagement": "High"}, Output: "Excellent"},
+		},
+	}
+
+	return Hierarchy{Stages: []System{exam, engagement, performance}}
+}
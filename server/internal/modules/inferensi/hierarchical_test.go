@@ -0,0 +1,54 @@
+package inferensi
+
+import (
+	"testing"
+)
+
+func TestHierarchy_InferFeedsEachStage(t *testing.T) {
+	inputs := DefaultInputs(3.0, 72, 0.82, 68, 75)
+	result, err := DefaultHierarchy().Infer(inputs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Stages) != 3 {
+		t.Fatalf("expected 3 stages, got %d", len(result.Stages))
+	}
+
+	names := []string{"exam_performance", "engagement", "performance"}
+	for i, stage := range result.Stages {
+		if stage.Stage != names[i] {
+			t.Errorf("expected stage %d to be %s, got %s", i, names[i], stage.Stage)
+		}
+		if stage.TotalWeight <= 0 {
+			t.Errorf("stage %s: expected at least one rule to fire", stage.Stage)
+		}
+	}
+
+	// Keluaran subsistem menjadi input tahap terakhir
+	final := result.Stages[2]
+	if final.Inputs["exam_performance"] != result.Stages[0].CrispOutput {
+		t.Errorf("expected exam_performance input %v, got %v", result.Stages[0].CrispOutput, final.Inputs["exam_performance"])
+	}
+	if final.Inputs["engagement"] != result.Stages[1].CrispOutput {
+		t.Errorf("expected engagement input %v, got %v", result.Stages[1].CrispOutput, final.Inputs["engagement"])
+	}
+	if final.Inputs["gpa"] != inputs["gpa"] {
+		t.Errorf("expected gpa input %v, got %v", inputs["gpa"], final.Inputs["gpa"])
+	}
+	if result.CrispOutput != final.CrispOutput {
+		t.Errorf("expected final crisp output %v, got %v", final.CrispOutput, result.CrispOutput)
+	}
+}
+
+func TestHierarchy_StopsWhenNoRuleFires(t *testing.T) {
+	stage := twoRuleSystem()
+	stage.Rules = stage.Rules[:1]
+
+	// x = 1 hanya anggota High, sedangkan aturan High sudah dihapus
+	if _, err := (Hierarchy{Stages: []System{stage}}).Infer(map[string]float64{"x": 1}); err == nil {
+		t.Errorf("expected error when no rule fires")
+	}
+	if _, err := (Hierarchy{}).Infer(map[string]float64{"x": 1}); err == nil {
+		t.Errorf("expected error for an empty hierarchy")
+	}
+}
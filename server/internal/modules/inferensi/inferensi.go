@@ -15,11 +15,11 @@ type TsukamotoResult struct {
 
 // RuleOutput represents individual rule calculation
 type RuleOutput struct {
-	RuleIndex      int
-	FiringStrength float64
-	CrispValue     float64
-	WeightedValue  float64
-	Performance    string
+	RuleIndex      int     `json:"rule_index"`
+	FiringStrength float64 `json:"firing_strength"`
+	CrispValue     float64 `json:"crisp_value"`
+	WeightedValue  float64 `json:"weighted_value"`
+	Performance    string  `json:"performance"`
 }
 
 // Performance value mapping for Tsukamoto (crisp values)
@@ -142,6 +142,11 @@ func TsukamotoInference(gpa, cca, attendance, midterm, finalExam float64) Tsukam
 func Inference(gpa, cca, attendance, midterm, finalExam float64) map[string]float64 {
 	result := TsukamotoInference(gpa, cca, attendance, midterm, finalExam)
 
+	return CategoricalOutput(result.CrispOutput)
+}
+
+// CategoricalOutput converts a crisp score into the legacy one-hot category map
+func CategoricalOutput(crispValue float64) map[string]float64 {
 	// Convert crisp output back to categorical representation for compatibility
 	output := map[string]float64{
 		"Poor":              0.0,
@@ -152,8 +157,6 @@ func Inference(gpa, cca, attendance, midterm, finalExam float64) map[string]floa
 	}
 
 	// Determine category based on crisp output value
-	crispValue := result.CrispOutput
-
 	if crispValue <= 40 {
 		output["Poor"] = 1.0
 	} else if crispValue <= 60 {
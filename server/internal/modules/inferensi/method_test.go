@@ -0,0 +1,128 @@
+package inferensi
+
+import (
+	"math"
+	"testing"
+	"tsukamoto/internal/modules/fuzzifikasi"
+)
+
+func TestParseMethod(t *testing.T) {
+	if method, err := ParseMethod(""); err != nil || method != MethodTsukamoto {
+		t.Errorf("expected an empty value to select tsukamoto, got %q, %v", method, err)
+	}
+	for _, method := range Methods() {
+		if parsed, err := ParseMethod(string(method)); err != nil || parsed != method {
+			t.Errorf("expected %s, got %q, %v", method, parsed, err)
+		}
+	}
+	if _, err := ParseMethod("fuzzy-logic"); err == nil {
+		t.Errorf("expected error for an unknown method")
+	}
+}
+
+func TestSystem_InferWithHandComputedOutputs(t *testing.T) {
+	// x = 0.25 memberi Low 0.75 dan High 0.25
+	tests := []struct {
+		method   Method
+		expected float64
+	}{
+		// (0.75*10 + 0.25*70) / 1
+		{MethodTsukamoto, 25},
+		// Persegi [0,20] setinggi 0.75 dan [60,80] setinggi 0.25:
+		// (0.75*20*10 + 0.25*20*70) / (0.75*20 + 0.25*20)
+		{MethodMamdani, 25},
+		{MethodMamdaniProduct, 25},
+		// Low: 10 + 40*0.25 = 20, High: 50 + 20*0.25 = 55; (0.75*20 + 0.25*55) / 1
+		{MethodSugeno, 28.75},
+	}
+
+	system := twoRuleSystem()
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			result, err := system.InferWith(tt.method, map[string]float64{"x": 0.25})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(result.TotalWeight-1) > 1e-9 {
+				t.Errorf("expected total weight 1, got %v", result.TotalWeight)
+			}
+			if math.Abs(result.CrispOutput-tt.expected) > 1e-6 {
+				t.Errorf("expected crisp output %v, got %v", tt.expected, result.CrispOutput)
+			}
+		})
+	}
+}
+
+func TestSystem_MamdaniCentroidOfTriangle(t *testing.T) {
+	system := twoRuleSystem()
+	system.Outputs[1].Set = fuzzifikasi.Term{Name: "High", A: 40, B: 50, C: 50, D: 60}
+
+	// Hanya High yang aktif; segitiga simetris terpotong tetap berpusat di puncaknya
+	for _, method := range []Method{MethodMamdani, MethodMamdaniProduct} {
+		result, err := system.InferWith(method, map[string]float64{"x": 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(result.CrispOutput-50) > 1e-6 {
+			t.Errorf("%s: expected centroid 50, got %v", method, result.CrispOutput)
+		}
+	}
+}
+
+func TestHierarchy_InferWithEveryMethod(t *testing.T) {
+	hierarchy := DefaultHierarchy()
+	inputs := DefaultInputs(3.0, 72, 0.82, 68, 75)
+
+	for _, method := range Methods() {
+		t.Run(string(method), func(t *testing.T) {
+			result, err := hierarchy.InferWith(method, inputs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Stages) != 3 {
+				t.Fatalf("expected 3 stages, got %d", len(result.Stages))
+			}
+			for _, stage := range result.Stages {
+				if stage.Method != method {
+					t.Errorf("stage %s: expected method %s, got %s", stage.Stage, method, stage.Method)
+				}
+			}
+
+			// Keluaran subsistem menjadi input tahap terakhir
+			final := result.Stages[2]
+			if final.Inputs["exam_performance"] != result.Stages[0].CrispOutput {
+				t.Errorf("expected exam_performance input %v, got %v", result.Stages[0].CrispOutput, final.Inputs["exam_performance"])
+			}
+			if final.Inputs["engagement"] != result.Stages[1].CrispOutput {
+				t.Errorf("expected engagement input %v, got %v", result.Stages[1].CrispOutput, final.Inputs["engagement"])
+			}
+			if result.CrispOutput != final.CrispOutput {
+				t.Errorf("expected final crisp output %v, got %v", final.CrispOutput, result.CrispOutput)
+			}
+		})
+	}
+}
+
+func TestDefaultHierarchy_SugenoUsesStageInputs(t *testing.T) {
+	stages := DefaultHierarchy().Stages
+	for _, stage := range stages {
+		for _, output := range stage.Outputs {
+			var total float64
+			for _, variable := range stage.Inputs {
+				coefficient := output.Sugeno.Coefficients[variable.Name]
+				if coefficient <= 0 {
+					t.Errorf("stage %s, output %s: expected a coefficient for %s", stage.Name, output.Name, variable.Name)
+				}
+				total += coefficient
+			}
+			// Koefisien yang tidak dipakai berarti ada input tanpa pengaruh
+			var all float64
+			for _, coefficient := range output.Sugeno.Coefficients {
+				all += coefficient
+			}
+			if math.Abs(total-all) > 1e-9 {
+				t.Errorf("stage %s, output %s: coefficients for unknown inputs %v", stage.Name, output.Name, output.Sugeno.Coefficients)
+			}
+		}
+	}
+}
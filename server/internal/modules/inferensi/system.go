@@ -0,0 +1,336 @@
+package inferensi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"tsukamoto/internal/modules/fuzzifikasi"
+)
+
+// Method selects how fired rules are combined into a crisp output.
+type Method string
+
+const (
+	// MethodTsukamoto takes the firing-strength weighted average of each consequent's crisp value.
+	MethodTsukamoto Method = "tsukamoto"
+	// MethodMamdani clips the consequent sets (min implication), aggregates them with max and takes the centroid.
+	MethodMamdani Method = "mamdani"
+	// MethodMamdaniProduct scales the consequent sets (product implication) before aggregation and centroid.
+	MethodMamdaniProduct Method = "mamdani_product"
+	// MethodSugeno evaluates first-order (linear) consequents and takes their weighted average.
+	MethodSugeno Method = "sugeno"
+)
+
+// ErrNoRuleActivated is returned when the inputs fire none of a system's rules.
+// The flat rule base does not cover every term combination, so callers must
+// not read a crisp output of 0 as a score.
+var ErrNoRuleActivated = errors.New("no rule activated: all firing strengths are zero")
+
+// mamdaniSamples is the number of points used to approximate the centroid integral
+const mamdaniSamples = 1001
+
+// Methods lists every supported inference method.
+func Methods() []Method {
+	return []Method{MethodTsukamoto, MethodMamdani, MethodMamdaniProduct, MethodSugeno}
+}
+
+// ParseMethod converts a request value into a Method. An empty value selects Tsukamoto.
+func ParseMethod(value string) (Method, error) {
+	if value == "" {
+		return MethodTsukamoto, nil
+	}
+	for _, method := range Methods() {
+		if string(method) == value {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("unknown inference method %q", value)
+}
+
+// OutputTerm is a consequent term. Each inference method reads its own part
+// of the definition: Value for Tsukamoto, Set for Mamdani and Sugeno for the
+// first-order Sugeno engine.
+type OutputTerm struct {
+	Name   string
+	Value  float64
+	Set    fuzzifikasi.Term
+	Sugeno SugenoConsequent
+}
+
+// SugenoConsequent is the linear function z = Constant + sum(Coefficients[v] * x_v),
+// where x_v is input v normalized to [0, 1] over its universe of discourse.
+type SugenoConsequent struct {
+	Constant     float64
+	Coefficients map[string]float64
+}
+
+// SystemRule is a rule over named input variables. Conditions maps a variable
+// name to the term it must match; variables without a condition are ignored.
+type SystemRule struct {
+	Conditions map[string]string
+	Output     string
+}
+
+// System is a single rule base over arbitrary input variables. Name doubles
+// as the variable name of its crisp output when the system is used as a stage
+// of a Hierarchy. OutputMin and OutputMax bound the output universe used by
+// the Mamdani centroid.
+type System struct {
+	Name      string
+	Inputs    []fuzzifikasi.Variable
+	Outputs   []OutputTerm
+	Rules     []SystemRule
+	OutputMin float64
+	OutputMax float64
+}
+
+// StageResult is the trace of evaluating one System. For the Mamdani methods
+// the per-rule CrispValue is the centroid of that rule's implied set, while
+// CrispOutput is the centroid of the aggregated set.
+type StageResult struct {
+	Stage       string                        `json:"stage"`
+	Method      Method                        `json:"method"`
+	Inputs      map[string]float64            `json:"inputs"`
+	Memberships map[string]map[string]float64 `json:"fuzzy_membership"`
+	RuleOutputs []RuleOutput                  `json:"rule_outputs"`
+	WeightedSum float64                       `json:"weighted_sum"`
+	TotalWeight float64                       `json:"total_weight"`
+	CrispOutput float64                       `json:"crisp_output"`
+}
+
+// Conditions returns the rule antecedents keyed by the variable names used in DefaultSystem.
+func (r Rule) Conditions() map[string]string {
+	return map[string]string{
+		"gpa":        r.GPA,
+		"cca":        r.CCA,
+		"attendance": r.Attendance,
+		"midterm":    r.MidtermExam,
+		"final_exam": r.FinalExam,
+	}
+}
+
+// DefaultSystem expresses the flat five-input rule base from Rules() as a System.
+func DefaultSystem() System {
+	rules := Rules()
+	systemRules := make([]SystemRule, 0, len(rules))
+	for _, rule := range rules {
+		systemRules = append(systemRules, SystemRule{Conditions: rule.Conditions(), Output: rule.Performance})
+	}
+
+	return System{
+		Name: "performance",
+		Inputs: []fuzzifikasi.Variable{
+			fuzzifikasi.GPAVariable(),
+			fuzzifikasi.CCAVariable(),
+			fuzzifikasi.AttendanceVariable(),
+			fuzzifikasi.MidtermVariable(),
+			fuzzifikasi.FinalExamVariable(),
+		},
+		Outputs:   PerformanceOutputs(),
+		Rules:     systemRules,
+		OutputMin: 0,
+		OutputMax: 100,
+	}
+}
+
+// DefaultInputs keys the five model inputs by the variable names used in DefaultSystem.
+func DefaultInputs(gpa, cca, attendance, midterm, finalExam float64) map[string]float64 {
+	return map[string]float64{
+		"gpa":        gpa,
+		"cca":        cca,
+		"attendance": attendance,
+		"midterm":    midterm,
+		"final_exam": finalExam,
+	}
+}
+
+// PerformanceOutputs returns the performance categories with their Tsukamoto
+// crisp values, Mamdani output sets and Sugeno consequents. The Sugeno
+// coefficients add up to the width of each category's range so that a
+// student at the top of every input lands at the top of the category.
+func PerformanceOutputs() []OutputTerm {
+	return performanceOutputs(map[string]float64{
+		"gpa":        0.30,
+		"cca":        0.20,
+		"attendance": 0.15,
+		"midterm":    0.15,
+		"final_exam": 0.20,
+	})
+}
+
+// performanceOutputs builds the performance categories with Sugeno slopes
+// split over the inputs by the given shares, which must add up to 1
+func performanceOutputs(shares map[string]float64) []OutputTerm {
+	weights := func(total float64) map[string]float64 {
+		coefficients := make(map[string]float64, len(shares))
+		for input, share := range shares {
+			coefficients[input] = total * share
+		}
+		return coefficients
+	}
+
+	return []OutputTerm{
+		{
+			Name:   "Poor",
+			Value:  performanceValues["Poor"],
+			Set:    fuzzifikasi.Term{Name: "Poor", A: 0, B: 0, C: 20, D: 40},
+			Sugeno: SugenoConsequent{Constant: 10, Coefficients: weights(20)},
+		},
+		{
+			Name:   "Needs Improvement",
+			Value:  performanceValues["Needs Improvement"],
+			Set:    fuzzifikasi.Term{Name: "Needs Improvement", A: 30, B: 45, C: 55, D: 60},
+			Sugeno: SugenoConsequent{Constant: 40, Coefficients: weights(20)},
+		},
+		{
+			Name:   "Satisfactory",
+			Value:  performanceValues["Satisfactory"],
+			Set:    fuzzifikasi.Term{Name: "Satisfactory", A: 60, B: 65, C: 75, D: 80},
+			Sugeno: SugenoConsequent{Constant: 60, Coefficients: weights(20)},
+		},
+		{
+			Name:   "Good",
+			Value:  performanceValues["Good"],
+			Set:    fuzzifikasi.Term{Name: "Good", A: 75, B: 80, C: 90, D: 95},
+			Sugeno: SugenoConsequent{Constant: 75, Coefficients: weights(20)},
+		},
+		{
+			Name:   "Excellent",
+			Value:  performanceValues["Excellent"],
+			Set:    fuzzifikasi.Term{Name: "Excellent", A: 90, B: 95, C: 100, D: 100},
+			Sugeno: SugenoConsequent{Constant: 90, Coefficients: weights(10)},
+		},
+	}
+}
+
+// Infer evaluates the system with the Tsukamoto method.
+func (s System) Infer(inputs map[string]float64) (StageResult, error) {
+	return s.InferWith(MethodTsukamoto, inputs)
+}
+
+// firedRule is a rule with non-zero firing strength and its consequent
+type firedRule struct {
+	strength float64
+	output   OutputTerm
+}
+
+// InferWith evaluates the system for the given inputs, which must contain a
+// value for every input variable, using the selected method.
+func (s System) InferWith(method Method, inputs map[string]float64) (StageResult, error) {
+	result := StageResult{
+		Stage:       s.Name,
+		Method:      method,
+		Inputs:      make(map[string]float64, len(s.Inputs)),
+		Memberships: make(map[string]map[string]float64, len(s.Inputs)),
+	}
+
+	for _, variable := range s.Inputs {
+		value, ok := inputs[variable.Name]
+		if !ok {
+			return result, fmt.Errorf("stage %s: missing input %q", s.Name, variable.Name)
+		}
+		result.Inputs[variable.Name] = value
+		result.Memberships[variable.Name] = variable.Fuzzify(value)
+	}
+
+	outputs := make(map[string]OutputTerm, len(s.Outputs))
+	for _, output := range s.Outputs {
+		outputs[output.Name] = output
+	}
+
+	var fired []firedRule
+	for i, rule := range s.Rules {
+		// Firing strength is the minimum (AND) over the rule's conditions
+		firingStrength := 1.0
+		for _, variable := range s.Inputs {
+			term, ok := rule.Conditions[variable.Name]
+			if !ok {
+				continue
+			}
+			firingStrength = math.Min(firingStrength, result.Memberships[variable.Name][term])
+		}
+
+		if firingStrength <= 0 {
+			continue
+		}
+
+		output, ok := outputs[rule.Output]
+		if !ok {
+			return result, fmt.Errorf("stage %s: rule %d has unknown output %q", s.Name, i, rule.Output)
+		}
+
+		var crispValue float64
+		switch method {
+		case MethodTsukamoto:
+			crispValue = output.Value
+		case MethodSugeno:
+			crispValue = s.sugenoValue(output.Sugeno, result.Inputs)
+		case MethodMamdani, MethodMamdaniProduct:
+			crispValue = s.centroid(method, []firedRule{{strength: firingStrength, output: output}})
+		default:
+			return result, fmt.Errorf("unknown inference method %q", method)
+		}
+
+		weightedValue := firingStrength * crispValue
+		result.WeightedSum += weightedValue
+		result.TotalWeight += firingStrength
+		fired = append(fired, firedRule{strength: firingStrength, output: output})
+		result.RuleOutputs = append(result.RuleOutputs, RuleOutput{
+			RuleIndex:      i,
+			FiringStrength: firingStrength,
+			CrispValue:     crispValue,
+			WeightedValue:  weightedValue,
+			Performance:    rule.Output,
+		})
+	}
+
+	if result.TotalWeight > 0 {
+		switch method {
+		case MethodMamdani, MethodMamdaniProduct:
+			result.CrispOutput = s.centroid(method, fired)
+		default:
+			result.CrispOutput = result.WeightedSum / result.TotalWeight
+		}
+	}
+
+	return result, nil
+}
+
+// sugenoValue evaluates a linear consequent on the normalized inputs
+func (s System) sugenoValue(consequent SugenoConsequent, inputs map[string]float64) float64 {
+	value := consequent.Constant
+	for _, variable := range s.Inputs {
+		value += consequent.Coefficients[variable.Name] * variable.Normalize(inputs[variable.Name])
+	}
+	return value
+}
+
+// centroid aggregates the implied output sets with max and returns the centre
+// of gravity, approximated over mamdaniSamples points of the output universe
+func (s System) centroid(method Method, fired []firedRule) float64 {
+	step := (s.OutputMax - s.OutputMin) / float64(mamdaniSamples-1)
+
+	var moment, area float64
+	for i := 0; i < mamdaniSamples; i++ {
+		z := s.OutputMin + float64(i)*step
+
+		var mu float64
+		for _, rule := range fired {
+			degree := rule.output.Set.Membership(z)
+			if method == MethodMamdaniProduct {
+				degree *= rule.strength
+			} else {
+				degree = math.Min(degree, rule.strength)
+			}
+			mu = math.Max(mu, degree)
+		}
+
+		moment += z * mu
+		area += mu
+	}
+
+	if area == 0 {
+		return 0
+	}
+	return moment / area
+}
@@ -0,0 +1,97 @@
+package inferensi
+
+import (
+	"math"
+	"testing"
+	"tsukamoto/internal/modules/fuzzifikasi"
+)
+
+// twoRuleSystem has one input on [0, 1] and two rules whose output sets are
+// rectangles, so every method has an output that is easy to compute by hand
+func twoRuleSystem() System {
+	inf := math.Inf(1)
+	return System{
+		Name: "test",
+		Inputs: []fuzzifikasi.Variable{{Name: "x", Min: 0, Max: 1, Terms: []fuzzifikasi.Term{
+			{Name: "Low", A: -inf, B: -inf, C: 0, D: 1},
+			{Name: "High", A: 0, B: 1, C: inf, D: inf},
+		}}},
+		Outputs: []OutputTerm{
+			{
+				Name:   "Low",
+				Value:  10,
+				Set:    fuzzifikasi.Term{Name: "Low", A: 0, B: 0, C: 20, D: 20},
+				Sugeno: SugenoConsequent{Constant: 10, Coefficients: map[string]float64{"x": 40}},
+			},
+			{
+				Name:   "High",
+				Value:  70,
+				Set:    fuzzifikasi.Term{Name: "High", A: 60, B: 60, C: 80, D: 80},
+				Sugeno: SugenoConsequent{Constant: 50, Coefficients: map[string]float64{"x": 20}},
+			},
+		},
+		Rules: []SystemRule{
+			{Conditions: map[string]string{"x": "Low"}, Output: "Low"},
+			{Conditions: map[string]string{"x": "High"}, Output: "High"},
+		},
+		OutputMin: 0,
+		OutputMax: 100,
+	}
+}
+
+func TestSystem_InferTrace(t *testing.T) {
+	result, err := twoRuleSystem().Infer(map[string]float64{"x": 0.25})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// x = 0.25 memberi Low 0.75 dan High 0.25; (0.75*10 + 0.25*70) / 1
+	if result.Stage != "test" || result.Method != MethodTsukamoto {
+		t.Errorf("expected stage test with tsukamoto, got %s with %s", result.Stage, result.Method)
+	}
+	if math.Abs(result.Memberships["x"]["Low"]-0.75) > 1e-9 || math.Abs(result.Memberships["x"]["High"]-0.25) > 1e-9 {
+		t.Errorf("expected memberships Low 0.75 and High 0.25, got %v", result.Memberships["x"])
+	}
+	if len(result.RuleOutputs) != 2 {
+		t.Fatalf("expected 2 fired rules, got %d", len(result.RuleOutputs))
+	}
+	if math.Abs(result.TotalWeight-1) > 1e-9 || math.Abs(result.WeightedSum-25) > 1e-9 {
+		t.Errorf("expected total weight 1 and weighted sum 25, got %v and %v", result.TotalWeight, result.WeightedSum)
+	}
+	if math.Abs(result.CrispOutput-25) > 1e-9 {
+		t.Errorf("expected crisp output 25, got %v", result.CrispOutput)
+	}
+}
+
+func TestSystem_InferErrors(t *testing.T) {
+	system := twoRuleSystem()
+	if _, err := system.Infer(map[string]float64{}); err == nil {
+		t.Errorf("expected error for a missing input")
+	}
+
+	system.Rules = append(system.Rules, SystemRule{Conditions: map[string]string{"x": "High"}, Output: "Outstanding"})
+	if _, err := system.Infer(map[string]float64{"x": 1}); err == nil {
+		t.Errorf("expected error for an unknown output")
+	}
+}
+
+func TestDefaultSystem_MatchesTsukamotoInference(t *testing.T) {
+	system := DefaultSystem()
+	for _, gpa := range []float64{1.8, 2.5, 3.2} {
+		for _, cca := range []float64{52, 65, 85} {
+			for _, attendance := range []float64{0.62, 0.75, 0.95} {
+				for _, exam := range []float64{53, 65, 90} {
+					expected := TsukamotoInference(gpa, cca, attendance, exam, exam)
+					result, err := system.Infer(DefaultInputs(gpa, cca, attendance, exam, exam))
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if math.Abs(result.CrispOutput-expected.CrispOutput) > 1e-9 || len(result.RuleOutputs) != len(expected.RuleOutputs) {
+						t.Fatalf("inputs %v %v %v %v: expected %v from %d rules, got %v from %d rules",
+							gpa, cca, attendance, exam, expected.CrispOutput, len(expected.RuleOutputs), result.CrispOutput, len(result.RuleOutputs))
+					}
+				}
+			}
+		}
+	}
+}
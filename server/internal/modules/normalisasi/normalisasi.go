@@ -0,0 +1,124 @@
+package normalisasi
+
+import (
+	"fmt"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+)
+
+// Universes of the fuzzy model; every stored academic value uses these ranges
+const (
+	ModelGPAMax        = 4.0
+	ModelAttendanceMax = 1.0
+	ModelScoreMax      = 100.0
+)
+
+// Scale describes how a university reports grades. AttendanceMax is 1 when
+// attendance is a fraction and 100 when it is a percentage; ScoreMax applies
+// to the core course average and the exam scores.
+type Scale struct {
+	GPAMax        float64 `json:"gpa_max"`
+	AttendanceMax float64 `json:"attendance_max"`
+	ScoreMax      float64 `json:"score_max"`
+}
+
+// DefaultScale is the scale of the fuzzy model itself.
+func DefaultScale() Scale {
+	return Scale{GPAMax: ModelGPAMax, AttendanceMax: ModelAttendanceMax, ScoreMax: ModelScoreMax}
+}
+
+// ScaleFor returns the grading scale of a university, falling back to the
+// model scale for any value that is not configured.
+func ScaleFor(university *models.University) Scale {
+	scale := DefaultScale()
+	if university == nil {
+		return scale
+	}
+	if university.GPAScale > 0 {
+		scale.GPAMax = float64(university.GPAScale)
+	}
+	if university.AttendanceScale > 0 {
+		scale.AttendanceMax = float64(university.AttendanceScale)
+	}
+	if university.ScoreScale > 0 {
+		scale.ScoreMax = float64(university.ScoreScale)
+	}
+	return scale
+}
+
+// Input holds the five academic values used by the fuzzy model.
+type Input struct {
+	GPA               float64
+	CoreCourseAverage float64
+	AttendanceRate    float64
+	MidtermExamScore  float64
+	FinalExamScore    float64
+}
+
+// FromAcademic reads the fuzzy inputs of an academic record.
+func FromAcademic(academic models.Academic) Input {
+	return Input{
+		GPA:               float64(academic.GPA),
+		CoreCourseAverage: float64(academic.CoreCourseAverage),
+		AttendanceRate:    float64(academic.AttendanceRate),
+		MidtermExamScore:  float64(academic.MidtermExamScore),
+		FinalExamScore:    float64(academic.FinalExamScore),
+	}
+}
+
+// Apply writes the values onto an academic record.
+func (in Input) Apply(academic *models.Academic) {
+	academic.GPA = float32(in.GPA)
+	academic.CoreCourseAverage = float32(in.CoreCourseAverage)
+	academic.AttendanceRate = float32(in.AttendanceRate)
+	academic.MidtermExamScore = float32(in.MidtermExamScore)
+	academic.FinalExamScore = float32(in.FinalExamScore)
+}
+
+// Normalize validates values reported on the given scale and converts them to
+// the model's universes. Every out-of-range value produces an ErrorDetail
+// keyed by the academic JSON field name.
+func Normalize(in Input, scale Scale) (Input, []utils.ErrorDetail) {
+	var errors []utils.ErrorDetail
+	check := func(field, label string, value, max float64) {
+		if value < 0 || value > max {
+			errors = append(errors, utils.ErrorDetail{
+				Field:   field,
+				Message: fmt.Sprintf("Nilai %s harus di antara 0 dan %g", label, max),
+			})
+		}
+	}
+
+	check("gpa", "GPA", in.GPA, scale.GPAMax)
+	check("core_course_average", "CCA", in.CoreCourseAverage, scale.ScoreMax)
+	check("attendance_rate", "attendance", in.AttendanceRate, scale.AttendanceMax)
+	check("midterm_exam_score", "midterm exam", in.MidtermExamScore, scale.ScoreMax)
+	check("final_exam_score", "final exam", in.FinalExamScore, scale.ScoreMax)
+	if len(errors) > 0 {
+		return in, errors
+	}
+
+	return Input{
+		GPA:               rescale(in.GPA, scale.GPAMax, ModelGPAMax),
+		CoreCourseAverage: rescale(in.CoreCourseAverage, scale.ScoreMax, ModelScoreMax),
+		AttendanceRate:    rescale(in.AttendanceRate, scale.AttendanceMax, ModelAttendanceMax),
+		MidtermExamScore:  rescale(in.MidtermExamScore, scale.ScoreMax, ModelScoreMax),
+		FinalExamScore:    rescale(in.FinalExamScore, scale.ScoreMax, ModelScoreMax),
+	}, nil
+}
+
+// rescale maps value from [0, from] to [0, to], leaving it untouched when the
+// ranges match so that values on the model scale round-trip exactly
+func rescale(value, from, to float64) float64 {
+	if from == to {
+		return value
+	}
+	return value * to / from
+}
+
+// Validate checks values that are already expressed in the model's universes,
+// such as stored academic records.
+func Validate(in Input) []utils.ErrorDetail {
+	_, errors := Normalize(in, DefaultScale())
+	return errors
+}
@@ -0,0 +1,112 @@
+package normalisasi
+
+import (
+	"math"
+	"testing"
+	"tsukamoto/internal/models"
+)
+
+func TestScaleFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		university *models.University
+		expected   Scale
+	}{
+		{"no university", nil, DefaultScale()},
+		{"unconfigured", &models.University{}, DefaultScale()},
+		{"gpa out of 5 with percent attendance", &models.University{GPAScale: 5, AttendanceScale: 100}, Scale{GPAMax: 5, AttendanceMax: 100, ScoreMax: 100}},
+		{"scores out of 10", &models.University{ScoreScale: 10}, Scale{GPAMax: 4, AttendanceMax: 1, ScoreMax: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScaleFor(tt.university); got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalize_Rescales(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       Input
+		scale    Scale
+		expected Input
+	}{
+		{
+			"model scale is unchanged",
+			Input{GPA: 3.3, CoreCourseAverage: 72.5, AttendanceRate: 0.87, MidtermExamScore: 64, FinalExamScore: 81},
+			DefaultScale(),
+			Input{GPA: 3.3, CoreCourseAverage: 72.5, AttendanceRate: 0.87, MidtermExamScore: 64, FinalExamScore: 81},
+		},
+		{
+			"gpa out of 5 and percent attendance",
+			Input{GPA: 4.5, CoreCourseAverage: 80, AttendanceRate: 85, MidtermExamScore: 70, FinalExamScore: 90},
+			Scale{GPAMax: 5, AttendanceMax: 100, ScoreMax: 100},
+			Input{GPA: 3.6, CoreCourseAverage: 80, AttendanceRate: 0.85, MidtermExamScore: 70, FinalExamScore: 90},
+		},
+		{
+			"scores out of 10",
+			Input{GPA: 2, CoreCourseAverage: 7.5, AttendanceRate: 0.5, MidtermExamScore: 6, FinalExamScore: 10},
+			Scale{GPAMax: 4, AttendanceMax: 1, ScoreMax: 10},
+			Input{GPA: 2, CoreCourseAverage: 75, AttendanceRate: 0.5, MidtermExamScore: 60, FinalExamScore: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := Normalize(tt.in, tt.scale)
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors %v", errs)
+			}
+			pairs := [][2]float64{
+				{tt.expected.GPA, got.GPA},
+				{tt.expected.CoreCourseAverage, got.CoreCourseAverage},
+				{tt.expected.AttendanceRate, got.AttendanceRate},
+				{tt.expected.MidtermExamScore, got.MidtermExamScore},
+				{tt.expected.FinalExamScore, got.FinalExamScore},
+			}
+			for _, pair := range pairs {
+				if math.Abs(pair[0]-pair[1]) > 1e-9 {
+					t.Fatalf("expected %+v, got %+v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalize_RejectsOutOfRange(t *testing.T) {
+	// Kehadiran 85 hanya valid pada skala persen
+	in := Input{GPA: 4.5, CoreCourseAverage: -1, AttendanceRate: 85, MidtermExamScore: 70, FinalExamScore: 101}
+	_, errs := Normalize(in, DefaultScale())
+
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, field := range []string{"gpa", "core_course_average", "attendance_rate", "final_exam_score"} {
+		if !fields[field] {
+			t.Errorf("expected an error for %s, got %v", field, errs)
+		}
+	}
+	if len(errs) != 4 {
+		t.Errorf("expected 4 errors, got %v", errs)
+	}
+
+	if errs := Validate(Input{GPA: 4, CoreCourseAverage: 100, AttendanceRate: 1, MidtermExamScore: 0, FinalExamScore: 100}); len(errs) != 0 {
+		t.Errorf("expected the bounds of the model scale to be valid, got %v", errs)
+	}
+}
+
+func TestRescale(t *testing.T) {
+	if got := rescale(0.87, 1, 1); got != 0.87 {
+		t.Errorf("expected matching ranges to round-trip exactly, got %v", got)
+	}
+	if got := rescale(50, 100, 1); got != 0.5 {
+		t.Errorf("expected 0.5, got %v", got)
+	}
+	if got := rescale(4.5, 5, 4); math.Abs(got-3.6) > 1e-9 {
+		t.Errorf("expected 3.6, got %v", got)
+	}
+}
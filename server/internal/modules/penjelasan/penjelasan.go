@@ -0,0 +1,224 @@
+package penjelasan
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"tsukamoto/internal/modules/fuzzifikasi"
+	"tsukamoto/internal/modules/inferensi"
+)
+
+// TopFactors is the number of factors described in the explanation texts
+const TopFactors = 3
+
+// Factor describes how one input variable shaped the crisp score. Term is the
+// term the value belongs to most strongly and Rule the 1-based number of the
+// strongest fired rule that uses that term (0 when none fired). Influence is
+// the change in crisp score relative to a typical value of the variable:
+// positive factors raised the score, negative ones lowered it.
+type Factor struct {
+	Variable   string  `json:"variable"`
+	Value      float64 `json:"value"`
+	Term       string  `json:"term"`
+	Membership float64 `json:"membership"`
+	Rule       int     `json:"rule"`
+	RuleOutput string  `json:"rule_output,omitempty"`
+	Influence  float64 `json:"influence"`
+}
+
+// Explanation is the natural-language justification of an assessment in
+// Indonesian and English, together with every factor ranked by influence.
+type Explanation struct {
+	Indonesian string   `json:"id"`
+	English    string   `json:"en"`
+	Factors    []Factor `json:"factors"`
+}
+
+// Label pasangan nama variabel dalam bahasa Indonesia dan Inggris
+var variableLabels = map[string][2]string{
+	"gpa":        {"IPK", "GPA"},
+	"cca":        {"Rata-rata mata kuliah inti", "core course average"},
+	"attendance": {"Kehadiran", "attendance"},
+	"midterm":    {"Nilai UTS", "midterm exam score"},
+	"final_exam": {"Nilai UAS", "final exam score"},
+}
+
+var termLabels = map[string]string{
+	"Low":    "Rendah",
+	"Medium": "Sedang",
+	"High":   "Tinggi",
+}
+
+var categoryLabels = map[string]string{
+	"Poor":              "Buruk",
+	"Needs Improvement": "Perlu Perbaikan",
+	"Satisfactory":      "Memuaskan",
+	"Good":              "Baik",
+	"Excellent":         "Sangat Baik",
+}
+
+// CategoryLabel returns the Indonesian name of a performance category
+func CategoryLabel(category string) string {
+	return translate(categoryLabels, category)
+}
+
+// Explain justifies the result of evaluating system with method. The
+// strongest membership of every input and the fired rules come from the
+// trace in result; the influence of each input is measured by re-evaluating
+// the system with that input replaced by the peak of its middle term.
+func Explain(system inferensi.System, method inferensi.Method, result inferensi.StageResult, category string) Explanation {
+	factors := make([]Factor, 0, len(system.Inputs))
+	for _, variable := range system.Inputs {
+		factor := Factor{Variable: variable.Name, Value: result.Inputs[variable.Name]}
+
+		for _, term := range variable.Terms {
+			if degree := result.Memberships[variable.Name][term.Name]; degree > factor.Membership {
+				factor.Term = term.Name
+				factor.Membership = degree
+			}
+		}
+
+		var strongest float64
+		for _, ruleOutput := range result.RuleOutputs {
+			if ruleOutput.FiringStrength <= strongest || ruleOutput.RuleIndex >= len(system.Rules) {
+				continue
+			}
+			if system.Rules[ruleOutput.RuleIndex].Conditions[variable.Name] != factor.Term {
+				continue
+			}
+			strongest = ruleOutput.FiringStrength
+			factor.Rule = ruleOutput.RuleIndex + 1
+			factor.RuleOutput = ruleOutput.Performance
+		}
+
+		factor.Influence = influence(system, method, result, variable.Name, reference(variable.Terms))
+		factors = append(factors, factor)
+	}
+
+	sort.SliceStable(factors, func(i, j int) bool {
+		return math.Abs(factors[i].Influence) > math.Abs(factors[j].Influence)
+	})
+
+	return Explanation{
+		Indonesian: indonesian(method, result, category, factors),
+		English:    english(method, result, category, factors),
+		Factors:    factors,
+	}
+}
+
+// influence returns how far the crisp output moves when the variable takes
+// the reference value instead of its actual value. It is zero when the
+// substitution leaves no rule firing, since the score is then undefined.
+func influence(system inferensi.System, method inferensi.Method, result inferensi.StageResult, name string, referenceValue float64) float64 {
+	if result.TotalWeight == 0 {
+		return 0
+	}
+
+	inputs := make(map[string]float64, len(result.Inputs))
+	for key, value := range result.Inputs {
+		inputs[key] = value
+	}
+	inputs[name] = referenceValue
+
+	counterfactual, err := system.InferWith(method, inputs)
+	if err != nil || counterfactual.TotalWeight == 0 {
+		return 0
+	}
+	return round(result.CrispOutput - counterfactual.CrispOutput)
+}
+
+// reference is the centre of the plateau of the middle term, which stands
+// for a typical value of the variable
+func reference(terms []fuzzifikasi.Term) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+	middle := terms[len(terms)/2]
+	return (middle.B + middle.C) / 2
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func topFactors(factors []Factor) []Factor {
+	if len(factors) > TopFactors {
+		return factors[:TopFactors]
+	}
+	return factors
+}
+
+func label(variable string, english bool) string {
+	labels, ok := variableLabels[variable]
+	if !ok {
+		return variable
+	}
+	if english {
+		return labels[1]
+	}
+	return labels[0]
+}
+
+func translate(labels map[string]string, value string) string {
+	if translated, ok := labels[value]; ok {
+		return translated
+	}
+	return value
+}
+
+func english(method inferensi.Method, result inferensi.StageResult, category string, factors []Factor) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Overall performance is %s (score %.1f, %s method).", category, result.CrispOutput, method)
+	if result.TotalWeight == 0 {
+		b.WriteString(" No rule fired for these inputs, so the score could not be determined.")
+		return b.String()
+	}
+
+	for _, factor := range topFactors(factors) {
+		fmt.Fprintf(&b, " Your %s (%.2f) is mostly %s (%.2f)", label(factor.Variable, true), factor.Value, factor.Term, factor.Membership)
+		if factor.Rule > 0 {
+			fmt.Fprintf(&b, ", which triggered rule %d (%s)", factor.Rule, factor.RuleOutput)
+		} else {
+			b.WriteString(", but no rule with that condition fired")
+		}
+
+		switch {
+		case factor.Influence > 0:
+			fmt.Fprintf(&b, " and raised the score by %.1f points.", factor.Influence)
+		case factor.Influence < 0:
+			fmt.Fprintf(&b, " and lowered the score by %.1f points.", -factor.Influence)
+		default:
+			b.WriteString(" and had no measurable effect on the score.")
+		}
+	}
+	return b.String()
+}
+
+func indonesian(method inferensi.Method, result inferensi.StageResult, category string, factors []Factor) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Performa keseluruhan %s (skor %.1f, metode %s).", translate(categoryLabels, category), result.CrispOutput, method)
+	if result.TotalWeight == 0 {
+		b.WriteString(" Tidak ada aturan yang aktif untuk nilai ini, sehingga skor tidak dapat ditentukan.")
+		return b.String()
+	}
+
+	for _, factor := range topFactors(factors) {
+		fmt.Fprintf(&b, " %s Anda (%.2f) sebagian besar %s (%.2f)", label(factor.Variable, false), factor.Value, translate(termLabels, factor.Term), factor.Membership)
+		if factor.Rule > 0 {
+			fmt.Fprintf(&b, ", yang memicu aturan %d (%s)", factor.Rule, translate(categoryLabels, factor.RuleOutput))
+		} else {
+			b.WriteString(", tetapi tidak ada aturan dengan kondisi tersebut yang aktif")
+		}
+
+		switch {
+		case factor.Influence > 0:
+			fmt.Fprintf(&b, " dan menaikkan skor sebesar %.1f poin.", factor.Influence)
+		case factor.Influence < 0:
+			fmt.Fprintf(&b, " dan menurunkan skor sebesar %.1f poin.", -factor.Influence)
+		default:
+			b.WriteString(" dan tidak berpengaruh terukur pada skor.")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package penjelasan
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"tsukamoto/internal/modules/inferensi"
+)
+
+func explainDefault(t *testing.T, method inferensi.Method, gpa, cca, attendance, midterm, finalExam float64) (inferensi.StageResult, Explanation) {
+	t.Helper()
+	system := inferensi.DefaultSystem()
+	result, err := system.InferWith(method, inferensi.DefaultInputs(gpa, cca, attendance, midterm, finalExam))
+	if err != nil {
+		t.Fatalf("InferWith: %v", err)
+	}
+	return result, Explain(system, method, result, "Needs Improvement")
+}
+
+func TestExplain_RanksFactorsByInfluence(t *testing.T) {
+	_, explanation := explainDefault(t, inferensi.MethodTsukamoto, 2.6, 65, 0.62, 60, 70)
+
+	if len(explanation.Factors) != 5 {
+		t.Fatalf("expected 5 factors, got %d", len(explanation.Factors))
+	}
+	for i := 1; i < len(explanation.Factors); i++ {
+		if math.Abs(explanation.Factors[i].Influence) > math.Abs(explanation.Factors[i-1].Influence) {
+			t.Errorf("factors not ranked by influence: %+v", explanation.Factors)
+		}
+	}
+
+	top := explanation.Factors[0]
+	if top.Variable != "attendance" || top.Term != "Low" || top.Influence >= 0 {
+		t.Errorf("expected low attendance to lower the score most, got %+v", top)
+	}
+	if top.Rule == 0 || top.RuleOutput == "" {
+		t.Errorf("expected the fired rule to be reported, got %+v", top)
+	}
+}
+
+func TestExplain_Texts(t *testing.T) {
+	_, explanation := explainDefault(t, inferensi.MethodTsukamoto, 2.6, 65, 0.62, 60, 70)
+
+	for _, want := range []string{"Your attendance (0.62) is mostly Low", "triggered rule", "lowered the score"} {
+		if !strings.Contains(explanation.English, want) {
+			t.Errorf("English explanation %q does not contain %q", explanation.English, want)
+		}
+	}
+	for _, want := range []string{"Perlu Perbaikan", "Kehadiran Anda (0.62) sebagian besar Rendah", "memicu aturan", "menurunkan skor"} {
+		if !strings.Contains(explanation.Indonesian, want) {
+			t.Errorf("Indonesian explanation %q does not contain %q", explanation.Indonesian, want)
+		}
+	}
+}
+
+func TestExplain_NoRuleFired(t *testing.T) {
+	result, explanation := explainDefault(t, inferensi.MethodTsukamoto, 4, 20, 0.1, 20, 20)
+	if result.TotalWeight != 0 {
+		t.Fatalf("expected no rule to fire, total weight %v", result.TotalWeight)
+	}
+
+	if !strings.Contains(explanation.English, "No rule fired") {
+		t.Errorf("unexpected English explanation %q", explanation.English)
+	}
+	for _, factor := range explanation.Factors {
+		if factor.Rule != 0 || factor.Influence != 0 {
+			t.Errorf("expected no rule and no influence, got %+v", factor)
+		}
+	}
+}
+
+func TestExplain_AllMethods(t *testing.T) {
+	for _, method := range inferensi.Methods() {
+		_, explanation := explainDefault(t, method, 3.1, 72, 0.8, 68, 74)
+		if !strings.Contains(explanation.English, string(method)+" method") {
+			t.Errorf("%s: explanation does not name the method: %q", method, explanation.English)
+		}
+	}
+}
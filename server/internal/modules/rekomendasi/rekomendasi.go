@@ -0,0 +1,329 @@
+package rekomendasi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"tsukamoto/internal/modules/deffuzifikasi"
+	"tsukamoto/internal/modules/inferensi"
+)
+
+// Defaults for the search. The Mamdani centroid samples the whole output
+// universe for every candidate, so those methods search a coarser grid.
+const (
+	DefaultSteps        = 6
+	DefaultMamdaniSteps = 3
+	DefaultLimit        = 3
+	MaxLimit            = 10
+	// DefaultMaxEvaluations bounds the inferences one search may run
+	DefaultMaxEvaluations = 20000
+)
+
+// Bound limits how far a variable can realistically move before the next
+// assessment and how much effort one full MaxChange costs.
+type Bound struct {
+	MaxChange float64 `json:"max_change"`
+	Weight    float64 `json:"weight"`
+}
+
+// DefaultBounds are the realistic changes within one term. Cumulative GPA
+// moves slowly, so changing it is weighted as more effort than the others.
+func DefaultBounds() map[string]Bound {
+	return map[string]Bound{
+		"gpa":        {MaxChange: 0.6, Weight: 2},
+		"cca":        {MaxChange: 20, Weight: 1},
+		"attendance": {MaxChange: 0.3, Weight: 1},
+		"midterm":    {MaxChange: 30, Weight: 1},
+		"final_exam": {MaxChange: 30, Weight: 1},
+	}
+}
+
+// Options configures a search. MaxChange overrides the default bound of a
+// variable and Locked variables keep their current value. Thresholds maps
+// scores to categories; nil selects the default thresholds. Zero Steps and
+// MaxEvaluations select the defaults.
+type Options struct {
+	Method         inferensi.Method
+	Target         string
+	Locked         []string
+	MaxChange      map[string]float64
+	Limit          int
+	Steps          int
+	MaxEvaluations int
+	Thresholds     *deffuzifikasi.Thresholds
+}
+
+// Change is the move of one variable in a plan.
+type Change struct {
+	Variable string  `json:"variable"`
+	From     float64 `json:"from"`
+	To       float64 `json:"to"`
+	Delta    float64 `json:"delta"`
+}
+
+// Plan is one combination of changes that makes the system output the target
+// category. Effort is the sum over the changes of Weight * |Delta| / MaxChange.
+type Plan struct {
+	Changes     []Change           `json:"changes"`
+	Inputs      map[string]float64 `json:"inputs"`
+	CrispOutput float64            `json:"crisp_output"`
+	Category    string             `json:"category"`
+	Effort      float64            `json:"effort"`
+}
+
+// Recommend searches the changes to inputs that make system output the target
+// category. Every unlocked variable is moved towards the target in Steps equal
+// steps up to its bound, clipped to its universe. Combinations are tried from
+// the least effort up; those that only add changes to a plan already found are
+// skipped without inference, and the search stops after Limit plans or
+// MaxEvaluations inferences. An empty result means no plan was found within
+// the bounds and that budget.
+func Recommend(system inferensi.System, inputs map[string]float64, opts Options) ([]Plan, error) {
+	targetRank := deffuzifikasi.CategoryRank(opts.Target)
+	if targetRank < 0 {
+		return nil, fmt.Errorf("unknown target category %q", opts.Target)
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+	if opts.Steps <= 0 {
+		opts.Steps = DefaultSteps
+		if opts.Method == inferensi.MethodMamdani || opts.Method == inferensi.MethodMamdaniProduct {
+			opts.Steps = DefaultMamdaniSteps
+		}
+	}
+	if opts.MaxEvaluations <= 0 {
+		opts.MaxEvaluations = DefaultMaxEvaluations
+	}
+	thresholds := deffuzifikasi.DefaultThresholds()
+	if opts.Thresholds != nil {
+		thresholds = *opts.Thresholds
+	}
+
+	compiled, err := inferensi.Compile(system, opts.Method)
+	if err != nil {
+		return nil, err
+	}
+	evaluator := compiled.NewEvaluator()
+
+	locked := make(map[string]bool, len(opts.Locked))
+	for _, name := range opts.Locked {
+		if !hasInput(system, name) {
+			return nil, fmt.Errorf("unknown variable %q", name)
+		}
+		locked[name] = true
+	}
+	for name, maxChange := range opts.MaxChange {
+		if !hasInput(system, name) {
+			return nil, fmt.Errorf("unknown variable %q", name)
+		}
+		if maxChange < 0 {
+			return nil, fmt.Errorf("max change of %s must not be negative", name)
+		}
+	}
+
+	current := make([]float64, len(system.Inputs))
+	for i, variable := range system.Inputs {
+		value, ok := inputs[variable.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing input %q", variable.Name)
+		}
+		current[i] = value
+	}
+
+	crisp, totalWeight, err := evaluator.Evaluate(current)
+	if err != nil {
+		return nil, err
+	}
+	currentRank := -1
+	if totalWeight > 0 {
+		currentRank = deffuzifikasi.CategoryRank(thresholds.Categorize(crisp))
+	}
+	if currentRank == targetRank {
+		return []Plan{newPlan(system, thresholds, current, current, nil, crisp)}, nil
+	}
+
+	// Naik untuk target lebih tinggi, turun untuk target lebih rendah
+	direction := 1.0
+	if targetRank < currentRank {
+		direction = -1
+	}
+
+	bounds := DefaultBounds()
+	weights := make([]float64, len(system.Inputs))
+	spans := make([]float64, len(system.Inputs))
+	levels := make([][]float64, len(system.Inputs))
+	for i, variable := range system.Inputs {
+		bound, ok := bounds[variable.Name]
+		if !ok {
+			bound = Bound{MaxChange: (variable.Max - variable.Min) / 4, Weight: 1}
+		}
+		if maxChange, ok := opts.MaxChange[variable.Name]; ok {
+			bound.MaxChange = maxChange
+		}
+		weights[i] = bound.Weight
+		spans[i] = bound.MaxChange
+
+		levels[i] = []float64{current[i]}
+		if locked[variable.Name] || bound.MaxChange == 0 {
+			continue
+		}
+		for k := 1; k <= opts.Steps; k++ {
+			value := current[i] + direction*bound.MaxChange*float64(k)/float64(opts.Steps)
+			value = round(math.Max(variable.Min, math.Min(variable.Max, value)))
+			if value == levels[i][len(levels[i])-1] {
+				break
+			}
+			levels[i] = append(levels[i], value)
+		}
+	}
+
+	// Urutkan semua kombinasi menurut effort tanpa menjalankan inferensi
+	candidates := make([]candidate, 0, combinations(levels))
+	index := make([]int, len(system.Inputs))
+	for n := 0; ; n++ {
+		var effort float64
+		for i := range index {
+			effort += weights[i] * math.Abs(levels[i][index[i]]-current[i]) / spans[i]
+		}
+		candidates = append(candidates, candidate{number: n, effort: round(effort)})
+
+		// Hitung maju seperti odometer atas semua kombinasi level
+		i := 0
+		for ; i < len(index); i++ {
+			index[i]++
+			if index[i] < len(levels[i]) {
+				break
+			}
+			index[i] = 0
+		}
+		if i == len(index) {
+			break
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].effort < candidates[j].effort
+	})
+
+	var minimal []Plan
+	var kept [][]float64
+	values := make([]float64, len(system.Inputs))
+	evaluations := 0
+	for _, c := range candidates {
+		levelsOf(levels, c.number, values)
+		// Kombinasi yang hanya menambah perubahan pada rencana lebih murah tidak perlu dinilai
+		redundant := false
+		for _, deltas := range kept {
+			if covers(values, current, deltas) {
+				redundant = true
+				break
+			}
+		}
+		if redundant {
+			continue
+		}
+		if evaluations == opts.MaxEvaluations {
+			break
+		}
+		evaluations++
+
+		crisp, totalWeight, err := evaluator.Evaluate(values)
+		if err != nil {
+			return nil, err
+		}
+		if totalWeight == 0 || deffuzifikasi.CategoryRank(thresholds.Categorize(crisp)) != targetRank {
+			continue
+		}
+		minimal = append(minimal, newPlan(system, thresholds, current, values, func(i int) float64 {
+			return weights[i] * math.Abs(values[i]-current[i]) / spans[i]
+		}, crisp))
+		deltas := make([]float64, len(values))
+		for i := range values {
+			deltas[i] = round(math.Abs(values[i] - current[i]))
+		}
+		kept = append(kept, deltas)
+		if len(minimal) == opts.Limit {
+			break
+		}
+	}
+
+	return minimal, nil
+}
+
+// candidate is one combination of levels, numbered in odometer order
+type candidate struct {
+	number int
+	effort float64
+}
+
+// combinations counts the level combinations of the search grid
+func combinations(levels [][]float64) int {
+	n := 1
+	for _, l := range levels {
+		n *= len(l)
+	}
+	return n
+}
+
+// levelsOf writes the values of the numbered combination into values; the
+// first variable changes fastest, like the odometer that numbered them
+func levelsOf(levels [][]float64, number int, values []float64) {
+	for i, l := range levels {
+		values[i] = l[number%len(l)]
+		number /= len(l)
+	}
+}
+
+// covers reports whether moving from current to values changes every variable
+// at least as much as the kept deltas do, so the combination only extends a
+// plan already found
+func covers(values, current, kept []float64) bool {
+	for i, delta := range kept {
+		if round(math.Abs(values[i]-current[i])) < delta {
+			return false
+		}
+	}
+	return true
+}
+
+func hasInput(system inferensi.System, name string) bool {
+	for _, variable := range system.Inputs {
+		if variable.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// newPlan records the changes from current to candidate; cost returns the
+// effort of changing input i
+func newPlan(system inferensi.System, thresholds deffuzifikasi.Thresholds, current, candidate []float64, cost func(i int) float64, crisp float64) Plan {
+	plan := Plan{
+		Changes:     []Change{},
+		Inputs:      make(map[string]float64, len(candidate)),
+		CrispOutput: crisp,
+		Category:    thresholds.Categorize(crisp),
+	}
+	for i, variable := range system.Inputs {
+		plan.Inputs[variable.Name] = candidate[i]
+		if candidate[i] == current[i] {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{
+			Variable: variable.Name,
+			From:     current[i],
+			To:       candidate[i],
+			Delta:    round(candidate[i] - current[i]),
+		})
+		plan.Effort += cost(i)
+	}
+	plan.Effort = round(plan.Effort)
+	return plan
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,203 @@
+package rekomendasi
+
+import (
+	"math"
+	"testing"
+	"tsukamoto/internal/modules/deffuzifikasi"
+	"tsukamoto/internal/modules/inferensi"
+)
+
+// A student who currently needs improvement
+var weakInputs = inferensi.DefaultInputs(2.6, 65, 0.62, 60, 70)
+
+func TestRecommend_ReachesTarget(t *testing.T) {
+	system := inferensi.DefaultSystem()
+	for _, method := range inferensi.Methods() {
+		plans, err := Recommend(system, weakInputs, Options{Method: method, Target: "Satisfactory"})
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if len(plans) == 0 {
+			t.Fatalf("%s: expected at least one plan", method)
+		}
+
+		for i, plan := range plans {
+			result, err := system.InferWith(method, plan.Inputs)
+			if err != nil {
+				t.Fatalf("%s: %v", method, err)
+			}
+			if category := deffuzifikasi.Categorize(result.CrispOutput); category != "Satisfactory" || plan.Category != category {
+				t.Errorf("%s: plan %d reaches %s, reported %s", method, i, category, plan.Category)
+			}
+			if len(plan.Changes) == 0 {
+				t.Errorf("%s: plan %d has no changes", method, i)
+			}
+			if i > 0 && plan.Effort < plans[i-1].Effort {
+				t.Errorf("%s: plans not ranked by effort", method)
+			}
+		}
+	}
+}
+
+func TestRecommend_MamdaniReachesGood(t *testing.T) {
+	// Dua kategori di atas posisi sekarang, dengan grid Mamdani yang lebih kasar
+	for _, method := range []inferensi.Method{inferensi.MethodMamdani, inferensi.MethodMamdaniProduct} {
+		plans, err := Recommend(inferensi.DefaultSystem(), weakInputs, Options{Method: method, Target: "Good"})
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if len(plans) == 0 {
+			t.Fatalf("%s: expected at least one plan", method)
+		}
+		for i, plan := range plans {
+			if plan.Category != "Good" || len(plan.Changes) == 0 {
+				t.Errorf("%s: unexpected plan %d %+v", method, i, plan)
+			}
+		}
+	}
+}
+
+func TestRecommend_StopsAtEvaluationBudget(t *testing.T) {
+	// Excellent tidak tercapai, pencarian berhenti setelah batas inferensi
+	plans, err := Recommend(inferensi.DefaultSystem(), weakInputs, Options{Method: inferensi.MethodTsukamoto, Target: "Excellent", MaxEvaluations: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 0 {
+		t.Errorf("expected no plans, got %+v", plans)
+	}
+}
+
+func TestRecommend_RespectsLocksAndBounds(t *testing.T) {
+	plans, err := Recommend(inferensi.DefaultSystem(), weakInputs, Options{
+		Method:    inferensi.MethodTsukamoto,
+		Target:    "Satisfactory",
+		Locked:    []string{"gpa"},
+		MaxChange: map[string]float64{"midterm": 10},
+		Limit:     5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) == 0 || len(plans) > 5 {
+		t.Fatalf("expected 1 to 5 plans, got %d", len(plans))
+	}
+
+	for _, plan := range plans {
+		for _, change := range plan.Changes {
+			if change.Variable == "gpa" {
+				t.Errorf("locked gpa changed: %+v", change)
+			}
+			if change.Variable == "midterm" && change.Delta > 10 {
+				t.Errorf("midterm change exceeds bound: %+v", change)
+			}
+			if change.Delta <= 0 {
+				t.Errorf("expected only increases towards a better category: %+v", change)
+			}
+		}
+	}
+}
+
+func TestRecommend_PlansAreMinimal(t *testing.T) {
+	plans, err := Recommend(inferensi.DefaultSystem(), weakInputs, Options{Method: inferensi.MethodTsukamoto, Target: "Satisfactory", Limit: MaxLimit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range plans {
+		for j := range plans {
+			if i > j && extends(plans[i], plans[j]) {
+				t.Errorf("plan %d only adds changes to plan %d", i, j)
+			}
+		}
+	}
+}
+
+func TestRecommend_AlreadyInTarget(t *testing.T) {
+	plans, err := Recommend(inferensi.DefaultSystem(), weakInputs, Options{Method: inferensi.MethodTsukamoto, Target: "Needs Improvement"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || len(plans[0].Changes) != 0 || plans[0].Effort != 0 {
+		t.Errorf("expected a single empty plan, got %+v", plans)
+	}
+}
+
+func TestRecommend_CustomThresholds(t *testing.T) {
+	// Dengan batas yang lebih longgar mahasiswa yang sama sudah Satisfactory
+	thresholds := deffuzifikasi.Thresholds{20, 30, 80, 95}
+	plans, err := Recommend(inferensi.DefaultSystem(), weakInputs, Options{Method: inferensi.MethodTsukamoto, Target: "Satisfactory", Thresholds: &thresholds})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || len(plans[0].Changes) != 0 || plans[0].Category != "Satisfactory" {
+		t.Errorf("expected a single empty plan in Satisfactory, got %+v", plans)
+	}
+}
+
+func TestRecommend_Unreachable(t *testing.T) {
+	plans, err := Recommend(inferensi.DefaultSystem(), weakInputs, Options{
+		Method: inferensi.MethodTsukamoto,
+		Target: "Excellent",
+		Locked: []string{"gpa", "cca", "attendance", "midterm", "final_exam"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 0 {
+		t.Errorf("expected no plans, got %+v", plans)
+	}
+}
+
+func TestRecommend_InvalidOptions(t *testing.T) {
+	system := inferensi.DefaultSystem()
+	cases := []Options{
+		{Method: inferensi.MethodTsukamoto, Target: "Legendary"},
+		{Method: "fuzzy", Target: "Good"},
+		{Method: inferensi.MethodTsukamoto, Target: "Good", Locked: []string{"height"}},
+		{Method: inferensi.MethodTsukamoto, Target: "Good", MaxChange: map[string]float64{"gpa": -1}},
+	}
+	for _, opts := range cases {
+		if _, err := Recommend(system, weakInputs, opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+// extends reports whether plan changes every variable at least as much as
+// kept does, i.e. plan is kept with extra effort
+func extends(plan, kept Plan) bool {
+	moved := make(map[string]float64, len(plan.Changes))
+	for _, change := range plan.Changes {
+		moved[change.Variable] = math.Abs(change.Delta)
+	}
+	for _, change := range kept.Changes {
+		if moved[change.Variable] < math.Abs(change.Delta) {
+			return false
+		}
+	}
+	return true
+}
+
+func benchmarkRecommend(b *testing.B, method inferensi.Method, target string) {
+	system := inferensi.DefaultSystem()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Recommend(system, weakInputs, Options{Method: method, Target: target}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Excellent tidak tercapai dari weakInputs, jadi seluruh grid dinilai
+func BenchmarkRecommendTsukamotoUnreachable(b *testing.B) {
+	benchmarkRecommend(b, inferensi.MethodTsukamoto, "Excellent")
+}
+
+func BenchmarkRecommendMamdani(b *testing.B) {
+	benchmarkRecommend(b, inferensi.MethodMamdani, "Excellent")
+}
+
+func BenchmarkRecommendSugeno(b *testing.B) {
+	benchmarkRecommend(b, inferensi.MethodSugeno, "Good")
+}
@@ -0,0 +1,186 @@
+package tren
+
+import (
+	"fmt"
+	"math"
+	"tsukamoto/internal/modules/deffuzifikasi"
+	"tsukamoto/internal/modules/inferensi"
+)
+
+// CrispOutput is the key of the crisp score in Analysis.Slopes
+const CrispOutput = "crisp_output"
+
+// Perubahan kategori antar term
+const (
+	Improved  = "improved"
+	Declined  = "declined"
+	Unchanged = "unchanged"
+)
+
+// Observation is the value of every input in one term. Observations are
+// passed to Analyze in chronological order.
+type Observation struct {
+	Label  string
+	Inputs map[string]float64
+}
+
+// Point is an observation together with its evaluated score.
+type Point struct {
+	Label       string             `json:"term"`
+	Inputs      map[string]float64 `json:"inputs"`
+	CrispOutput float64            `json:"crisp_output"`
+	Category    string             `json:"category"`
+}
+
+// Transition compares the categories of two consecutive terms.
+type Transition struct {
+	From         string  `json:"from_term"`
+	To           string  `json:"to_term"`
+	FromCategory string  `json:"from_category"`
+	ToCategory   string  `json:"to_category"`
+	Change       string  `json:"change"`
+	ScoreDelta   float64 `json:"score_delta"`
+}
+
+// Projection is the expected result of the next term when every input keeps
+// following its linear trend.
+type Projection struct {
+	Inputs      map[string]float64 `json:"inputs"`
+	CrispOutput float64            `json:"crisp_output"`
+	Category    string             `json:"category"`
+}
+
+// Analysis is the trend of a student over their terms. Slopes are the least
+// squares change per term of every input and of the crisp score. Projection
+// is nil when there are fewer than two terms.
+type Analysis struct {
+	Series      []Point            `json:"series"`
+	Slopes      map[string]float64 `json:"slopes"`
+	Transitions []Transition       `json:"transitions"`
+	Projection  *Projection        `json:"projection"`
+}
+
+// Analyze evaluates every observation with system and method, categorizes the
+// scores with thresholds and derives the slopes, category transitions and the
+// projection of the next term. Projected inputs are clipped to the universe of
+// their variable before evaluation.
+func Analyze(system inferensi.System, thresholds deffuzifikasi.Thresholds, method inferensi.Method, observations []Observation) (Analysis, error) {
+	analysis := Analysis{
+		Series:      make([]Point, 0, len(observations)),
+		Slopes:      make(map[string]float64, len(system.Inputs)+1),
+		Transitions: []Transition{},
+	}
+
+	compiled, err := inferensi.Compile(system, method)
+	if err != nil {
+		return analysis, err
+	}
+	evaluator := compiled.NewEvaluator()
+
+	values := make([]float64, len(system.Inputs))
+	series := make([][]float64, len(system.Inputs))
+	var scores []float64
+	for _, observation := range observations {
+		for i, variable := range system.Inputs {
+			value, ok := observation.Inputs[variable.Name]
+			if !ok {
+				return analysis, fmt.Errorf("term %q is missing input %q", observation.Label, variable.Name)
+			}
+			values[i] = value
+			series[i] = append(series[i], value)
+		}
+
+		crisp, _, err := evaluator.Evaluate(values)
+		if err != nil {
+			return analysis, err
+		}
+		scores = append(scores, crisp)
+		analysis.Series = append(analysis.Series, Point{
+			Label:       observation.Label,
+			Inputs:      observation.Inputs,
+			CrispOutput: crisp,
+			Category:    thresholds.Categorize(crisp),
+		})
+	}
+
+	for i, variable := range system.Inputs {
+		analysis.Slopes[variable.Name] = round(slope(series[i]))
+	}
+	analysis.Slopes[CrispOutput] = round(slope(scores))
+
+	for i := 1; i < len(analysis.Series); i++ {
+		previous, current := analysis.Series[i-1], analysis.Series[i]
+		transition := Transition{
+			From:         previous.Label,
+			To:           current.Label,
+			FromCategory: previous.Category,
+			ToCategory:   current.Category,
+			Change:       Unchanged,
+			ScoreDelta:   round(current.CrispOutput - previous.CrispOutput),
+		}
+		switch from, to := deffuzifikasi.CategoryRank(previous.Category), deffuzifikasi.CategoryRank(current.Category); {
+		case to > from:
+			transition.Change = Improved
+		case to < from:
+			transition.Change = Declined
+		}
+		analysis.Transitions = append(analysis.Transitions, transition)
+	}
+
+	if len(observations) < 2 {
+		return analysis, nil
+	}
+
+	projection := &Projection{Inputs: make(map[string]float64, len(system.Inputs))}
+	for i, variable := range system.Inputs {
+		value := round(extrapolate(series[i]))
+		value = math.Max(variable.Min, math.Min(variable.Max, value))
+		projection.Inputs[variable.Name] = value
+		values[i] = value
+	}
+	projection.CrispOutput, _, err = evaluator.Evaluate(values)
+	if err != nil {
+		return analysis, err
+	}
+	projection.Category = thresholds.Categorize(projection.CrispOutput)
+	analysis.Projection = projection
+
+	return analysis, nil
+}
+
+// slope fits y against the term index 0..n-1 by least squares
+func slope(y []float64) float64 {
+	n := float64(len(y))
+	if len(y) < 2 {
+		return 0
+	}
+	meanX := (n - 1) / 2
+	var meanY float64
+	for _, value := range y {
+		meanY += value
+	}
+	meanY /= n
+
+	var covariance, variance float64
+	for i, value := range y {
+		dx := float64(i) - meanX
+		covariance += dx * (value - meanY)
+		variance += dx * dx
+	}
+	return covariance / variance
+}
+
+// extrapolate returns the value of the least squares line at the next term index
+func extrapolate(y []float64) float64 {
+	n := float64(len(y))
+	var meanY float64
+	for _, value := range y {
+		meanY += value
+	}
+	meanY /= n
+	return meanY + slope(y)*(n-(n-1)/2)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
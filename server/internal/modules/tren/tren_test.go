@@ -0,0 +1,101 @@
+package tren
+
+import (
+	"math"
+	"testing"
+	"tsukamoto/internal/modules/deffuzifikasi"
+	"tsukamoto/internal/modules/inferensi"
+)
+
+func observation(label string, gpa, cca, attendance, midterm, finalExam float64) Observation {
+	return Observation{Label: label, Inputs: inferensi.DefaultInputs(gpa, cca, attendance, midterm, finalExam)}
+}
+
+func TestSlopeAndExtrapolate(t *testing.T) {
+	y := []float64{2.0, 2.5, 3.0}
+	if got := slope(y); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("slope = %v, want 0.5", got)
+	}
+	if got := extrapolate(y); math.Abs(got-3.5) > 1e-9 {
+		t.Errorf("extrapolate = %v, want 3.5", got)
+	}
+	if got := slope([]float64{4}); got != 0 {
+		t.Errorf("slope of one point = %v, want 0", got)
+	}
+}
+
+func TestAnalyze_ImprovingStudent(t *testing.T) {
+	observations := []Observation{
+		observation("2024/2025 odd", 2.2, 55, 0.6, 50, 55),
+		observation("2024/2025 even", 2.8, 68, 0.75, 65, 70),
+		observation("2025/2026 odd", 3.4, 82, 0.9, 80, 85),
+	}
+
+	analysis, err := Analyze(inferensi.DefaultSystem(), deffuzifikasi.DefaultThresholds(), inferensi.MethodTsukamoto, observations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(analysis.Series) != 3 || analysis.Series[2].Label != "2025/2026 odd" {
+		t.Fatalf("unexpected series %+v", analysis.Series)
+	}
+	if math.Abs(analysis.Slopes["gpa"]-0.6) > 1e-9 || analysis.Slopes[CrispOutput] <= 0 {
+		t.Errorf("unexpected slopes %v", analysis.Slopes)
+	}
+
+	if len(analysis.Transitions) != 2 {
+		t.Fatalf("expected 2 transitions, got %d", len(analysis.Transitions))
+	}
+	for _, transition := range analysis.Transitions {
+		if transition.ScoreDelta <= 0 || transition.Change == Declined {
+			t.Errorf("expected non-declining transition, got %+v", transition)
+		}
+	}
+
+	projection := analysis.Projection
+	if projection == nil {
+		t.Fatal("expected a projection")
+	}
+	// GPA 3.4 + 0.6 is clipped to the top of its universe
+	if projection.Inputs["gpa"] != 4 {
+		t.Errorf("expected projected GPA clipped to 4, got %v", projection.Inputs["gpa"])
+	}
+	if deffuzifikasi.CategoryRank(projection.Category) < deffuzifikasi.CategoryRank(analysis.Series[2].Category) {
+		t.Errorf("projection %s should not fall below the last term %s", projection.Category, analysis.Series[2].Category)
+	}
+}
+
+func TestAnalyze_DecliningTransition(t *testing.T) {
+	observations := []Observation{
+		observation("2024/2025 odd", 3.6, 88, 0.95, 85, 90),
+		observation("2024/2025 even", 2.0, 45, 0.5, 40, 45),
+	}
+
+	analysis, err := Analyze(inferensi.DefaultSystem(), deffuzifikasi.DefaultThresholds(), inferensi.MethodTsukamoto, observations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Transitions[0].Change != Declined || analysis.Transitions[0].ScoreDelta >= 0 {
+		t.Errorf("expected a decline, got %+v", analysis.Transitions[0])
+	}
+	if analysis.Slopes[CrispOutput] >= 0 {
+		t.Errorf("expected negative score slope, got %v", analysis.Slopes[CrispOutput])
+	}
+}
+
+func TestAnalyze_SingleTerm(t *testing.T) {
+	analysis, err := Analyze(inferensi.DefaultSystem(), deffuzifikasi.DefaultThresholds(), inferensi.MethodTsukamoto, []Observation{observation("2025/2026 odd", 3, 70, 0.8, 70, 70)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Projection != nil || len(analysis.Transitions) != 0 || analysis.Slopes["gpa"] != 0 {
+		t.Errorf("unexpected analysis for a single term %+v", analysis)
+	}
+}
+
+func TestAnalyze_MissingInput(t *testing.T) {
+	_, err := Analyze(inferensi.DefaultSystem(), deffuzifikasi.DefaultThresholds(), inferensi.MethodTsukamoto, []Observation{{Label: "x", Inputs: map[string]float64{"gpa": 3}}})
+	if err == nil {
+		t.Error("expected error for missing input")
+	}
+}
@@ -0,0 +1,107 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type directory struct {
+	db *gorm.DB
+}
+
+// NewDirectory returns a Directory reading ownership from the database
+func NewDirectory(db *gorm.DB) Directory {
+	return &directory{db: db}
+}
+
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func deref(id *uint) uint {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+func (d *directory) Student(ctx context.Context, id uint) (Student, error) {
+	var user models.User
+	if err := d.db.WithContext(ctx).Select("id", "university_id", "advisor_id").First(&user, id).Error; err != nil {
+		return Student{}, notFound(err)
+	}
+	student := Student{ID: id, UniversityID: deref(user.UniversityID), AdvisorID: deref(user.AdvisorID)}
+	if student.UniversityID == 0 {
+		// Akun lama hanya mencatat universitas pada data akademik
+		var academic models.Academic
+		err := d.db.WithContext(ctx).Select("university_id").Where("user_id = ?", id).Order("id DESC").First(&academic).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return Student{}, err
+		}
+		student.UniversityID = academic.UniversityID
+	}
+	return student, nil
+}
+
+func (d *directory) User(ctx context.Context, id uint) (User, error) {
+	var user models.User
+	if err := d.db.WithContext(ctx).Select("id", "role", "university_id").First(&user, id).Error; err != nil {
+		return User{}, notFound(err)
+	}
+	return User{ID: id, Role: user.Role, UniversityID: deref(user.UniversityID)}, nil
+}
+
+func (d *directory) AcademicOwner(ctx context.Context, academicID int) (uint, error) {
+	var academic models.Academic
+	if err := d.db.WithContext(ctx).Select("user_id").First(&academic, academicID).Error; err != nil {
+		return 0, notFound(err)
+	}
+	return academic.UserID, nil
+}
+
+func (d *directory) AlertOwner(ctx context.Context, alertID int) (uint, error) {
+	var alert models.Alert
+	if err := d.db.WithContext(ctx).Select("user_id").First(&alert, alertID).Error; err != nil {
+		return 0, notFound(err)
+	}
+	return alert.UserID, nil
+}
+
+func (d *directory) Course(ctx context.Context, id int) (Course, error) {
+	var course models.Course
+	if err := d.db.WithContext(ctx).Select("id", "university_id", "lecturer_id").First(&course, id).Error; err != nil {
+		return Course{}, notFound(err)
+	}
+	return Course{ID: course.ID, UniversityID: course.UniversityID, LecturerID: deref(course.LecturerID)}, nil
+}
+
+func (d *directory) EnrollmentCourse(ctx context.Context, enrollmentID int) (Course, error) {
+	var enrollment models.Enrollment
+	if err := d.db.WithContext(ctx).Select("course_id").First(&enrollment, enrollmentID).Error; err != nil {
+		return Course{}, notFound(err)
+	}
+	return d.Course(ctx, enrollment.CourseID)
+}
+
+func (d *directory) SessionCourse(ctx context.Context, sessionID int) (Course, error) {
+	var session models.AttendanceSession
+	if err := d.db.WithContext(ctx).Select("course_id").First(&session, sessionID).Error; err != nil {
+		return Course{}, notFound(err)
+	}
+	return d.Course(ctx, session.CourseID)
+}
+
+func (d *directory) Teaches(ctx context.Context, lecturerID, studentID uint) (bool, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&models.Enrollment{}).
+		Joins("JOIN courses ON courses.id = enrollments.course_id").
+		Where("courses.lecturer_id = ? AND enrollments.user_id = ?", lecturerID, studentID).
+		Count(&count).Error
+	return count > 0, err
+}
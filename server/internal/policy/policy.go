@@ -0,0 +1,379 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ErrNotFound is returned by a Directory when the looked up entity does not exist
+var ErrNotFound = errors.New("not found")
+
+// errForbidden is returned by a scope that denies the caller
+var errForbidden = errors.New("forbidden")
+
+// Student is the ownership information of a student account
+type Student struct {
+	ID           uint
+	UniversityID uint
+	AdvisorID    uint
+}
+
+// Course is the ownership information of a course
+type Course struct {
+	ID           int
+	UniversityID uint
+	LecturerID   uint
+}
+
+// User is the ownership information of any account
+type User struct {
+	ID           uint
+	Role         string
+	UniversityID uint
+}
+
+// Directory resolves the relations ownership rules depend on
+type Directory interface {
+	Student(ctx context.Context, id uint) (Student, error)
+	User(ctx context.Context, id uint) (User, error)
+	AcademicOwner(ctx context.Context, academicID int) (uint, error)
+	AlertOwner(ctx context.Context, alertID int) (uint, error)
+	Course(ctx context.Context, id int) (Course, error)
+	EnrollmentCourse(ctx context.Context, enrollmentID int) (Course, error)
+	SessionCourse(ctx context.Context, sessionID int) (Course, error)
+	// Teaches reports whether the lecturer teaches a course the student is enrolled in
+	Teaches(ctx context.Context, lecturerID, studentID uint) (bool, error)
+}
+
+// scope decides whether the caller may touch the entity named by the request
+type scope func(r *http.Request, caller middleware.AuthUser, dir Directory) error
+
+// Rule lists the roles allowed on a route and the ownership checks the
+// caller must pass on top of the role
+type Rule struct {
+	public         bool
+	passwordChange bool
+	roles          []string
+	scopes         []scope
+}
+
+// Public marks a route reachable without authentication
+func Public() Rule {
+	return Rule{public: true}
+}
+
+// Roles allows callers holding any of the roles
+func Roles(roles ...string) Rule {
+	return Rule{roles: roles}
+}
+
+func (rule Rule) with(s scope) Rule {
+	rule.scopes = append(append([]scope{}, rule.scopes...), s)
+	return rule
+}
+
+// AllowPendingPasswordChange lets callers who still have to replace their
+// initial password use the route; every other route is closed to them
+func (rule Rule) AllowPendingPasswordChange() Rule {
+	rule.passwordChange = true
+	return rule
+}
+
+// Global restricts the admins on the route to super admins, which are the
+// only ones not bound to a university
+func (rule Rule) Global() Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		if caller.Role == models.RoleAdmin {
+			return errForbidden
+		}
+		return nil
+	})
+}
+
+// OwnUniversity requires the caller to belong to the university whose ID is
+// in the path variable
+func (rule Rule) OwnUniversity(param string) Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		id, err := pathID(r, param)
+		if err != nil {
+			return err
+		}
+		return sameUniversity(caller, uint(id))
+	})
+}
+
+// OwnStudent requires access to the student whose ID is in the path variable
+func (rule Rule) OwnStudent(param string) Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		id, err := pathID(r, param)
+		if err != nil {
+			return err
+		}
+		return canAccessStudent(r.Context(), caller, dir, uint(id))
+	})
+}
+
+// OwnAcademic requires access to the student owning the academic record
+func (rule Rule) OwnAcademic(param string) Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		id, err := pathID(r, param)
+		if err != nil {
+			return err
+		}
+		owner, err := dir.AcademicOwner(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		return canAccessStudent(r.Context(), caller, dir, owner)
+	})
+}
+
+// OwnAlert requires access to the student the alert was raised for
+func (rule Rule) OwnAlert(param string) Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		id, err := pathID(r, param)
+		if err != nil {
+			return err
+		}
+		owner, err := dir.AlertOwner(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		return canAccessStudent(r.Context(), caller, dir, owner)
+	})
+}
+
+// OwnCourse requires the caller to teach the course, administer its
+// university or, for other roles, belong to its university
+func (rule Rule) OwnCourse(param string) Rule {
+	return rule.courseScope(param, Directory.Course)
+}
+
+// OwnEnrollment applies OwnCourse to the course of the enrollment
+func (rule Rule) OwnEnrollment(param string) Rule {
+	return rule.courseScope(param, Directory.EnrollmentCourse)
+}
+
+// OwnSession applies OwnCourse to the course of the attendance session
+func (rule Rule) OwnSession(param string) Rule {
+	return rule.courseScope(param, Directory.SessionCourse)
+}
+
+func (rule Rule) courseScope(param string, lookup func(Directory, context.Context, int) (Course, error)) Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		id, err := pathID(r, param)
+		if err != nil {
+			return err
+		}
+		course, err := lookup(dir, r.Context(), id)
+		if err != nil {
+			return err
+		}
+		switch caller.Role {
+		case models.RoleSuperAdmin, models.RoleAdmin:
+			return sameUniversity(caller, course.UniversityID)
+		case models.RoleLecturer:
+			if course.LecturerID == caller.ID {
+				return nil
+			}
+			return errForbidden
+		default:
+			if caller.UniversityID != 0 && caller.UniversityID == course.UniversityID {
+				return nil
+			}
+			return errForbidden
+		}
+	})
+}
+
+// OwnUser requires the caller to be the user or an admin of their university
+func (rule Rule) OwnUser(param string) Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		id, err := pathID(r, param)
+		if err != nil {
+			return err
+		}
+		if uint(id) == caller.ID || caller.Role == models.RoleSuperAdmin {
+			return nil
+		}
+		if caller.Role != models.RoleAdmin {
+			return errForbidden
+		}
+		user, err := dir.User(r.Context(), uint(id))
+		if err != nil {
+			return err
+		}
+		return sameUniversity(caller, user.UniversityID)
+	})
+}
+
+// SelfQuery requires callers with one of the roles to pass their own ID in
+// the query parameter, e.g. an advisor listing alerts with advisor_id
+func (rule Rule) SelfQuery(param string, roles ...string) Rule {
+	return rule.with(func(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+		if !hasRole(roles, caller.Role) {
+			return nil
+		}
+		if r.URL.Query().Get(param) != strconv.FormatUint(uint64(caller.ID), 10) {
+			return errForbidden
+		}
+		return nil
+	})
+}
+
+// canAccessStudent applies the ownership rules of a student's data: the
+// student themselves, their advisor, a lecturer teaching them and the admins
+// of their university
+func canAccessStudent(ctx context.Context, caller middleware.AuthUser, dir Directory, studentID uint) error {
+	switch caller.Role {
+	case models.RoleSuperAdmin:
+		return nil
+	case models.RoleStudent:
+		if caller.ID == studentID {
+			return nil
+		}
+		return errForbidden
+	case models.RoleAdmin:
+		student, err := dir.Student(ctx, studentID)
+		if err != nil {
+			return err
+		}
+		return sameUniversity(caller, student.UniversityID)
+	case models.RoleAdvisor:
+		student, err := dir.Student(ctx, studentID)
+		if err != nil {
+			return err
+		}
+		if student.AdvisorID == caller.ID {
+			return nil
+		}
+		return errForbidden
+	case models.RoleLecturer:
+		teaches, err := dir.Teaches(ctx, caller.ID, studentID)
+		if err != nil {
+			return err
+		}
+		if teaches {
+			return nil
+		}
+		return errForbidden
+	}
+	return errForbidden
+}
+
+func sameUniversity(caller middleware.AuthUser, universityID uint) error {
+	if caller.Role == models.RoleSuperAdmin || (caller.UniversityID != 0 && caller.UniversityID == universityID) {
+		return nil
+	}
+	return errForbidden
+}
+
+func pathID(r *http.Request, param string) (int, error) {
+	id, err := strconv.Atoi(mux.Vars(r)[param])
+	if err != nil || id <= 0 {
+		// ID yang tidak valid ditolak oleh handler dengan 400
+		return 0, ErrNotFound
+	}
+	return id, nil
+}
+
+// holdsRole reports whether the caller holds one of the roles. Super admins
+// hold every admin route as well.
+func holdsRole(roles []string, role string) bool {
+	return hasRole(roles, role) || (role == models.RoleSuperAdmin && hasRole(roles, models.RoleAdmin))
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Check evaluates the rule for the caller. A nil error allows the request;
+// ErrNotFound means the entity could not be resolved and the handler should
+// answer instead.
+func (rule Rule) Check(r *http.Request, caller middleware.AuthUser, dir Directory) error {
+	if rule.public {
+		return nil
+	}
+	if !holdsRole(rule.roles, caller.Role) {
+		return errForbidden
+	}
+	for _, s := range rule.scopes {
+		if err := s(r, caller, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Route identifies a route by its method and mux path template
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Policies maps every route to the rule guarding it
+type Policies map[Route]Rule
+
+// Lookup returns the rule of the route matched for the request
+func (p Policies) Lookup(r *http.Request) (Rule, bool) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return Rule{}, false
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return Rule{}, false
+	}
+	rule, ok := p[Route{Method: r.Method, Path: template}]
+	return rule, ok
+}
+
+func forbidden(w http.ResponseWriter) {
+	utils.WriteResponse(w, http.StatusForbidden, []utils.ErrorDetail{{Message: "You are not allowed to access this resource"}}, nil)
+}
+
+// Authorize enforces the policies on every route. It must run after
+// middleware.Authenticate; routes without a policy are denied.
+func Authorize(policies Policies, dir Directory) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rule, ok := policies.Lookup(r)
+			if ok && rule.public {
+				next.ServeHTTP(w, r)
+				return
+			}
+			caller, authenticated := middleware.UserFromContext(r.Context())
+			if !ok || !authenticated {
+				forbidden(w)
+				return
+			}
+			if caller.MustChangePassword && !rule.passwordChange {
+				utils.WriteResponse(w, http.StatusForbidden, []utils.ErrorDetail{{Field: "password", Message: "Password change required"}}, nil)
+				return
+			}
+
+			err := rule.Check(r, caller, dir)
+			switch {
+			case err == nil, errors.Is(err, ErrNotFound):
+				// Entitas yang tidak ada dilaporkan oleh handler
+				next.ServeHTTP(w, r)
+			case errors.Is(err, errForbidden):
+				forbidden(w)
+			default:
+				utils.WriteResponse(w, http.StatusInternalServerError, []utils.ErrorDetail{{Message: "Failed to check permissions"}}, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+type fakeDirectory struct {
+	err error
+}
+
+func (d fakeDirectory) Student(ctx context.Context, id uint) (Student, error) {
+	if d.err != nil {
+		return Student{}, d.err
+	}
+	switch id {
+	case 10:
+		return Student{ID: 10, UniversityID: 1, AdvisorID: 30}, nil
+	case 11:
+		return Student{ID: 11, UniversityID: 2, AdvisorID: 31}, nil
+	}
+	return Student{}, ErrNotFound
+}
+
+func (d fakeDirectory) User(ctx context.Context, id uint) (User, error) {
+	student, err := d.Student(ctx, id)
+	return User{ID: student.ID, Role: models.RoleStudent, UniversityID: student.UniversityID}, err
+}
+
+func (d fakeDirectory) AcademicOwner(ctx context.Context, academicID int) (uint, error) {
+	return 10, d.err
+}
+
+func (d fakeDirectory) AlertOwner(ctx context.Context, alertID int) (uint, error) {
+	return 10, d.err
+}
+
+func (d fakeDirectory) Course(ctx context.Context, id int) (Course, error) {
+	return Course{ID: id, UniversityID: 1, LecturerID: 20}, d.err
+}
+
+func (d fakeDirectory) EnrollmentCourse(ctx context.Context, enrollmentID int) (Course, error) {
+	return d.Course(ctx, 300)
+}
+
+func (d fakeDirectory) SessionCourse(ctx context.Context, sessionID int) (Course, error) {
+	return d.Course(ctx, 300)
+}
+
+func (d fakeDirectory) Teaches(ctx context.Context, lecturerID, studentID uint) (bool, error) {
+	return lecturerID == 20 && studentID == 10, d.err
+}
+
+// serve routes a request through Authorize with the caller already
+// authenticated; the wrapped handler answers 200
+func serve(policies Policies, dir Directory, caller *middleware.AuthUser, method, path string) int {
+	r := mux.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if caller != nil {
+				req = req.WithContext(middleware.WithUser(req.Context(), *caller))
+			}
+			next.ServeHTTP(w, req)
+		})
+	})
+	r.Use(Authorize(policies, dir))
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	r.HandleFunc("/students/{id}", ok)
+	r.HandleFunc("/public", ok)
+	r.HandleFunc("/unlisted", ok)
+	r.HandleFunc("/password", ok)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w.Code
+}
+
+func TestAuthorize(t *testing.T) {
+	policies := Policies{
+		{Method: http.MethodGet, Path: "/students/{id}"}: Roles(models.RoleAdmin, models.RoleAdvisor, models.RoleStudent).OwnStudent("id"),
+		{Method: http.MethodGet, Path: "/public"}:        Public(),
+		{Method: http.MethodPost, Path: "/password"}:     Roles(models.RoleStudent).AllowPendingPasswordChange(),
+	}
+	student := &middleware.AuthUser{ID: 10, Role: models.RoleStudent, UniversityID: 1}
+	lecturer := &middleware.AuthUser{ID: 20, Role: models.RoleLecturer, UniversityID: 1}
+	pending := &middleware.AuthUser{ID: 10, Role: models.RoleStudent, UniversityID: 1, MustChangePassword: true}
+
+	tests := []struct {
+		name   string
+		dir    Directory
+		caller *middleware.AuthUser
+		method string
+		path   string
+		want   int
+	}{
+		{"own record", fakeDirectory{}, student, "GET", "/students/10", http.StatusOK},
+		{"someone else's record", fakeDirectory{}, student, "GET", "/students/11", http.StatusForbidden},
+		{"role not allowed", fakeDirectory{}, lecturer, "GET", "/students/10", http.StatusForbidden},
+		{"method without policy", fakeDirectory{}, student, "DELETE", "/students/10", http.StatusForbidden},
+		{"route without policy", fakeDirectory{}, student, "GET", "/unlisted", http.StatusForbidden},
+		{"public without caller", fakeDirectory{}, nil, "GET", "/public", http.StatusOK},
+		{"protected without caller", fakeDirectory{}, nil, "GET", "/students/10", http.StatusForbidden},
+		{"invalid id left to handler", fakeDirectory{}, &middleware.AuthUser{ID: 30, Role: models.RoleAdvisor}, "GET", "/students/abc", http.StatusOK},
+		{"pending password change", fakeDirectory{}, pending, "GET", "/students/10", http.StatusForbidden},
+		{"password change while pending", fakeDirectory{}, pending, "POST", "/password", http.StatusOK},
+		{"public while pending", fakeDirectory{}, pending, "GET", "/public", http.StatusOK},
+		{"directory failure", fakeDirectory{err: errors.New("db error")}, &middleware.AuthUser{ID: 30, Role: models.RoleAdvisor}, "GET", "/students/10", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(policies, tt.dir, tt.caller, tt.method, tt.path); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCanAccessStudent(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller middleware.AuthUser
+		want   bool
+	}{
+		{"the student", middleware.AuthUser{ID: 10, Role: models.RoleStudent}, true},
+		{"another student", middleware.AuthUser{ID: 11, Role: models.RoleStudent}, false},
+		{"assigned advisor", middleware.AuthUser{ID: 30, Role: models.RoleAdvisor}, true},
+		{"other advisor", middleware.AuthUser{ID: 31, Role: models.RoleAdvisor}, false},
+		{"teaching lecturer", middleware.AuthUser{ID: 20, Role: models.RoleLecturer}, true},
+		{"other lecturer", middleware.AuthUser{ID: 21, Role: models.RoleLecturer}, false},
+		{"super admin", middleware.AuthUser{ID: 1, Role: models.RoleSuperAdmin}, true},
+		{"admin without university", middleware.AuthUser{ID: 4, Role: models.RoleAdmin}, false},
+		{"admin of the university", middleware.AuthUser{ID: 2, Role: models.RoleAdmin, UniversityID: 1}, true},
+		{"admin of another university", middleware.AuthUser{ID: 3, Role: models.RoleAdmin, UniversityID: 2}, false},
+		{"unknown role", middleware.AuthUser{ID: 10, Role: "guest"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := canAccessStudent(context.Background(), tt.caller, fakeDirectory{}, 10)
+			if (err == nil) != tt.want {
+				t.Errorf("expected access %v, got error %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSelfQuery(t *testing.T) {
+	rule := Roles(models.RoleAdmin, models.RoleAdvisor).SelfQuery("advisor_id", models.RoleAdvisor)
+	advisor := middleware.AuthUser{ID: 30, Role: models.RoleAdvisor}
+
+	for query, want := range map[string]bool{"?advisor_id=30": true, "?advisor_id=31": false, "": false} {
+		err := rule.Check(httptest.NewRequest("GET", "/alerts"+query, nil), advisor, fakeDirectory{})
+		if (err == nil) != want {
+			t.Errorf("%q: expected access %v, got error %v", query, want, err)
+		}
+	}
+	admin := middleware.AuthUser{ID: 1, Role: models.RoleAdmin}
+	if err := rule.Check(httptest.NewRequest("GET", "/alerts", nil), admin, fakeDirectory{}); err != nil {
+		t.Errorf("admin should not need the query parameter, got %v", err)
+	}
+}
+
+func TestSuperAdmin(t *testing.T) {
+	superAdmin := middleware.AuthUser{ID: 1, Role: models.RoleSuperAdmin}
+	admin := middleware.AuthUser{ID: 2, Role: models.RoleAdmin, UniversityID: 1}
+	req := httptest.NewRequest("GET", "/terms", nil)
+
+	if err := Roles(models.RoleAdmin).Check(req, superAdmin, fakeDirectory{}); err != nil {
+		t.Errorf("super admin should hold admin routes, got %v", err)
+	}
+	if err := Roles(models.RoleAdmin).Global().Check(req, superAdmin, fakeDirectory{}); err != nil {
+		t.Errorf("super admin should pass Global, got %v", err)
+	}
+	if err := Roles(models.RoleAdmin).Global().Check(req, admin, fakeDirectory{}); err == nil {
+		t.Error("admin bound to a university should not pass Global")
+	}
+	if err := Roles(models.RoleLecturer).Check(req, superAdmin, fakeDirectory{}); err == nil {
+		t.Error("super admin should not hold routes without the admin role")
+	}
+}
+
+func TestOwnUniversity(t *testing.T) {
+	rule := Roles(models.RoleAdmin, models.RoleLecturer).OwnUniversity("id")
+	for _, tt := range []struct {
+		name   string
+		caller middleware.AuthUser
+		want   bool
+	}{
+		{"super admin", middleware.AuthUser{ID: 1, Role: models.RoleSuperAdmin}, true},
+		{"admin of the university", middleware.AuthUser{ID: 2, Role: models.RoleAdmin, UniversityID: 1}, true},
+		{"admin of another university", middleware.AuthUser{ID: 3, Role: models.RoleAdmin, UniversityID: 2}, false},
+		{"lecturer of the university", middleware.AuthUser{ID: 20, Role: models.RoleLecturer, UniversityID: 1}, true},
+		{"admin without university", middleware.AuthUser{ID: 4, Role: models.RoleAdmin}, false},
+	} {
+		req := mux.SetURLVars(httptest.NewRequest("GET", "/university/1/fuzzy-config", nil), map[string]string{"id": "1"})
+		if err := rule.Check(req, tt.caller, fakeDirectory{}); (err == nil) != tt.want {
+			t.Errorf("%s: expected access %v, got error %v", tt.name, tt.want, err)
+		}
+	}
+}
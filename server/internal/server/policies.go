@@ -0,0 +1,126 @@
+package server
+
+import (
+	"net/http"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/policy"
+)
+
+const (
+	admin    = models.RoleAdmin
+	lecturer = models.RoleLecturer
+	advisor  = models.RoleAdvisor
+	student  = models.RoleStudent
+)
+
+// policies lists the rule guarding every route registered by RegisterRoutes.
+// A route missing here is denied for everyone.
+func policies() policy.Policies {
+	all := []string{admin, lecturer, advisor, student}
+	staff := []string{admin, lecturer, advisor}
+
+	return policy.Policies{
+		{Method: http.MethodGet, Path: "/health"}: policy.Public(),
+
+		// auth
+		{Method: http.MethodPost, Path: "/auth/login"}:           policy.Public(),
+		{Method: http.MethodPost, Path: "/auth/register"}:        policy.Public(),
+		{Method: http.MethodPost, Path: "/auth/refresh"}:         policy.Public(),
+		{Method: http.MethodPost, Path: "/auth/logout"}:          policy.Public(),
+		{Method: http.MethodPost, Path: "/auth/password"}:        policy.Roles(all...).AllowPendingPasswordChange(),
+		{Method: http.MethodPost, Path: "/auth/password/forgot"}: policy.Public(),
+		{Method: http.MethodPost, Path: "/auth/password/reset"}:  policy.Public(),
+
+		// datasets
+		{Method: http.MethodPost, Path: "/datasets/import"}: policy.Roles(admin),
+		{Method: http.MethodGet, Path: "/datasets"}:         policy.Roles(admin),
+
+		// fuzzy: mahasiswa hanya boleh melihat hasilnya sendiri
+		{Method: http.MethodGet, Path: "/fuzzy/{id}"}:                  policy.Roles(all...).OwnStudent("id"),
+		{Method: http.MethodGet, Path: "/fuzzy/{id}/hierarchical"}:     policy.Roles(all...).OwnStudent("id"),
+		{Method: http.MethodGet, Path: "/fuzzy/{id}/compare"}:          policy.Roles(all...).OwnStudent("id"),
+		{Method: http.MethodPost, Path: "/fuzzy/{id}/recommendations"}: policy.Roles(all...).OwnStudent("id"),
+
+		// academic
+		{Method: http.MethodPost, Path: "/academic"}:                                policy.Roles(admin),
+		{Method: http.MethodPut, Path: "/academic/{id}"}:                            policy.Roles(admin).OwnAcademic("id"),
+		{Method: http.MethodGet, Path: "/academic"}:                                 policy.Roles(admin),
+		{Method: http.MethodGet, Path: "/academic/student/{student_id}"}:            policy.Roles(all...).OwnStudent("student_id"),
+		{Method: http.MethodGet, Path: "/academic/student/{student_id}/trend"}:      policy.Roles(all...).OwnStudent("student_id"),
+		{Method: http.MethodGet, Path: "/academic/student/{student_id}/attendance"}: policy.Roles(all...).OwnStudent("student_id"),
+		{Method: http.MethodPost, Path: "/academic/student/{student_id}/recompute"}: policy.Roles(admin, lecturer).OwnStudent("student_id"),
+
+		// users
+		{Method: http.MethodPost, Path: "/users"}:                 policy.Roles(admin),
+		{Method: http.MethodGet, Path: "/users"}:                  policy.Roles(admin),
+		{Method: http.MethodGet, Path: "/users/{id}"}:             policy.Roles(all...).OwnUser("id"),
+		{Method: http.MethodPut, Path: "/users/{id}"}:             policy.Roles(admin).OwnUser("id"),
+		{Method: http.MethodDelete, Path: "/users/{id}"}:          policy.Roles(admin).OwnUser("id"),
+		{Method: http.MethodDelete, Path: "/users/{id}/sessions"}: policy.Roles(admin).OwnUser("id"),
+
+		// university
+		{Method: http.MethodGet, Path: "/university"}:      policy.Public(),
+		{Method: http.MethodGet, Path: "/university/{id}"}: policy.Roles(all...),
+		// pengelolaan universitas hanya untuk admin seluruh universitas
+		{Method: http.MethodPost, Path: "/university"}:            policy.Roles(admin).Global(),
+		{Method: http.MethodGet, Path: "/universities"}:           policy.Roles(admin).Global(),
+		{Method: http.MethodPut, Path: "/university/{id}"}:        policy.Roles(admin).Global(),
+		{Method: http.MethodDelete, Path: "/university/{id}"}:     policy.Roles(admin).Global(),
+		{Method: http.MethodPost, Path: "/university/{id}/merge"}: policy.Roles(admin).Global(),
+		// model fuzzy dikelola oleh admin universitas itu sendiri
+		{Method: http.MethodGet, Path: "/university/{id}/fuzzy-config"}:    policy.Roles(admin, lecturer).OwnUniversity("id"),
+		{Method: http.MethodPut, Path: "/university/{id}/fuzzy-config"}:    policy.Roles(admin).OwnUniversity("id"),
+		{Method: http.MethodDelete, Path: "/university/{id}/fuzzy-config"}: policy.Roles(admin).OwnUniversity("id"),
+
+		// terms berlaku untuk semua universitas
+		{Method: http.MethodPost, Path: "/terms"}:        policy.Roles(admin).Global(),
+		{Method: http.MethodGet, Path: "/terms"}:         policy.Roles(all...),
+		{Method: http.MethodGet, Path: "/terms/{id}"}:    policy.Roles(all...),
+		{Method: http.MethodPut, Path: "/terms/{id}"}:    policy.Roles(admin).Global(),
+		{Method: http.MethodDelete, Path: "/terms/{id}"}: policy.Roles(admin).Global(),
+
+		// alerts: dosen wali hanya melihat mahasiswa bimbingannya
+		{Method: http.MethodGet, Path: "/alerts"}:           policy.Roles(admin, advisor, student).SelfQuery("advisor_id", advisor).SelfQuery("user_id", student),
+		{Method: http.MethodPost, Path: "/alerts/evaluate"}: policy.Roles(admin),
+		{Method: http.MethodGet, Path: "/alerts/{id}"}:      policy.Roles(admin, advisor, student).OwnAlert("id"),
+		{Method: http.MethodPut, Path: "/alerts/{id}"}:      policy.Roles(admin, advisor).OwnAlert("id"),
+
+		// webhooks
+		{Method: http.MethodPost, Path: "/webhooks"}:                policy.Roles(admin).Global(),
+		{Method: http.MethodGet, Path: "/webhooks"}:                 policy.Roles(admin).Global(),
+		{Method: http.MethodGet, Path: "/webhooks/{id}"}:            policy.Roles(admin).Global(),
+		{Method: http.MethodPut, Path: "/webhooks/{id}"}:            policy.Roles(admin).Global(),
+		{Method: http.MethodDelete, Path: "/webhooks/{id}"}:         policy.Roles(admin).Global(),
+		{Method: http.MethodGet, Path: "/webhooks/{id}/deliveries"}: policy.Roles(admin).Global(),
+		{Method: http.MethodPost, Path: "/webhooks/{id}/test"}:      policy.Roles(admin).Global(),
+
+		// notifications
+		{Method: http.MethodGet, Path: "/notifications"}:                       policy.Roles(admin).Global(),
+		{Method: http.MethodGet, Path: "/users/{id}/notification-preferences"}: policy.Roles(all...).OwnUser("id"),
+		{Method: http.MethodPut, Path: "/users/{id}/notification-preferences"}: policy.Roles(all...).OwnUser("id"),
+
+		// courses
+		{Method: http.MethodPost, Path: "/courses"}:                               policy.Roles(admin),
+		{Method: http.MethodGet, Path: "/courses"}:                                policy.Roles(all...),
+		{Method: http.MethodGet, Path: "/courses/{id}"}:                           policy.Roles(all...).OwnCourse("id"),
+		{Method: http.MethodPut, Path: "/courses/{id}"}:                           policy.Roles(admin).OwnCourse("id"),
+		{Method: http.MethodPost, Path: "/courses/{id}/enrollments"}:              policy.Roles(admin, lecturer).OwnCourse("id"),
+		{Method: http.MethodGet, Path: "/courses/{id}/enrollments"}:               policy.Roles(admin, lecturer).OwnCourse("id"),
+		{Method: http.MethodPut, Path: "/enrollments/{id}/grades/{component}"}:    policy.Roles(admin, lecturer).OwnEnrollment("id"),
+		{Method: http.MethodDelete, Path: "/enrollments/{id}/grades/{component}"}: policy.Roles(admin, lecturer).OwnEnrollment("id"),
+		{Method: http.MethodPost, Path: "/courses/{id}/sessions"}:                 policy.Roles(admin, lecturer).OwnCourse("id"),
+		{Method: http.MethodGet, Path: "/courses/{id}/sessions"}:                  policy.Roles(admin, lecturer).OwnCourse("id"),
+		{Method: http.MethodPost, Path: "/sessions/{id}/attendance"}:              policy.Roles(admin, lecturer).OwnSession("id"),
+		{Method: http.MethodGet, Path: "/sessions/{id}/attendance"}:               policy.Roles(admin, lecturer).OwnSession("id"),
+
+		// audit log mencakup semua universitas
+		{Method: http.MethodGet, Path: "/audit"}:        policy.Roles(admin).Global(),
+		{Method: http.MethodGet, Path: "/audit/verify"}: policy.Roles(admin).Global(),
+
+		// analytics
+		{Method: http.MethodGet, Path: "/analytics/summary"}:     policy.Roles(staff...),
+		{Method: http.MethodGet, Path: "/analytics/histogram"}:   policy.Roles(staff...),
+		{Method: http.MethodGet, Path: "/analytics/correlation"}: policy.Roles(staff...),
+		{Method: http.MethodGet, Path: "/analytics/compare"}:     policy.Roles(staff...),
+	}
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"tsukamoto/config"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+	"tsukamoto/internal/policy"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+type fakeDatabase struct{}
+
+func (fakeDatabase) Health() map[string]string { return nil }
+func (fakeDatabase) Close() error              { return nil }
+func (fakeDatabase) GetDB() *gorm.DB           { return nil }
+
+// Student 10 belongs to university 1 with advisor 30 and attends course
+// 300 taught by lecturer 20. Student 11 belongs to university 2.
+type fakeDirectory struct{}
+
+func (fakeDirectory) Student(ctx context.Context, id uint) (policy.Student, error) {
+	if id == 11 {
+		return policy.Student{ID: 11, UniversityID: 2, AdvisorID: 31}, nil
+	}
+	return policy.Student{ID: id, UniversityID: 1, AdvisorID: 30}, nil
+}
+
+func (d fakeDirectory) User(ctx context.Context, id uint) (policy.User, error) {
+	owner, err := d.Student(ctx, id)
+	return policy.User{ID: id, Role: student, UniversityID: owner.UniversityID}, err
+}
+
+func (fakeDirectory) AcademicOwner(ctx context.Context, academicID int) (uint, error) {
+	return 10, nil
+}
+
+func (fakeDirectory) AlertOwner(ctx context.Context, alertID int) (uint, error) {
+	return 10, nil
+}
+
+func (fakeDirectory) Course(ctx context.Context, id int) (policy.Course, error) {
+	return policy.Course{ID: id, UniversityID: 1, LecturerID: 20}, nil
+}
+
+func (d fakeDirectory) EnrollmentCourse(ctx context.Context, enrollmentID int) (policy.Course, error) {
+	return d.Course(ctx, 300)
+}
+
+func (d fakeDirectory) SessionCourse(ctx context.Context, sessionID int) (policy.Course, error) {
+	return d.Course(ctx, 300)
+}
+
+func (fakeDirectory) Teaches(ctx context.Context, lecturerID, studentID uint) (bool, error) {
+	return lecturerID == 20 && studentID == 10, nil
+}
+
+var callers = map[string]middleware.AuthUser{
+	"super-admin":      {ID: 1, Role: models.RoleSuperAdmin},
+	"university-admin": {ID: 2, Role: admin, UniversityID: 1},
+	"other-admin":      {ID: 3, Role: admin, UniversityID: 2},
+	"lecturer":         {ID: 20, Role: lecturer, UniversityID: 1},
+	"advisor":          {ID: 30, Role: advisor, UniversityID: 1},
+	"student":          {ID: 10, Role: student, UniversityID: 1},
+	"other-student":    {ID: 11, Role: student, UniversityID: 2},
+}
+
+func TestEveryRouteHasPolicy(t *testing.T) {
+	s := &Server{db: fakeDatabase{}, cfg: &config.Config{}, events: events.NewBus()}
+	table := policies()
+	registered := map[policy.Route]bool{}
+
+	err := s.router().Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Route tanpa batasan method, misalnya /health
+			methods = []string{http.MethodGet}
+		}
+		for _, method := range methods {
+			key := policy.Route{Method: method, Path: path}
+			registered[key] = true
+			if _, ok := table[key]; !ok {
+				t.Errorf("%s %s has no policy", method, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := range table {
+		if !registered[key] {
+			t.Errorf("policy for %s %s matches no route", key.Method, key.Path)
+		}
+	}
+}
+
+func TestRoutePolicies(t *testing.T) {
+	all := "super-admin university-admin other-admin lecturer advisor student other-student"
+	admins := "super-admin university-admin other-admin"
+	studentData := "super-admin university-admin lecturer advisor student"
+
+	tests := []struct {
+		method  string
+		path    string
+		allowed string
+	}{
+		{"POST", "/auth/password", all},
+
+		{"POST", "/datasets/import", admins},
+		{"GET", "/datasets", admins},
+
+		{"GET", "/fuzzy/10", studentData},
+		{"GET", "/fuzzy/10/hierarchical", studentData},
+		{"GET", "/fuzzy/10/compare", studentData},
+		{"POST", "/fuzzy/10/recommendations", studentData},
+
+		{"POST", "/academic", admins},
+		{"PUT", "/academic/100", "super-admin university-admin"},
+		{"GET", "/academic", admins},
+		{"GET", "/academic/student/10", studentData},
+		{"GET", "/academic/student/10/trend", studentData},
+		{"GET", "/academic/student/10/attendance", studentData},
+		{"POST", "/academic/student/10/recompute", "super-admin university-admin lecturer"},
+
+		{"POST", "/users", admins},
+		{"GET", "/users", admins},
+		{"GET", "/users/10", "super-admin university-admin student"},
+		{"PUT", "/users/10", "super-admin university-admin"},
+		{"DELETE", "/users/10", "super-admin university-admin"},
+		{"DELETE", "/users/10/sessions", "super-admin university-admin"},
+
+		{"GET", "/university/1", all},
+		{"POST", "/university", "super-admin"},
+		{"GET", "/universities", "super-admin"},
+		{"PUT", "/university/1", "super-admin"},
+		{"DELETE", "/university/1", "super-admin"},
+		{"POST", "/university/1/merge", "super-admin"},
+		{"GET", "/university/1/fuzzy-config", "super-admin university-admin lecturer"},
+		{"PUT", "/university/1/fuzzy-config", "super-admin university-admin"},
+		{"DELETE", "/university/1/fuzzy-config", "super-admin university-admin"},
+
+		{"POST", "/terms", "super-admin"},
+		{"GET", "/terms", all},
+		{"GET", "/terms/1", all},
+		{"PUT", "/terms/1", "super-admin"},
+		{"DELETE", "/terms/1", "super-admin"},
+
+		{"GET", "/alerts", admins},
+		{"GET", "/alerts?advisor_id=30", admins + " advisor"},
+		{"GET", "/alerts?user_id=10", admins + " student"},
+		{"POST", "/alerts/evaluate", admins},
+		{"GET", "/alerts/200", "super-admin university-admin advisor student"},
+		{"PUT", "/alerts/200", "super-admin university-admin advisor"},
+
+		{"POST", "/webhooks", "super-admin"},
+		{"GET", "/webhooks", "super-admin"},
+		{"GET", "/webhooks/1", "super-admin"},
+		{"PUT", "/webhooks/1", "super-admin"},
+		{"DELETE", "/webhooks/1", "super-admin"},
+		{"GET", "/webhooks/1/deliveries", "super-admin"},
+		{"POST", "/webhooks/1/test", "super-admin"},
+
+		{"GET", "/notifications", "super-admin"},
+		{"GET", "/users/10/notification-preferences", "super-admin university-admin student"},
+		{"PUT", "/users/10/notification-preferences", "super-admin university-admin student"},
+
+		{"POST", "/courses", admins},
+		{"GET", "/courses", all},
+		{"GET", "/courses/300", "super-admin university-admin lecturer advisor student"},
+		{"PUT", "/courses/300", "super-admin university-admin"},
+		{"POST", "/courses/300/enrollments", "super-admin university-admin lecturer"},
+		{"GET", "/courses/300/enrollments", "super-admin university-admin lecturer"},
+		{"PUT", "/enrollments/400/grades/midterm", "super-admin university-admin lecturer"},
+		{"DELETE", "/enrollments/400/grades/midterm", "super-admin university-admin lecturer"},
+		{"POST", "/courses/300/sessions", "super-admin university-admin lecturer"},
+		{"GET", "/courses/300/sessions", "super-admin university-admin lecturer"},
+		{"POST", "/sessions/500/attendance", "super-admin university-admin lecturer"},
+		{"GET", "/sessions/500/attendance", "super-admin university-admin lecturer"},
+
+		{"GET", "/audit", "super-admin"},
+		{"GET", "/audit/verify", "super-admin"},
+
+		{"GET", "/analytics/summary", admins + " lecturer advisor"},
+		{"GET", "/analytics/histogram", admins + " lecturer advisor"},
+		{"GET", "/analytics/correlation", admins + " lecturer advisor"},
+		{"GET", "/analytics/compare", admins + " lecturer advisor"},
+	}
+
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			caller := callers[r.Header.Get("X-Caller")]
+			next.ServeHTTP(w, r.WithContext(middleware.WithUser(r.Context(), caller)))
+		})
+	})
+	router.Use(policy.Authorize(policies(), fakeDirectory{}))
+	for route := range policies() {
+		router.HandleFunc(route.Path, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}).Methods(route.Method)
+	}
+
+	covered := map[policy.Route]bool{}
+	names := make([]string, 0, len(callers))
+	for name := range callers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, tt := range tests {
+		allowed := map[string]bool{}
+		for _, name := range strings.Fields(tt.allowed) {
+			allowed[name] = true
+		}
+		for _, name := range names {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("X-Caller", name)
+			var match mux.RouteMatch
+			if router.Match(req, &match) {
+				template, _ := match.Route.GetPathTemplate()
+				covered[policy.Route{Method: tt.method, Path: template}] = true
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			want := http.StatusForbidden
+			if allowed[name] {
+				want = http.StatusOK
+			}
+			if w.Code != want {
+				t.Errorf("%s %s as %s: expected %d, got %d", tt.method, tt.path, name, want, w.Code)
+			}
+		}
+	}
+
+	for route, rule := range policies() {
+		if !covered[route] && rule.Check(httptest.NewRequest(route.Method, route.Path, nil), middleware.AuthUser{}, fakeDirectory{}) != nil {
+			t.Errorf("%s %s is not covered by the test table", route.Method, route.Path)
+		}
+	}
+}
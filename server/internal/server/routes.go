@@ -3,40 +3,89 @@ package server
 import (
 	"net/http"
 
+	"tsukamoto/internal/audit"
 	"tsukamoto/internal/domain/academic"
+	"tsukamoto/internal/domain/alerts"
+	"tsukamoto/internal/domain/analytics"
+	"tsukamoto/internal/domain/auditlog"
 	"tsukamoto/internal/domain/auth"
+	"tsukamoto/internal/domain/courses"
 	"tsukamoto/internal/domain/datasets"
 	"tsukamoto/internal/domain/fuzzy"
+	"tsukamoto/internal/domain/notifications"
+	"tsukamoto/internal/domain/terms"
 	"tsukamoto/internal/domain/university"
 	"tsukamoto/internal/domain/users"
+	"tsukamoto/internal/domain/webhooks"
 	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/policy"
 	"tsukamoto/internal/utils"
 
 	"github.com/gorilla/mux"
 )
 
 func (s *Server) RegisterRoutes() http.Handler {
+	// Wrap all routes with CORS middleware
+	return middleware.CORSMiddleware(s.router())
+}
+
+func (s *Server) router() *mux.Router {
 	r := mux.NewRouter()
+	auditLog := audit.NewLog(auditlog.NewAuditRepository(s.db.GetDB()))
+	sessions := auth.NewSessionService(auth.NewAuthRepository(s.db.GetDB()), s.tokens, auth.RefreshExpireFromConfig(s.cfg))
+
+	// Semua route wajib membawa token kecuali yang dibutuhkan sebelum login
+	r.Use(middleware.Authenticate(middleware.JWTValidator(s.tokens, sessions),
+		middleware.PublicRoute{Path: "/health"},
+		middleware.PublicRoute{Method: http.MethodPost, Path: "/auth/login"},
+		middleware.PublicRoute{Method: http.MethodPost, Path: "/auth/register"},
+		middleware.PublicRoute{Method: http.MethodPost, Path: "/auth/refresh"},
+		middleware.PublicRoute{Method: http.MethodPost, Path: "/auth/logout"},
+		middleware.PublicRoute{Method: http.MethodPost, Path: "/auth/password/forgot"},
+		middleware.PublicRoute{Method: http.MethodPost, Path: "/auth/password/reset"},
+		middleware.PublicRoute{Method: http.MethodGet, Path: "/university"},
+	))
+	// Setelah identitas diketahui, cek peran dan kepemilikan data per route
+	r.Use(policy.Authorize(policies(), policy.NewDirectory(s.db.GetDB())))
 
 	// Health check route
 	r.HandleFunc("/health", s.healthHandler)
 
 	// datasets routes
-	datasets.DatasetsRoute(r, s.db.GetDB())
+	datasets.DatasetsRoute(r, s.db.GetDB(), s.events, s.cfg, auditLog)
 
 	// fuzzy routes
-	fuzzy.FuzzyRoute(r, s.db.GetDB())
+	fuzzy.FuzzyRoute(r, s.db.GetDB(), s.events, auditLog)
 
-	academic.AcademicRoute(r, s.db.GetDB())
+	academic.AcademicRoute(r, s.db.GetDB(), s.events, auditLog)
 
-	users.UserRoute(r, s.db.GetDB())
+	users.UserRoute(r, s.db.GetDB(), sessions, utils.PasswordPolicyFromConfig(s.cfg), auditLog)
 
-	auth.AuthRoute(r, s.db.GetDB())
+	auth.AuthRoute(r, s.db.GetDB(), sessions, s.cfg)
 
-	university.UniversityRoute(r, s.db.GetDB())
+	university.UniversityRoute(r, s.db.GetDB(), auditLog)
 
-	// Wrap all routes with CORS middleware
-	return middleware.CORSMiddleware(r)
+	terms.TermRoute(r, s.db.GetDB())
+
+	// early-warning routes
+	alerts.AlertRoute(r, s.db.GetDB(), s.events, s.cfg)
+
+	// outbound webhook routes
+	webhooks.WebhookRoute(r, s.db.GetDB(), s.events, s.cfg)
+
+	// email notification routes
+	notifications.NotificationRoute(r, s.db.GetDB(), s.events, s.cfg)
+
+	// course, enrollment and grade routes
+	courses.CourseRoute(r, s.db.GetDB(), s.events, s.cfg, auditLog)
+
+	// audit log routes
+	auditlog.AuditRoute(r, s.db.GetDB())
+
+	// cohort analytics routes
+	analytics.AnalyticsRoute(r, s.db.GetDB())
+
+	return r
 }
 
 func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
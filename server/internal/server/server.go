@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strconv"
@@ -9,21 +10,38 @@ import (
 
 	_ "github.com/joho/godotenv/autoload"
 
+	"tsukamoto/config"
 	"tsukamoto/internal/database"
+	"tsukamoto/internal/events"
+	"tsukamoto/internal/utils"
 )
 
 type Server struct {
 	port int
 
-	db database.Service
+	db     database.Service
+	cfg    *config.Config
+	events *events.Bus
+	tokens *utils.JWTUtil
 }
 
 func NewServer() *http.Server {
 	port, _ := strconv.Atoi(os.Getenv("PORT"))
+	cfg := config.LoadConfig()
+
+	// Tanpa kunci penandatangan yang valid server tidak boleh berjalan
+	tokens, err := utils.NewJWTUtil(cfg)
+	if err != nil {
+		log.Fatalf("invalid JWT configuration: %v", err)
+	}
+
 	NewServer := &Server{
 		port: port,
 
-		db: database.New(),
+		db:     database.New(),
+		cfg:    cfg,
+		events: events.NewBus(),
+		tokens: tokens,
 	}
 
 	// Declare Server config
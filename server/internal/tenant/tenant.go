@@ -0,0 +1,78 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrOutsideScope is returned by repositories asked to write data belonging
+// to a university the caller cannot reach
+var ErrOutsideScope = errors.New("university outside the caller's scope")
+
+// Scope is the set of universities a caller may read and change. Every
+// caller is bound to their own university except super admins.
+type Scope struct {
+	UniversityID uint
+	All          bool
+}
+
+type systemKey struct{}
+
+// WithSystem marks the context as work of the system itself, such as a
+// background job, which reaches every university when no caller is set
+func WithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+// FromContext returns the scope of the authenticated caller. Super admins and
+// contexts marked with WithSystem reach every university. Any other context
+// without a caller, like a bound caller without a university, reaches none.
+func FromContext(ctx context.Context) Scope {
+	caller, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		if system, _ := ctx.Value(systemKey{}).(bool); system {
+			return Scope{All: true}
+		}
+		return Scope{}
+	}
+	if caller.Role == models.RoleSuperAdmin {
+		return Scope{All: true}
+	}
+	return Scope{UniversityID: caller.UniversityID}
+}
+
+// Allows reports whether data of the university is inside the scope
+func (s Scope) Allows(universityID uint) bool {
+	return s.All || (s.UniversityID != 0 && s.UniversityID == universityID)
+}
+
+// Filter returns a GORM scope limiting a query to the scope using the
+// university column of the queried table
+func (s Scope) Filter(column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if s.All {
+			return db
+		}
+		return db.Where(column+" = ?", s.UniversityID)
+	}
+}
+
+// FilterUsers returns a GORM scope limiting a query to rows whose user column
+// points at a user of the scope
+func (s Scope) FilterUsers(column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if s.All {
+			return db
+		}
+		return db.Where(column+" IN (SELECT id FROM users WHERE university_id = ?)", s.UniversityID)
+	}
+}
+
+// Scoped is shorthand for FromContext(ctx).Filter(column)
+func Scoped(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return FromContext(ctx).Filter(column)
+}
@@ -0,0 +1,64 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+	"tsukamoto/internal/middleware"
+	"tsukamoto/internal/models"
+)
+
+func TestFromContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller *middleware.AuthUser
+		system bool
+		want   Scope
+	}{
+		// Tanpa pemanggil dan tanpa WithSystem tidak ada universitas yang terjangkau
+		{"no caller", nil, false, Scope{}},
+		{"system", nil, true, Scope{All: true}},
+		{"super admin", &middleware.AuthUser{ID: 1, Role: models.RoleSuperAdmin}, false, Scope{All: true}},
+		{"admin", &middleware.AuthUser{ID: 2, Role: models.RoleAdmin, UniversityID: 3}, false, Scope{UniversityID: 3}},
+		// Pemanggil tetap dibatasi walaupun konteksnya ditandai sistem
+		{"admin in system context", &middleware.AuthUser{ID: 2, Role: models.RoleAdmin, UniversityID: 3}, true, Scope{UniversityID: 3}},
+		{"student", &middleware.AuthUser{ID: 4, Role: models.RoleStudent, UniversityID: 5}, false, Scope{UniversityID: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.system {
+				ctx = WithSystem(ctx)
+			}
+			if tt.caller != nil {
+				ctx = middleware.WithUser(ctx, *tt.caller)
+			}
+			if got := FromContext(ctx); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestScopeAllows(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope Scope
+		id    uint
+		want  bool
+	}{
+		{"all universities", Scope{All: true}, 7, true},
+		{"own university", Scope{UniversityID: 3}, 3, true},
+		{"other university", Scope{UniversityID: 3}, 4, false},
+		// Pengguna tanpa universitas tidak boleh menjangkau data tanpa universitas
+		{"no university", Scope{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.Allows(tt.id); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,95 +1,317 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"tsukamoto/config"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/sirupsen/logrus"
 )
 
+const (
+	defaultJWTExpire   = 15 * time.Minute
+	defaultJWTIssuer   = "tsukamoto"
+	defaultJWTAudience = "tsukamoto-api"
+)
+
+var (
+	// ErrMissingJWTSecret is returned outside development when no signing key is configured
+	ErrMissingJWTSecret = errors.New("JWT_SECRET or JWT_PRIVATE_KEY_FILE must be set outside development")
+	// ErrUnknownKey is returned for a token signed with a key ID the service does not know
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+// Claims are the claims carried by an access token
+type Claims struct {
+	UserID             int    `json:"user_id"`
+	Username           string `json:"username"`
+	Role               string `json:"role"`
+	UniversityID       int    `json:"university_id"`
+	UniversityName     string `json:"university_name,omitempty"`
+	SessionID          string `json:"sid,omitempty"`
+	MustChangePassword bool   `json:"pwd_change,omitempty"` // Token hanya untuk mengganti password
+	jwt.RegisteredClaims
+}
+
+// signingKey is a key identified by its kid. Previous keys only carry the
+// verification half.
+type signingKey struct {
+	id     string
+	method jwt.SigningMethod
+	sign   interface{}
+	verify interface{}
+}
+
+// JWTUtil issues and validates access tokens with the key configured in
+// config.Config. Tokens signed by a previous key stay valid until they expire
+// as long as that key is listed in JWT_PREVIOUS_KEYS.
 type JWTUtil struct {
-	secret    string
-	expire    time.Duration
-	algorithm string
+	current  signingKey
+	keys     map[string]signingKey
+	expire   time.Duration
+	issuer   string
+	audience string
+	now      func() time.Time
 }
 
-// NewJWTUtil creates a new instance of JWTUtil with the given configuration.
-func NewJWTUtil(cfg *config.Config) *JWTUtil {
-	expireDuration, err := time.ParseDuration(cfg.JWTExpire)
+// NewJWTUtil builds the token service from the configuration. Outside
+// development it refuses to run without a configured key; in development a
+// random secret is generated for the lifetime of the process.
+func NewJWTUtil(cfg *config.Config) (*JWTUtil, error) {
+	expire := defaultJWTExpire
+	if cfg.JWTExpire != "" {
+		value, err := time.ParseDuration(cfg.JWTExpire)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("invalid JWT_EXPIRE %q", cfg.JWTExpire)
+		}
+		expire = value
+	}
+
+	algorithm := cfg.JWTAlgorithm
+	if algorithm == "" {
+		algorithm = jwt.SigningMethodHS256.Alg()
+	}
+
+	current, err := loadSigningKey(cfg, algorithm)
 	if err != nil {
-		expireDuration = 24 * time.Hour // Default to 24 hours if parsing fails
+		return nil, err
 	}
 
-	return &JWTUtil{
-		secret:    cfg.JWTSecret,
-		expire:    expireDuration,
-		algorithm: cfg.JWTAlgorithm,
+	j := &JWTUtil{
+		current:  current,
+		keys:     map[string]signingKey{current.id: current},
+		expire:   expire,
+		issuer:   valueOr(cfg.JWTIssuer, defaultJWTIssuer),
+		audience: valueOr(cfg.JWTAudience, defaultJWTAudience),
+		now:      time.Now,
 	}
+
+	for _, entry := range strings.Split(cfg.JWTPreviousKeys, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		key, err := parsePreviousKey(strings.TrimSpace(entry))
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := j.keys[key.id]; exists {
+			return nil, fmt.Errorf("duplicate JWT key id %q", key.id)
+		}
+		j.keys[key.id] = key
+	}
+	return j, nil
 }
 
-type Claims struct {
-	ID   int    `json:"user_id"`
-	Name string `json:"name"`
-	Nim  string `json:"nim"`
-	Role string `json:"role"`
-	jwt.RegisteredClaims
+// IsDevelopment reports whether APP_ENV names a local development setup. An
+// unset APP_ENV counts as production, so a deploy that forgets it still
+// refuses to start without a signing key.
+func IsDevelopment(cfg *config.Config) bool {
+	switch strings.ToLower(strings.TrimSpace(cfg.AppEnv)) {
+	case "local", "dev", "development", "test":
+		return true
+	}
+	return false
 }
 
-// GenerateJWT generates a new JWT token for a user.
-func (j *JWTUtil) GenerateJWT(id int, name, nim, role string) (string, error) {
-	claims := &Claims{
-		ID:   id,
-		Name: name,
-		Nim:  nim,
-		Role: role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expire)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.GetSigningMethod(j.algorithm), claims)
-	return token.SignedString([]byte(j.secret))
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
 }
 
-// ValidateJWT validates a JWT token and returns the claims if valid.
-func (j *JWTUtil) ValidateJWT(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secret), nil
-	})
-	if err != nil {
-		return nil, err
+func loadSigningKey(cfg *config.Config, algorithm string) (signingKey, error) {
+	key := signingKey{method: jwt.GetSigningMethod(algorithm)}
+
+	switch algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		secret := []byte(cfg.JWTSecret)
+		if len(secret) == 0 {
+			if !IsDevelopment(cfg) {
+				return signingKey{}, ErrMissingJWTSecret
+			}
+			// Token dari secret acak tidak bertahan setelah restart
+			secret = make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return signingKey{}, err
+			}
+			logrus.Warn("JWT_SECRET is not set: using a random secret, tokens will not survive a restart")
+		}
+		key.sign, key.verify = secret, secret
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg():
+		if cfg.JWTPrivateKeyFile == "" {
+			return signingKey{}, fmt.Errorf("JWT_PRIVATE_KEY_FILE is required for %s", algorithm)
+		}
+		pem, err := os.ReadFile(cfg.JWTPrivateKeyFile)
+		if err != nil {
+			return signingKey{}, fmt.Errorf("read JWT private key: %w", err)
+		}
+		if algorithm == jwt.SigningMethodRS256.Alg() {
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+			if err != nil {
+				return signingKey{}, fmt.Errorf("parse JWT private key: %w", err)
+			}
+			key.sign, key.verify = private, &private.PublicKey
+		} else {
+			private, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+			if err != nil {
+				return signingKey{}, fmt.Errorf("parse JWT private key: %w", err)
+			}
+			key.sign, key.verify = private, private.(ed25519.PrivateKey).Public()
+		}
+	default:
+		return signingKey{}, fmt.Errorf("unsupported JWT_ALGORITHM %q: use HS256, RS256 or EdDSA", algorithm)
 	}
 
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
-		return nil, jwt.ErrSignatureInvalid
+	key.id = cfg.JWTKeyID
+	if key.id == "" {
+		id, err := thumbprint(key.verify)
+		if err != nil {
+			return signingKey{}, err
+		}
+		key.id = id
+	}
+	return key, nil
+}
+
+// parsePreviousKey reads a "kid:algorithm:value" entry where value is the
+// secret for HS256 or the path of a PEM key file otherwise
+func parsePreviousKey(entry string) (signingKey, error) {
+	parts := strings.SplitN(entry, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return signingKey{}, fmt.Errorf("invalid JWT_PREVIOUS_KEYS entry %q: expected kid:algorithm:value", entry)
 	}
+	key := signingKey{id: parts[0], method: jwt.GetSigningMethod(parts[1])}
 
-	return claims, nil
+	switch parts[1] {
+	case jwt.SigningMethodHS256.Alg():
+		key.verify = []byte(parts[2])
+		return key, nil
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg():
+		pem, err := os.ReadFile(parts[2])
+		if err != nil {
+			return signingKey{}, fmt.Errorf("read previous JWT key %q: %w", key.id, err)
+		}
+		key.verify, err = parsePublicKey(parts[1], pem)
+		if err != nil {
+			return signingKey{}, fmt.Errorf("parse previous JWT key %q: %w", key.id, err)
+		}
+		return key, nil
+	}
+	return signingKey{}, fmt.Errorf("unsupported algorithm %q for previous JWT key %q", parts[1], key.id)
 }
 
-var jwtKey = []byte("your-secret-key") // In production, use env variable
+// parsePublicKey accepts either the public key or the retired private key
+func parsePublicKey(algorithm string, pem []byte) (interface{}, error) {
+	if algorithm == jwt.SigningMethodRS256.Alg() {
+		if public, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+			return public, nil
+		}
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		return &private.PublicKey, nil
+	}
+	if public, err := jwt.ParseEdPublicKeyFromPEM(pem); err == nil {
+		return public, nil
+	}
+	private, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, err
+	}
+	return private.(crypto.Signer).Public(), nil
+}
 
-// GenerateJWT generates a new JWT token using the default HS256 method.
-func GenerateJWT(userID int, username string, role string, universityID int, universityName string) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+// thumbprint derives a stable key ID from the verification key
+func thumbprint(key interface{}) (string, error) {
+	var material []byte
+	switch k := key.(type) {
+	case []byte:
+		material = k
+	case *rsa.PublicKey, ed25519.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return "", err
+		}
+		material = der
+	default:
+		return "", fmt.Errorf("unsupported key type %T", key)
+	}
+	sum := sha256.Sum256(material)
+	return hex.EncodeToString(sum[:8]), nil
+}
 
-	claims["user_id"] = userID
-	claims["username"] = username
-	claims["role"] = role
-	claims["university_id"] = universityID
-	claims["university_name"] = universityName
-	claims["exp"] = time.Now().Add(24 * time.Hour).Unix()
+// Expire returns the lifetime of issued tokens
+func (j *JWTUtil) Expire() time.Duration {
+	return j.expire
+}
+
+// GenerateJWT signs the claims with the current key, filling in the issuer,
+// audience, issue time and expiry.
+func (j *JWTUtil) GenerateJWT(claims Claims) (string, error) {
+	now := j.now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Issuer:    j.issuer,
+		Audience:  jwt.ClaimStrings{j.audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(j.expire)),
+	}
 
-	return token.SignedString(jwtKey)
+	token := jwt.NewWithClaims(j.current.method, claims)
+	token.Header["kid"] = j.current.id
+	return token.SignedString(j.current.sign)
 }
 
-// ValidateToken parses and validates the JWT token using the default HS256 method.
-func ValidateToken(tokenString string) (*jwt.Token, error) {
-	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return jwtKey, nil
+// ValidateJWT verifies the signature against the key named by the kid
+// header, then the expiry, issuer and audience.
+func (j *JWTUtil) ValidateJWT(tokenString string) (*Claims, error) {
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+	token, err := parser.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := j.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		// Algoritma harus sesuai kunci agar token HS256 tidak bisa memakai kunci publik
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return key.verify, nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	now := j.now()
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, jwt.ErrTokenExpired
+	}
+	if !claims.VerifyIssuedAt(now, false) || !claims.VerifyNotBefore(now, false) {
+		return nil, jwt.ErrTokenNotValidYet
+	}
+	if !claims.VerifyIssuer(j.issuer, true) {
+		return nil, jwt.ErrTokenInvalidIssuer
+	}
+	if !claims.VerifyAudience(j.audience, true) {
+		return nil, jwt.ErrTokenInvalidAudience
+	}
+	return claims, nil
 }
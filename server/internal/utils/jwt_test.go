@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tsukamoto/config"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var testClaims = Claims{UserID: 7, Username: "budi", Role: "student", UniversityID: 3}
+
+func newTokens(t *testing.T, cfg *config.Config) *JWTUtil {
+	t.Helper()
+	tokens, err := NewJWTUtil(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJWTUtil_RoundTrip(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edKey)
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+	}{
+		{"HS256", &config.Config{JWTSecret: "secret"}},
+		{"RS256", &config.Config{JWTAlgorithm: "RS256", JWTPrivateKeyFile: writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))}},
+		{"EdDSA", &config.Config{JWTAlgorithm: "EdDSA", JWTPrivateKeyFile: writePEM(t, "PRIVATE KEY", edDER)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := newTokens(t, tt.cfg)
+			token, err := tokens.GenerateJWT(testClaims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			claims, err := tokens.ValidateJWT(token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.UserID != 7 || claims.Role != "student" || claims.UniversityID != 3 {
+				t.Errorf("unexpected claims %+v", claims)
+			}
+			if claims.Issuer != defaultJWTIssuer || !claims.VerifyAudience(defaultJWTAudience, true) {
+				t.Errorf("expected default issuer and audience, got %q %v", claims.Issuer, claims.Audience)
+			}
+		})
+	}
+}
+
+func TestJWTUtil_Rotation(t *testing.T) {
+	old := newTokens(t, &config.Config{JWTSecret: "old-secret", JWTKeyID: "2024"})
+	token, err := old.GenerateJWT(testClaims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := newTokens(t, &config.Config{JWTSecret: "new-secret", JWTKeyID: "2025", JWTPreviousKeys: "2024:HS256:old-secret"})
+	if _, err := rotated.ValidateJWT(token); err != nil {
+		t.Errorf("token signed by the previous key should still be accepted: %v", err)
+	}
+
+	// Setelah token lama kedaluwarsa, kunci lama tidak lagi berguna
+	rotated.now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+	if _, err := rotated.ValidateJWT(token); !errors.Is(err, jwt.ErrTokenExpired) {
+		t.Errorf("expected expired token, got %v", err)
+	}
+
+	retired := newTokens(t, &config.Config{JWTSecret: "new-secret", JWTKeyID: "2025"})
+	if _, err := retired.ValidateJWT(token); err == nil {
+		t.Error("token signed by a removed key should be rejected")
+	}
+
+	fresh, _ := rotated.GenerateJWT(testClaims)
+	header, _, _ := new(jwt.Parser).ParseUnverified(fresh, &Claims{})
+	if header.Header["kid"] != "2025" {
+		t.Errorf("new tokens should be signed with the current key, got kid %v", header.Header["kid"])
+	}
+}
+
+func TestJWTUtil_RejectsForeignTokens(t *testing.T) {
+	tokens := newTokens(t, &config.Config{JWTSecret: "secret", JWTKeyID: "k1"})
+
+	otherIssuer := newTokens(t, &config.Config{JWTSecret: "secret", JWTKeyID: "k1", JWTIssuer: "someone-else"})
+	token, _ := otherIssuer.GenerateJWT(testClaims)
+	if _, err := tokens.ValidateJWT(token); !errors.Is(err, jwt.ErrTokenInvalidIssuer) {
+		t.Errorf("expected invalid issuer, got %v", err)
+	}
+
+	otherAudience := newTokens(t, &config.Config{JWTSecret: "secret", JWTKeyID: "k1", JWTAudience: "lms"})
+	token, _ = otherAudience.GenerateJWT(testClaims)
+	if _, err := tokens.ValidateJWT(token); !errors.Is(err, jwt.ErrTokenInvalidAudience) {
+		t.Errorf("expected invalid audience, got %v", err)
+	}
+
+	// Token tanpa exp tidak pernah diterima
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 1, "role": "admin", "iss": defaultJWTIssuer, "aud": defaultJWTAudience})
+	unsigned.Header["kid"] = "k1"
+	token, _ = unsigned.SignedString([]byte("secret"))
+	if _, err := tokens.ValidateJWT(token); err == nil {
+		t.Error("token without expiry should be rejected")
+	}
+}
+
+func TestJWTUtil_RejectsAlgorithmMismatch(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	tokens := newTokens(t, &config.Config{JWTAlgorithm: "RS256", JWTKeyID: "rsa", JWTPrivateKeyFile: writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))})
+
+	// Menandatangani HS256 dengan kunci publik sebagai secret adalah serangan klasik
+	publicDER, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims)
+	forged.Header["kid"] = "rsa"
+	token, _ := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if _, err := tokens.ValidateJWT(token); err == nil {
+		t.Error("HS256 token should not validate against an RS256 key")
+	}
+}
+
+func TestNewJWTUtil_Configuration(t *testing.T) {
+	if _, err := NewJWTUtil(&config.Config{AppEnv: "production"}); !errors.Is(err, ErrMissingJWTSecret) {
+		t.Errorf("expected missing secret error in production, got %v", err)
+	}
+	if _, err := NewJWTUtil(&config.Config{}); !errors.Is(err, ErrMissingJWTSecret) {
+		t.Errorf("an unset APP_ENV should count as production, got %v", err)
+	}
+	if _, err := NewJWTUtil(&config.Config{AppEnv: "local"}); err != nil {
+		t.Errorf("development should fall back to a random secret, got %v", err)
+	}
+
+	invalid := []*config.Config{
+		{JWTSecret: "secret", JWTAlgorithm: "none"},
+		{JWTSecret: "secret", JWTExpire: "forever"},
+		{JWTAlgorithm: "RS256"},
+		{JWTSecret: "secret", JWTPreviousKeys: "only-a-kid"},
+		{JWTSecret: "secret", JWTKeyID: "k1", JWTPreviousKeys: "k1:HS256:old"},
+	}
+	for _, cfg := range invalid {
+		if _, err := NewJWTUtil(cfg); err == nil {
+			t.Errorf("expected configuration %+v to be rejected", cfg)
+		}
+	}
+}
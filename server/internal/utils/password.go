@@ -1,6 +1,14 @@
 package utils
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"tsukamoto/config"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -18,3 +26,96 @@ func CheckPassword(password, hashedPassword string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
+
+// DefaultAdminPassword is the password of the seeded admin account
+const DefaultAdminPassword = "admin123"
+
+// IsDefaultPassword reports whether password is one the system hands out: the
+// seeded admin password, or the username imported students start with
+func IsDefaultPassword(password, username string) bool {
+	return password == DefaultAdminPassword || (username != "" && strings.EqualFold(password, username))
+}
+
+// bcrypt mengabaikan byte setelah 72, jadi password lebih panjang ditolak
+const maxPasswordLength = 72
+
+// PasswordPolicy describes the passwords users may choose
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// DefaultPasswordPolicy requires eight characters with a lowercase letter and a digit
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 8, RequireLower: true, RequireDigit: true}
+}
+
+// PasswordPolicyFromConfig overrides the default policy with PASSWORD_* settings
+func PasswordPolicyFromConfig(cfg *config.Config) PasswordPolicy {
+	policy := DefaultPasswordPolicy()
+	if value, err := strconv.Atoi(cfg.PasswordMinLength); err == nil && value > 0 && value <= maxPasswordLength {
+		policy.MinLength = value
+	}
+	for _, setting := range []struct {
+		value string
+		field *bool
+	}{
+		{cfg.PasswordRequireUpper, &policy.RequireUpper},
+		{cfg.PasswordRequireLower, &policy.RequireLower},
+		{cfg.PasswordRequireDigit, &policy.RequireDigit},
+		{cfg.PasswordRequireSymbol, &policy.RequireSymbol},
+	} {
+		if parsed, err := strconv.ParseBool(setting.value); err == nil {
+			*setting.field = parsed
+		}
+	}
+	return policy
+}
+
+// Validate returns the rules the password breaks, empty when it is acceptable
+func (p PasswordPolicy) Validate(password, username string) []string {
+	var problems []string
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		problems = append(problems, fmt.Sprintf("Password must be at least %d characters", p.MinLength))
+	}
+	if len(password) > maxPasswordLength {
+		problems = append(problems, fmt.Sprintf("Password must be at most %d bytes", maxPasswordLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		problems = append(problems, "Password must contain an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		problems = append(problems, "Password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		problems = append(problems, "Password must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		problems = append(problems, "Password must contain a symbol")
+	}
+	if username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		problems = append(problems, "Password must not contain the username")
+	}
+	if password == DefaultAdminPassword {
+		problems = append(problems, "Password must not be a default password")
+	}
+	return problems
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"tsukamoto/config"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{"secret123", true},
+		{"short1", false},
+		{"nodigitshere", false},
+		{"12345678", false},
+		{"budi-2024x", false},
+		{DefaultAdminPassword, false},
+		{strings.Repeat("a1", 37), false},
+	}
+	for _, tt := range tests {
+		problems := policy.Validate(tt.password, "Budi")
+		if (len(problems) == 0) != tt.valid {
+			t.Errorf("%q: expected valid=%v, got %v", tt.password, tt.valid, problems)
+		}
+	}
+}
+
+func TestIsDefaultPassword(t *testing.T) {
+	tests := []struct {
+		password, username string
+		want               bool
+	}{
+		{"admin123", "admin", true},
+		{"student42", "student42", true},
+		{"Student42", "student42", true},
+		{"secret123", "student42", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := IsDefaultPassword(tt.password, tt.username); got != tt.want {
+			t.Errorf("IsDefaultPassword(%q, %q) = %v, want %v", tt.password, tt.username, got, tt.want)
+		}
+	}
+}
+
+func TestPasswordPolicyFromConfig(t *testing.T) {
+	policy := PasswordPolicyFromConfig(&config.Config{
+		PasswordMinLength:     "12",
+		PasswordRequireUpper:  "true",
+		PasswordRequireDigit:  "false",
+		PasswordRequireSymbol: "1",
+	})
+	want := PasswordPolicy{MinLength: 12, RequireUpper: true, RequireLower: true, RequireSymbol: true}
+	if policy != want {
+		t.Errorf("expected %+v, got %+v", want, policy)
+	}
+
+	if problems := policy.Validate("Long-password", ""); len(problems) != 0 {
+		t.Errorf("expected password to pass, got %v", problems)
+	}
+
+	// Nilai yang tidak valid diabaikan
+	if policy := PasswordPolicyFromConfig(&config.Config{PasswordMinLength: "100", PasswordRequireLower: "maybe"}); policy != DefaultPasswordPolicy() {
+		t.Errorf("invalid settings should keep the defaults, got %+v", policy)
+	}
+}
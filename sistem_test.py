@@ -5,6 +5,7 @@ from sklearn.metrics import confusion_matrix, classification_report, accuracy_sc
 import matplotlib.pyplot as plt
 import seaborn as sns
 import time
+import os
 
 def load_csv_data(filename):
     """Load dan bersihkan data CSV"""
@@ -17,11 +18,31 @@ def load_csv_data(filename):
         print(f"Error loading CSV: {e}")
         return None
 
-def get_api_prediction(student_id, base_url="http://localhost:8080/fuzzy"):
-    """Ambil prediksi dari API sistem fuzzy"""
+def login(server_url, username, password):
+    """Login ke API dan kembalikan access token, atau None bila gagal"""
+    try:
+        response = requests.post(f"{server_url}/auth/login", json={"username": username, "password": password}, timeout=10)
+    except requests.exceptions.RequestException as e:
+        print(f"Login gagal: {e}")
+        return None
+
+    if response.status_code != 200:
+        print(f"Login gagal untuk {username}: Status {response.status_code}")
+        return None
+    data = response.json().get('data') or {}
+    if data.get('must_change_password'):
+        # Token ini hanya berlaku untuk mengganti password
+        print(f"Password {username} harus diganti dulu lewat aplikasi sebelum testing")
+        return None
+    return data.get('token')
+
+def get_api_prediction(student_id, base_url="http://localhost:8080/fuzzy", method=None, token=None):
+    """Ambil prediksi dari API sistem fuzzy (method: tsukamoto, mamdani, mamdani_product, sugeno)"""
     try:
         url = f"{base_url}/{student_id}"
-        response = requests.get(url, timeout=10)
+        params = {"method": method} if method else None
+        headers = {"Authorization": f"Bearer {token}"} if token else None
+        response = requests.get(url, params=params, headers=headers, timeout=10)
         
         if response.status_code == 200:
             result = response.json()
@@ -50,6 +71,8 @@ def get_api_prediction(student_id, base_url="http://localhost:8080/fuzzy"):
             print(f"API Error for ID {student_id}: Status {response.status_code}")
             if response.status_code == 404:
                 print(f"  Student ID {student_id} not found")
+            elif response.status_code in (401, 403):
+                print("  Token tidak valid atau tidak punya akses ke data ini")
             return None
             
     except requests.exceptions.Timeout:
@@ -79,7 +102,7 @@ def normalize_performance_labels(label):
             return 'Excellent'
     return label
 
-def test_system_accuracy(csv_file, max_records=1000, start_id=2, api_base_url="http://localhost:8080/fuzzy"):
+def test_system_accuracy(csv_file, max_records=1000, start_id=2, api_base_url="http://localhost:8080/fuzzy", method=None, token=None):
     """Test akurasi sistem dengan confusion matrix"""
     
     print("=== TESTING CONFUSION MATRIX SISTEM FUZZY ===\n")
@@ -105,7 +128,7 @@ def test_system_accuracy(csv_file, max_records=1000, start_id=2, api_base_url="h
     print("Testing koneksi API...")
     
     # Test koneksi pertama
-    test_prediction = get_api_prediction(start_id, base_url=api_base_url)
+    test_prediction = get_api_prediction(start_id, base_url=api_base_url, method=method, token=token)
     if test_prediction is None:
         print("❌ Tidak dapat terhubung ke API. Pastikan:")
         print("   1. Server berjalan di", api_base_url)
@@ -135,7 +158,7 @@ def test_system_accuracy(csv_file, max_records=1000, start_id=2, api_base_url="h
         actual_label = normalize_performance_labels(actual_label)
         
         # Ambil prediksi dari API
-        predicted_label = get_api_prediction(system_id, base_url=api_base_url)
+        predicted_label = get_api_prediction(system_id, base_url=api_base_url, method=method, token=token)
         predicted_label = normalize_performance_labels(predicted_label)
         
         if predicted_label is not None:
@@ -277,20 +300,34 @@ def custom_test():
     CSV_FILE = "./server/docs/datatest_beta_2.csv"  # Path ke file CSV
     MAX_RECORDS = 1000               # Maksimal records untuk ditest
     START_ID = 2                     # ID awal di sistem (karena ID 1 mungkin admin)
-    API_BASE_URL = "http://localhost:8080/fuzzy"  # Base URL API
+    SERVER_URL = "http://localhost:8080"  # Alamat server API
+    API_BASE_URL = f"{SERVER_URL}/fuzzy"  # Base URL API
+    METHOD = "tsukamoto"             # Metode inferensi: tsukamoto, mamdani, mamdani_product, sugeno
+    # Akun untuk login; endpoint /fuzzy hanya bisa diakses dengan token
+    USERNAME = os.environ.get("API_USERNAME", "admin")
+    PASSWORD = os.environ.get("API_PASSWORD", "")
     
     print(f"📁 CSV File: {CSV_FILE}")
     print(f"📊 Max Records: {MAX_RECORDS}")
     print(f"🆔 Start ID: {START_ID}")
     print(f"🌐 API URL: {API_BASE_URL}")
+    print(f"🧮 Method: {METHOD}")
+    print(f"👤 User: {USERNAME}")
     print()
     
+    token = login(SERVER_URL, USERNAME, PASSWORD)
+    if token is None:
+        print("❌ Tidak dapat login. Set API_USERNAME dan API_PASSWORD dengan akun admin.")
+        return None
+
     # Jalankan testing
     results = test_system_accuracy(
         csv_file=CSV_FILE,
         max_records=MAX_RECORDS,
         start_id=START_ID,
-        api_base_url=API_BASE_URL
+        api_base_url=API_BASE_URL,
+        method=METHOD,
+        token=token
     )
     
     return results
@@ -302,6 +339,7 @@ if __name__ == "__main__":
     print("✅ Server API berjalan di http://localhost:8080")
     print("✅ File datatest_beta_2.csv tersedia")
     print("✅ Data student tersedia mulai dari ID 2")
+    print("✅ API_USERNAME dan API_PASSWORD berisi akun admin")
     print("=" * 50)
     print()
     